@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // Common errors
@@ -69,6 +70,16 @@ func HasLabel(issue *Issue, label string) bool {
 	return false
 }
 
+// IsMergeRequest reports whether an issue is a merge request. MRs are
+// identified by the gt:merge-request label; the deprecated issue_type is only
+// a fallback, since MRs created by `gt done` may have type "task" (#816).
+func IsMergeRequest(issue *Issue) bool {
+	if issue == nil {
+		return false
+	}
+	return HasLabel(issue, "gt:merge-request") || issue.Type == "merge-request"
+}
+
 // IssueDep represents a dependency or dependent issue with its relation.
 type IssueDep struct {
 	ID             string `json:"id"`
@@ -81,13 +92,19 @@ type IssueDep struct {
 
 // ListOptions specifies filters for listing issues.
 type ListOptions struct {
-	Status     string // "open", "closed", "all"
-	Type       string // Deprecated: use Label instead. "task", "bug", "feature", "epic"
-	Label      string // Label filter (e.g., "gt:agent", "gt:merge-request")
-	Priority   int    // 0-4, -1 for no filter
-	Parent     string // filter by parent ID
-	Assignee   string // filter by assignee (e.g., "gastown/Toast")
-	NoAssignee bool   // filter for issues with no assignee
+	Status     string   // "open", "closed", "all"
+	Type       string   // Deprecated: use Label instead. "task", "bug", "feature", "epic"
+	Label      string   // Label filter (e.g., "gt:agent", "gt:merge-request")
+	Labels     []string // Additional labels; issues must have all of them (AND)
+	AnyLabels  []string // Issues must have at least one of these labels (OR)
+	Priority   int      // 0-4, -1 for no filter
+	Parent     string   // filter by parent ID
+	Assignee   string   // filter by assignee (e.g., "gastown/Toast")
+	NoAssignee bool     // filter for issues with no assignee
+	Limit      int      // max results to return (0 = bd default)
+	Offset     int      // number of results to skip (for paging)
+	SortBy     string   // sort field passed to bd (e.g., "priority", "created", "updated"); "" = bd default
+	Descending bool     // reverse the SortBy order (ignored without SortBy)
 }
 
 // CreateOptions specifies options for creating an issue.
@@ -97,8 +114,9 @@ type CreateOptions struct {
 	Priority    int    // 0-4
 	Description string
 	Parent      string
-	Actor       string // Who is creating this issue (populates created_by)
-	Ephemeral   bool   // Create as ephemeral (wisp) - not exported to JSONL
+	Labels      []string // Extra labels, added alongside the gt:<type> label
+	Actor       string   // Who is creating this issue (populates created_by)
+	Ephemeral   bool     // Create as ephemeral (wisp) - not exported to JSONL
 }
 
 // UpdateOptions specifies options for updating an issue.
@@ -108,6 +126,7 @@ type UpdateOptions struct {
 	Priority     *int
 	Description  *string
 	Assignee     *string
+	Type         *string
 	AddLabels    []string // Labels to add
 	RemoveLabels []string // Labels to remove
 	SetLabels    []string // Labels to set (replaces all existing)
@@ -131,6 +150,9 @@ type Beads struct {
 	// Populated on first call to getTownRoot() to avoid filesystem walk on every operation.
 	townRoot     string
 	townRootOnce sync.Once
+
+	// runner executes bd; nil means bdRunner with the environment built by run.
+	runner util.Runner
 }
 
 // New creates a new Beads wrapper for the given directory.
@@ -151,6 +173,13 @@ func NewWithBeadsDir(workDir, beadsDir string) *Beads {
 	return &Beads{workDir: workDir, beadsDir: beadsDir}
 }
 
+// NewWithRunner creates a Beads wrapper that executes bd through r, so tests
+// can substitute a fake. r receives the same arguments as the real bd, but
+// not the BEADS_DIR environment run would otherwise set.
+func NewWithRunner(workDir string, r util.Runner) *Beads {
+	return &Beads{workDir: workDir, runner: r}
+}
+
 // getActor returns the BD_ACTOR value for this context.
 // Returns empty string when in isolated mode (tests) to prevent
 // inherited actors from routing to production databases.
@@ -211,36 +240,79 @@ func (b *Beads) run(args ...string) ([]byte, error) {
 		fullArgs = append([]string{"--db", beadsDB}, fullArgs...)
 	}
 
-	cmd := exec.Command("bd", fullArgs...) //nolint:gosec // G204: bd is a trusted internal tool
-	cmd.Dir = b.workDir
+	r := b.runner
+	if r == nil {
+		// Build environment: filter beads env vars when in isolated mode (tests)
+		// to prevent routing to production databases.
+		var env []string
+		if b.isolated {
+			env = filterBeadsEnv(os.Environ())
+		} else {
+			env = os.Environ()
+		}
+		r = bdRunner{env: append(env, "BEADS_DIR="+beadsDir), requireOutput: true}
+	}
 
-	// Build environment: filter beads env vars when in isolated mode (tests)
-	// to prevent routing to production databases.
-	var env []string
-	if b.isolated {
-		env = filterBeadsEnv(os.Environ())
-	} else {
-		env = os.Environ()
+	stdout, err := r.Run(b.workDir, "bd", fullArgs...)
+	if err != nil {
+		// Like util.ExecWithOutput, a Runner reports stderr as the error text
+		stderr := err.Error()
+		var be *bdError
+		if errors.As(err, &be) {
+			err, stderr = be.err, be.stderr
+		}
+		return nil, b.wrapError(err, stderr, args)
 	}
-	cmd.Env = append(env, "BEADS_DIR="+beadsDir)
+	return []byte(stdout), nil
+}
+
+// errNoOutput is returned by bdRunner for bd's exit code 0 bug: when an
+// issue is not found, bd may exit 0 but write the error to stderr with
+// empty stdout.
+var errNoOutput = errors.New("command produced no output")
+
+// bdError is a failed bd run, keeping stderr apart from the underlying
+// error so wrapError can tell a missing binary from a bd failure.
+type bdError struct {
+	err    error
+	stderr string
+}
+
+func (e *bdError) Error() string {
+	if e.stderr != "" {
+		return e.stderr
+	}
+	return e.err.Error()
+}
+
+func (e *bdError) Unwrap() error { return e.err }
+
+// bdRunner is the util.Runner that executes bd for real with env. Like
+// util.ExecRunner it returns trimmed stdout. With requireOutput, an exit 0
+// that writes only to stderr is treated as a failure (errNoOutput) to
+// avoid JSON parse failures.
+type bdRunner struct {
+	env           []string
+	requireOutput bool
+}
+
+// Run implements util.Runner.
+func (r bdRunner) Run(dir, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...) //nolint:gosec // G204: bd is a trusted internal tool
+	cmd.Dir = dir
+	cmd.Env = r.env
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
-	if err != nil {
-		return nil, b.wrapError(err, stderr.String(), args)
+	if err := cmd.Run(); err != nil {
+		return "", &bdError{err: err, stderr: strings.TrimSpace(stderr.String())}
 	}
-
-	// Handle bd exit code 0 bug: when issue not found,
-	// bd may exit 0 but write error to stderr with empty stdout.
-	// Detect this case and treat as error to avoid JSON parse failures.
-	if stdout.Len() == 0 && stderr.Len() > 0 {
-		return nil, b.wrapError(fmt.Errorf("command produced no output"), stderr.String(), args)
+	if r.requireOutput && stdout.Len() == 0 && stderr.Len() > 0 {
+		return "", &bdError{err: errNoOutput, stderr: strings.TrimSpace(stderr.String())}
 	}
-
-	return stdout.Bytes(), nil
+	return strings.TrimSpace(stdout.String()), nil
 }
 
 // Run executes a bd command and returns stdout.
@@ -258,7 +330,8 @@ func (b *Beads) wrapError(err error, stderr string, args []string) error {
 	stderr = strings.TrimSpace(stderr)
 
 	// Check for bd not installed
-	if execErr, ok := err.(*exec.Error); ok && errors.Is(execErr.Err, exec.ErrNotFound) {
+	var execErr *exec.Error
+	if errors.As(err, &execErr) && errors.Is(execErr.Err, exec.ErrNotFound) {
 		return ErrNotInstalled
 	}
 
@@ -298,6 +371,90 @@ func filterBeadsEnv(environ []string) []string {
 
 // List returns issues matching the given options.
 func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
+	out, err := b.run(listArgs(opts)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	}
+
+	return issues, nil
+}
+
+// ListPaged returns all issues matching the given options, fetching them in
+// pages of pageSize to bound the size of each bd invocation.
+// opts.Limit and opts.Offset are overridden per page.
+func (b *Beads) ListPaged(opts ListOptions, pageSize int) ([]*Issue, error) {
+	return collectPages(pageSize, func(limit, offset int) ([]*Issue, error) {
+		opts.Limit = limit
+		opts.Offset = offset
+		return b.List(opts)
+	})
+}
+
+// collectPages calls fetch with increasing offsets until a short page is returned,
+// and concatenates the results. A non-positive pageSize fetches everything at once.
+func collectPages(pageSize int, fetch func(limit, offset int) ([]*Issue, error)) ([]*Issue, error) {
+	if pageSize <= 0 {
+		return fetch(0, 0)
+	}
+
+	var all []*Issue
+	for offset := 0; ; offset += pageSize {
+		page, err := fetch(pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// Children returns all direct children of parentID, across every status
+// and priority.
+func (b *Beads) Children(parentID string) ([]*Issue, error) {
+	return b.List(ListOptions{
+		Parent:   parentID,
+		Status:   "all",
+		Priority: -1, // No priority filter
+	})
+}
+
+// ChildrenStats returns child counts for parentID by status.
+// total includes statuses other than open, in_progress, and closed
+// (e.g., blocked or hooked), which are not broken out separately.
+func (b *Beads) ChildrenStats(parentID string) (total, closed, open, inProgress int, err error) {
+	children, err := b.Children(parentID)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	total, closed, open, inProgress = countChildStatuses(children)
+	return total, closed, open, inProgress, nil
+}
+
+// countChildStatuses tallies issues by status for ChildrenStats.
+func countChildStatuses(children []*Issue) (total, closed, open, inProgress int) {
+	for _, child := range children {
+		total++
+		switch child.Status {
+		case "closed":
+			closed++
+		case "open":
+			open++
+		case "in_progress":
+			inProgress++
+		}
+	}
+	return total, closed, open, inProgress
+}
+
+// listArgs builds the bd list arguments for the given options.
+func listArgs(opts ListOptions) []string {
 	args := []string{"list", "--json"}
 
 	if opts.Status != "" {
@@ -310,6 +467,12 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 		// Deprecated: convert type to label for backward compatibility
 		args = append(args, "--label=gt:"+opts.Type)
 	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label="+label)
+	}
+	if len(opts.AnyLabels) > 0 {
+		args = append(args, "--label-any="+strings.Join(opts.AnyLabels, ","))
+	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
 	}
@@ -322,18 +485,20 @@ func (b *Beads) List(opts ListOptions) ([]*Issue, error) {
 	if opts.NoAssignee {
 		args = append(args, "--no-assignee")
 	}
-
-	out, err := b.run(args...)
-	if err != nil {
-		return nil, err
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("--limit=%d", opts.Limit))
 	}
-
-	var issues []*Issue
-	if err := json.Unmarshal(out, &issues); err != nil {
-		return nil, fmt.Errorf("parsing bd list output: %w", err)
+	if opts.Offset > 0 {
+		args = append(args, fmt.Sprintf("--offset=%d", opts.Offset))
+	}
+	if opts.SortBy != "" {
+		args = append(args, "--sort="+opts.SortBy)
+		if opts.Descending {
+			args = append(args, "--reverse")
+		}
 	}
 
-	return issues, nil
+	return args
 }
 
 // ListByAssignee returns all issues assigned to a specific assignee.
@@ -492,51 +657,17 @@ func (b *Beads) Blocked() ([]*Issue, error) {
 }
 
 // Create creates a new issue and returns it.
+// The generated ID is available as the returned issue's ID.
 // If opts.Actor is empty, it defaults to the BD_ACTOR environment variable.
 // This ensures created_by is populated for issue provenance tracking.
 func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
-	args := []string{"create", "--json"}
-
-	if opts.Title != "" {
-		args = append(args, "--title="+opts.Title)
-	}
-	// Type is deprecated: convert to gt:<type> label
-	if opts.Type != "" {
-		args = append(args, "--labels=gt:"+opts.Type)
-	}
-	if opts.Priority >= 0 {
-		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
-	}
-	if opts.Description != "" {
-		args = append(args, "--description="+opts.Description)
-	}
-	if opts.Parent != "" {
-		args = append(args, "--parent="+opts.Parent)
-	}
-	if opts.Ephemeral {
-		args = append(args, "--ephemeral")
-	}
-	// Default Actor from BD_ACTOR env var if not specified
-	// Uses getActor() to respect isolated mode (tests)
-	actor := opts.Actor
-	if actor == "" {
-		actor = b.getActor()
-	}
-	if actor != "" {
-		args = append(args, "--actor="+actor)
-	}
+	args := append([]string{"create", "--json"}, createArgs(opts, b.createActor(opts))...)
 
 	out, err := b.run(args...)
 	if err != nil {
 		return nil, err
 	}
-
-	var issue Issue
-	if err := json.Unmarshal(out, &issue); err != nil {
-		return nil, fmt.Errorf("parsing bd create output: %w", err)
-	}
-
-	return &issue, nil
+	return parseCreateOutput(out)
 }
 
 // CreateWithID creates an issue with a specific ID.
@@ -547,13 +678,39 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 	if NeedsForceForID(id) {
 		args = append(args, "--force")
 	}
+	args = append(args, createArgs(opts, b.createActor(opts))...)
+
+	out, err := b.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCreateOutput(out)
+}
+
+// createActor returns opts.Actor, defaulting to BD_ACTOR.
+// Uses getActor() to respect isolated mode (tests).
+func (b *Beads) createActor(opts CreateOptions) string {
+	if opts.Actor != "" {
+		return opts.Actor
+	}
+	return b.getActor()
+}
+
+// createArgs builds the bd create flags for the given options.
+func createArgs(opts CreateOptions, actor string) []string {
+	var args []string
 
 	if opts.Title != "" {
 		args = append(args, "--title="+opts.Title)
 	}
 	// Type is deprecated: convert to gt:<type> label
+	var labels []string
 	if opts.Type != "" {
-		args = append(args, "--labels=gt:"+opts.Type)
+		labels = append(labels, "gt:"+opts.Type)
+	}
+	labels = append(labels, opts.Labels...)
+	if len(labels) > 0 {
+		args = append(args, "--labels="+strings.Join(labels, ","))
 	}
 	if opts.Priority >= 0 {
 		args = append(args, fmt.Sprintf("--priority=%d", opts.Priority))
@@ -564,31 +721,33 @@ func (b *Beads) CreateWithID(id string, opts CreateOptions) (*Issue, error) {
 	if opts.Parent != "" {
 		args = append(args, "--parent="+opts.Parent)
 	}
-	// Default Actor from BD_ACTOR env var if not specified
-	// Uses getActor() to respect isolated mode (tests)
-	actor := opts.Actor
-	if actor == "" {
-		actor = b.getActor()
+	if opts.Ephemeral {
+		args = append(args, "--ephemeral")
 	}
 	if actor != "" {
 		args = append(args, "--actor="+actor)
 	}
 
-	out, err := b.run(args...)
-	if err != nil {
-		return nil, err
-	}
+	return args
+}
 
+// parseCreateOutput parses the issue printed by bd create --json.
+func parseCreateOutput(out []byte) (*Issue, error) {
 	var issue Issue
 	if err := json.Unmarshal(out, &issue); err != nil {
 		return nil, fmt.Errorf("parsing bd create output: %w", err)
 	}
-
 	return &issue, nil
 }
 
 // Update updates an existing issue.
 func (b *Beads) Update(id string, opts UpdateOptions) error {
+	_, err := b.run(updateArgs(id, opts)...)
+	return err
+}
+
+// updateArgs builds the bd update arguments for the given options.
+func updateArgs(id string, opts UpdateOptions) []string {
 	args := []string{"update", id}
 
 	if opts.Title != nil {
@@ -606,6 +765,9 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 	if opts.Assignee != nil {
 		args = append(args, "--assignee="+*opts.Assignee)
 	}
+	if opts.Type != nil {
+		args = append(args, "--type="+*opts.Type)
+	}
 	// Label operations: set-labels replaces all, otherwise use add/remove
 	if len(opts.SetLabels) > 0 {
 		for _, label := range opts.SetLabels {
@@ -619,8 +781,70 @@ func (b *Beads) Update(id string, opts UpdateOptions) error {
 			args = append(args, "--remove-label="+label)
 		}
 	}
+	return args
+}
 
-	_, err := b.run(args...)
+// AddLabel adds label to an issue. It is a no-op if the issue already has it.
+func (b *Beads) AddLabel(id, label string) error {
+	return updateLabel(id, label, true, b.Show, b.run)
+}
+
+// RemoveLabel removes label from an issue. It is a no-op if the issue
+// doesn't have it.
+func (b *Beads) RemoveLabel(id, label string) error {
+	return updateLabel(id, label, false, b.Show, b.run)
+}
+
+// updateLabel implements AddLabel and RemoveLabel with the issue lookup and
+// bd invocation supplied by the caller. The issue is checked first so that
+// repeating a change doesn't issue a redundant bd update.
+func updateLabel(id, label string, add bool, show func(string) (*Issue, error), run func(args ...string) ([]byte, error)) error {
+	issue, err := show(id)
+	if err != nil {
+		return err
+	}
+	if HasLabel(issue, label) == add {
+		return nil
+	}
+
+	var opts UpdateOptions
+	if add {
+		opts.AddLabels = []string{label}
+	} else {
+		opts.RemoveLabels = []string{label}
+	}
+	_, err = run(updateArgs(id, opts)...)
+	return err
+}
+
+// SetParent moves an issue under parentID. It refuses with ErrParentCycle
+// if id is already an ancestor of parentID.
+func (b *Beads) SetParent(id, parentID string) error {
+	if err := CheckReparent(b, id, parentID); err != nil {
+		return err
+	}
+	_, err := b.run("update", id, "--parent="+parentID)
+	return err
+}
+
+// SetType changes an issue's type (e.g., "task" to "merge-request").
+func (b *Beads) SetType(id, issueType string) error {
+	return b.Update(id, UpdateOptions{Type: &issueType})
+}
+
+// SetPriority sets an issue's priority, 0 (highest) through 4. -1 means
+// "unset" only where priorities are filters or defaults (ListOptions,
+// CreateOptions); it can't be stored, so it is rejected here.
+func (b *Beads) SetPriority(id string, priority int) error {
+	if priority < 0 || priority > 4 {
+		return fmt.Errorf("invalid priority %d: must be 0 (highest) to 4", priority)
+	}
+	return b.Update(id, UpdateOptions{Priority: &priority})
+}
+
+// ClearParent detaches an issue from its parent.
+func (b *Beads) ClearParent(id string) error {
+	_, err := b.run("update", id, "--parent=")
 	return err
 }
 
@@ -663,6 +887,30 @@ func (b *Beads) CloseWithReason(reason string, ids ...string) error {
 	return err
 }
 
+// BulkClose closes each issue with its own bd close, so one failure doesn't
+// stop the rest. Returns how many closed and one error per failed ID.
+func (b *Beads) BulkClose(ids []string) (closed int, errs []error) {
+	return bulkClose(ids, func(id string) error { return b.Close(id) })
+}
+
+// BulkCloseWithReason is BulkClose with a resolution reason on each issue.
+func (b *Beads) BulkCloseWithReason(reason string, ids []string) (closed int, errs []error) {
+	return bulkClose(ids, func(id string) error { return b.CloseWithReason(reason, id) })
+}
+
+// bulkClose calls closeOne for every ID, collecting failures rather than
+// stopping at the first. Each error is prefixed with its ID.
+func bulkClose(ids []string, closeOne func(id string) error) (closed int, errs []error) {
+	for _, id := range ids {
+		if err := closeOne(id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		closed++
+	}
+	return closed, errs
+}
+
 // ForceCloseWithReason closes one or more issues with --force, bypassing
 // dependency checks. Used by gt done where the polecat is about to be nuked
 // and open molecule wisps should not block issue closure.
@@ -716,6 +964,47 @@ func (b *Beads) RemoveDependency(issue, dependsOn string) error {
 	return err
 }
 
+// Dependencies returns the blocking dependencies of id, from bd dep list:
+// blockedBy are the issues id depends on, blocks are the issues that depend
+// on id. Only "blocks" links are included, not parent-child or tracking.
+func (b *Beads) Dependencies(id string) (blockedBy, blocks []string, err error) {
+	blockedBy, err = b.depList(id, "down")
+	if err != nil {
+		return nil, nil, err
+	}
+	blocks, err = b.depList(id, "up")
+	if err != nil {
+		return nil, nil, err
+	}
+	return blockedBy, blocks, nil
+}
+
+// depList returns the IDs of id's "blocks" dependencies in one direction.
+func (b *Beads) depList(id, direction string) ([]string, error) {
+	out, err := b.run("dep", "list", id, "--direction="+direction, "-t", "blocks", "--json")
+	if err != nil {
+		return nil, err
+	}
+	return parseDepListIDs(out)
+}
+
+// parseDepListIDs extracts issue IDs from bd dep list --json output.
+// Empty output means no dependencies.
+func parseDepListIDs(out []byte) ([]string, error) {
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	var deps []IssueDep
+	if err := json.Unmarshal(out, &deps); err != nil {
+		return nil, fmt.Errorf("parsing bd dep list output: %w", err)
+	}
+	ids := make([]string, 0, len(deps))
+	for _, d := range deps {
+		ids = append(ids, d.ID)
+	}
+	return ids, nil
+}
+
 // Sync syncs beads with remote.
 func (b *Beads) Sync() error {
 	_, err := b.run("sync")
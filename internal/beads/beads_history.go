@@ -0,0 +1,110 @@
+package beads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrHistoryNotSupported is returned by History when the beads backend keeps
+// no per-issue history. bd history needs the Dolt backend.
+var ErrHistoryNotSupported = errors.New("history not supported by this beads backend (requires Dolt)")
+
+// StateChange is one recorded change to an issue, derived from consecutive
+// snapshots in the backend's history.
+type StateChange struct {
+	Timestamp string `json:"timestamp"`        // When the change was committed
+	Actor     string `json:"actor,omitempty"`  // Committer recorded by the backend
+	Commit    string `json:"commit,omitempty"` // Backend commit hash
+	Field     string `json:"field"`            // Changed field, or "created" for the first snapshot
+	From      string `json:"from,omitempty"`   // Previous value
+	To        string `json:"to,omitempty"`     // New value
+}
+
+// historyEntry is one snapshot of an issue from bd history --json.
+type historyEntry struct {
+	CommitHash string `json:"commit_hash"`
+	Committer  string `json:"committer"`
+	CommitDate string `json:"commit_date"`
+	Issue      Issue  `json:"issue"`
+}
+
+// History returns the recorded changes to an issue, oldest first. It returns
+// ErrHistoryNotSupported, not an empty history, when the backend has none.
+func (b *Beads) History(id string) ([]StateChange, error) {
+	return issueHistory(id, b.run)
+}
+
+// issueHistory implements History with the bd invocation supplied by the caller.
+func issueHistory(id string, run func(args ...string) ([]byte, error)) ([]StateChange, error) {
+	out, err := run("history", id, "--json")
+	if err != nil {
+		if isUnknownCommand(err) || isHistoryUnsupported(err) {
+			return nil, ErrHistoryNotSupported
+		}
+		return nil, err
+	}
+	var entries []historyEntry
+	if err := json.Unmarshal(out, &entries); err != nil {
+		return nil, fmt.Errorf("parsing bd history output: %w", err)
+	}
+	return historyChanges(entries), nil
+}
+
+// isHistoryUnsupported reports whether bd refused history for the backend
+// (e.g., SQLite), as opposed to failing for some other reason.
+func isHistoryUnsupported(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not supported") || strings.Contains(msg, "requires dolt")
+}
+
+// historyChanges turns snapshots into the field changes between them, in
+// commit order. The first snapshot is reported as "created" with its status.
+func historyChanges(entries []historyEntry) []StateChange {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].CommitDate < entries[j].CommitDate })
+
+	var changes []StateChange
+	for i, e := range entries {
+		at := StateChange{Timestamp: e.CommitDate, Actor: e.Committer, Commit: e.CommitHash}
+		if i == 0 {
+			at.Field, at.To = "created", e.Issue.Status
+			changes = append(changes, at)
+			continue
+		}
+		for _, f := range diffIssueFields(&entries[i-1].Issue, &e.Issue) {
+			c := at
+			c.Field, c.From, c.To = f.name, f.from, f.to
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+type fieldChange struct {
+	name, from, to string
+}
+
+// diffIssueFields lists the tracked fields that differ between two snapshots.
+// Descriptions are reported as edited without their (long) contents.
+func diffIssueFields(prev, cur *Issue) []fieldChange {
+	var diffs []fieldChange
+	add := func(name, from, to string) {
+		if from != to {
+			diffs = append(diffs, fieldChange{name, from, to})
+		}
+	}
+	add("status", prev.Status, cur.Status)
+	add("priority", "P"+strconv.Itoa(prev.Priority), "P"+strconv.Itoa(cur.Priority))
+	add("assignee", prev.Assignee, cur.Assignee)
+	add("title", prev.Title, cur.Title)
+	add("type", prev.Type, cur.Type)
+	add("parent", prev.Parent, cur.Parent)
+	add("labels", strings.Join(prev.Labels, ","), strings.Join(cur.Labels, ","))
+	if prev.Description != cur.Description {
+		diffs = append(diffs, fieldChange{name: "description", to: "(edited)"})
+	}
+	return diffs
+}
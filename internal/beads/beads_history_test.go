@@ -0,0 +1,72 @@
+package beads
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// historyFixture is bd history --json for an epic that was created, worked,
+// closed by a forced land, then reopened. bd lists newest first.
+const historyFixture = `[
+  {"commit_hash":"d4","committer":"gastown/crew/max","commit_date":"2026-01-15T12:00:00Z",
+   "issue":{"id":"gt-epic","title":"Auth","status":"open","priority":1,"issue_type":"epic","assignee":"gastown/crew/max","description":"integration_branch: integration/gt-epic"}},
+  {"commit_hash":"c3","committer":"gastown/refinery","commit_date":"2026-01-15T11:00:00Z",
+   "issue":{"id":"gt-epic","title":"Auth","status":"closed","priority":1,"issue_type":"epic","description":"integration_branch: integration/gt-epic"}},
+  {"commit_hash":"a1","committer":"mayor","commit_date":"2026-01-15T09:00:00Z",
+   "issue":{"id":"gt-epic","title":"Auth","status":"open","priority":2,"issue_type":"epic"}},
+  {"commit_hash":"b2","committer":"mayor","commit_date":"2026-01-15T10:00:00Z",
+   "issue":{"id":"gt-epic","title":"Auth","status":"in_progress","priority":1,"issue_type":"epic","description":"integration_branch: integration/gt-epic"}}
+]`
+
+func TestIssueHistory_Fixture(t *testing.T) {
+	var gotArgs []string
+	run := func(args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(historyFixture), nil
+	}
+
+	changes, err := issueHistory("gt-epic", run)
+	if err != nil {
+		t.Fatalf("issueHistory() error = %v", err)
+	}
+	if want := []string{"history", "gt-epic", "--json"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("bd args = %v, want %v", gotArgs, want)
+	}
+
+	want := []StateChange{
+		{Timestamp: "2026-01-15T09:00:00Z", Actor: "mayor", Commit: "a1", Field: "created", To: "open"},
+		{Timestamp: "2026-01-15T10:00:00Z", Actor: "mayor", Commit: "b2", Field: "status", From: "open", To: "in_progress"},
+		{Timestamp: "2026-01-15T10:00:00Z", Actor: "mayor", Commit: "b2", Field: "priority", From: "P2", To: "P1"},
+		{Timestamp: "2026-01-15T10:00:00Z", Actor: "mayor", Commit: "b2", Field: "description", To: "(edited)"},
+		{Timestamp: "2026-01-15T11:00:00Z", Actor: "gastown/refinery", Commit: "c3", Field: "status", From: "in_progress", To: "closed"},
+		{Timestamp: "2026-01-15T12:00:00Z", Actor: "gastown/crew/max", Commit: "d4", Field: "status", From: "closed", To: "open"},
+		{Timestamp: "2026-01-15T12:00:00Z", Actor: "gastown/crew/max", Commit: "d4", Field: "assignee", To: "gastown/crew/max"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("issueHistory() =\n%+v\nwant\n%+v", changes, want)
+	}
+}
+
+func TestIssueHistory_NotSupported(t *testing.T) {
+	for _, msg := range []string{
+		`bd history gt-epic --json: Error: unknown command "history" for "bd"`,
+		`bd history gt-epic --json: history requires Dolt backend`,
+		`bd history gt-epic --json: Error: history is not supported by the sqlite backend`,
+	} {
+		run := func(args ...string) ([]byte, error) { return nil, errors.New(msg) }
+		changes, err := issueHistory("gt-epic", run)
+		if !errors.Is(err, ErrHistoryNotSupported) {
+			t.Errorf("%q: error = %v, want ErrHistoryNotSupported", msg, err)
+		}
+		if changes != nil {
+			t.Errorf("%q: changes = %v, want nil", msg, changes)
+		}
+	}
+
+	// Other failures are passed through
+	run := func(args ...string) ([]byte, error) { return nil, ErrNotFound }
+	if _, err := issueHistory("gt-nope", run); !errors.Is(err, ErrNotFound) {
+		t.Errorf("error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package beads provides full-text issue search.
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Search returns issues whose text matches query, filtered by opts the same
+// way List filters. It uses bd search when the installed bd supports it and
+// otherwise falls back to listing and matching title and description.
+func (b *Beads) Search(query string, opts ListOptions) ([]*Issue, error) {
+	return searchIssues(query, opts, b.run, b.List)
+}
+
+// searchIssues implements Search with the bd invocation and list fallback
+// supplied by the caller.
+func searchIssues(query string, opts ListOptions, run func(args ...string) ([]byte, error), list func(ListOptions) ([]*Issue, error)) ([]*Issue, error) {
+	out, err := run(searchArgs(query, opts)...)
+	if err == nil {
+		var issues []*Issue
+		if err := json.Unmarshal(out, &issues); err != nil {
+			return nil, fmt.Errorf("parsing bd search output: %w", err)
+		}
+		return issues, nil
+	}
+	if !isUnknownCommand(err) {
+		return nil, err
+	}
+
+	// Older bd without search: filter a regular listing client-side
+	issues, err := list(opts)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*Issue
+	for _, issue := range issues {
+		if issueMatchesQuery(issue, query) {
+			matched = append(matched, issue)
+		}
+	}
+	return matched, nil
+}
+
+// searchArgs builds the bd search arguments: the list filters for opts,
+// then the query after "--" so a query starting with "-" isn't read as a flag.
+func searchArgs(query string, opts ListOptions) []string {
+	args := listArgs(opts)
+	args[0] = "search"
+	return append(args, "--", query)
+}
+
+// isUnknownCommand reports whether err is bd rejecting a subcommand it
+// doesn't have.
+func isUnknownCommand(err error) bool {
+	return strings.Contains(err.Error(), "unknown command")
+}
+
+// issueMatchesQuery reports whether query appears in the issue's title or
+// description, ignoring case.
+func issueMatchesQuery(issue *Issue, query string) bool {
+	q := strings.ToLower(query)
+	return strings.Contains(strings.ToLower(issue.Title), q) ||
+		strings.Contains(strings.ToLower(issue.Description), q)
+}
@@ -0,0 +1,78 @@
+package beads
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSearchArgs(t *testing.T) {
+	got := searchArgs("-auth", ListOptions{Status: "open", Type: "merge-request", Priority: -1, Limit: 20})
+	want := []string{"search", "--json", "--status=open", "--label=gt:merge-request", "--limit=20", "--", "-auth"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("searchArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestSearchIssues_Native(t *testing.T) {
+	var gotArgs []string
+	run := func(args ...string) ([]byte, error) {
+		gotArgs = args
+		return []byte(`[{"id":"gt-mr1","title":"Fix auth token refresh"}]`), nil
+	}
+	list := func(ListOptions) ([]*Issue, error) {
+		t.Fatal("list fallback should not be used when bd search works")
+		return nil, nil
+	}
+
+	issues, err := searchIssues("auth", ListOptions{Label: "gt:merge-request", Priority: -1}, run, list)
+	if err != nil {
+		t.Fatalf("searchIssues() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].ID != "gt-mr1" {
+		t.Errorf("issues = %+v, want [gt-mr1]", issues)
+	}
+	if len(gotArgs) == 0 || gotArgs[0] != "search" || gotArgs[len(gotArgs)-1] != "auth" {
+		t.Errorf("bd args = %v, want search ... auth", gotArgs)
+	}
+}
+
+func TestSearchIssues_Fallback(t *testing.T) {
+	run := func(args ...string) ([]byte, error) {
+		return nil, errors.New(`bd search --json -- auth: Error: unknown command "search" for "bd"`)
+	}
+	var gotOpts ListOptions
+	list := func(opts ListOptions) ([]*Issue, error) {
+		gotOpts = opts
+		return []*Issue{
+			{ID: "gt-1", Title: "Fix AUTH token refresh"},
+			{ID: "gt-2", Title: "Docs", Description: "Explain the auth flow"},
+			{ID: "gt-3", Title: "Unrelated", Description: "nothing here"},
+		}, nil
+	}
+
+	opts := ListOptions{Status: "open", Label: "gt:merge-request", Priority: -1}
+	issues, err := searchIssues("auth", opts, run, list)
+	if err != nil {
+		t.Fatalf("searchIssues() error = %v", err)
+	}
+	if len(issues) != 2 || issues[0].ID != "gt-1" || issues[1].ID != "gt-2" {
+		t.Errorf("issues = %+v, want gt-1 and gt-2", issues)
+	}
+	if gotOpts.Status != "open" || gotOpts.Label != "gt:merge-request" {
+		t.Errorf("fallback list opts = %+v, want filters passed through", gotOpts)
+	}
+}
+
+func TestSearchIssues_OtherErrorsNotMasked(t *testing.T) {
+	run := func(args ...string) ([]byte, error) {
+		return nil, errors.New("bd search: database locked")
+	}
+	list := func(ListOptions) ([]*Issue, error) {
+		t.Fatal("list fallback should only be used when search is unsupported")
+		return nil, nil
+	}
+	if _, err := searchIssues("auth", ListOptions{Priority: -1}, run, list); err == nil {
+		t.Error("expected bd error to be returned")
+	}
+}
@@ -2,10 +2,13 @@ package beads
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -33,7 +36,166 @@ func TestListOptions(t *testing.T) {
 	}
 }
 
+// TestListArgs verifies ListOptions are propagated to the bd list invocation.
+func TestListArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts ListOptions
+		want []string
+	}{
+		{
+			name: "no paging",
+			opts: ListOptions{Status: "open", Priority: -1},
+			want: []string{"list", "--json", "--status=open"},
+		},
+		{
+			name: "limit and offset",
+			opts: ListOptions{Type: "merge-request", Priority: -1, Limit: 100, Offset: 200},
+			want: []string{"list", "--json", "--label=gt:merge-request", "--limit=100", "--offset=200"},
+		},
+		{
+			name: "zero offset omitted",
+			opts: ListOptions{Priority: -1, Limit: 50},
+			want: []string{"list", "--json", "--limit=50"},
+		},
+		{
+			name: "labels are ANDed alongside type",
+			opts: ListOptions{Type: "merge-request", Labels: []string{"gt:urgent", "rig:gastown"}, Priority: -1},
+			want: []string{"list", "--json", "--label=gt:merge-request", "--label=gt:urgent", "--label=rig:gastown"},
+		},
+		{
+			name: "any labels are ORed",
+			opts: ListOptions{Status: "open", AnyLabels: []string{"gt:bug", "gt:task"}, Priority: -1},
+			want: []string{"list", "--json", "--status=open", "--label-any=gt:bug,gt:task"},
+		},
+		{
+			name: "labels without label or type",
+			opts: ListOptions{Labels: []string{"gt:merge-request"}, Priority: -1},
+			want: []string{"list", "--json", "--label=gt:merge-request"},
+		},
+		{
+			name: "sort forwarded",
+			opts: ListOptions{Status: "open", Priority: -1, SortBy: "created"},
+			want: []string{"list", "--json", "--status=open", "--sort=created"},
+		},
+		{
+			name: "descending sort",
+			opts: ListOptions{Priority: -1, SortBy: "priority", Descending: true},
+			want: []string{"list", "--json", "--sort=priority", "--reverse"},
+		},
+		{
+			name: "descending without sort field omitted",
+			opts: ListOptions{Priority: -1, Descending: true},
+			want: []string{"list", "--json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := listArgs(tt.opts)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("listArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCollectPages verifies paging assembles the full result set.
+func TestCollectPages(t *testing.T) {
+	var all []*Issue
+	for i := 0; i < 7; i++ {
+		all = append(all, &Issue{ID: fmt.Sprintf("gt-%d", i)})
+	}
+	fetch := func(calls *int) func(limit, offset int) ([]*Issue, error) {
+		return func(limit, offset int) ([]*Issue, error) {
+			*calls++
+			if limit == 0 {
+				return all, nil
+			}
+			if offset >= len(all) {
+				return nil, nil
+			}
+			end := offset + limit
+			if end > len(all) {
+				end = len(all)
+			}
+			return all[offset:end], nil
+		}
+	}
+
+	tests := []struct {
+		name      string
+		pageSize  int
+		wantCalls int
+	}{
+		{name: "unpaged", pageSize: 0, wantCalls: 1},
+		{name: "uneven pages", pageSize: 3, wantCalls: 3},
+		{name: "exact pages", pageSize: 7, wantCalls: 2},
+		{name: "page larger than result", pageSize: 100, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			got, err := collectPages(tt.pageSize, fetch(&calls))
+			if err != nil {
+				t.Fatalf("collectPages() error = %v", err)
+			}
+			if len(got) != len(all) {
+				t.Fatalf("collectPages() returned %d issues, want %d", len(got), len(all))
+			}
+			for i, issue := range got {
+				if issue.ID != all[i].ID {
+					t.Errorf("issue[%d] = %s, want %s", i, issue.ID, all[i].ID)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fetch called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	t.Run("error aborts", func(t *testing.T) {
+		_, err := collectPages(3, func(limit, offset int) ([]*Issue, error) {
+			return nil, fmt.Errorf("boom")
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
 // TestCreateOptions verifies CreateOptions fields.
+func TestCountChildStatuses(t *testing.T) {
+	children := []*Issue{
+		{ID: "gt-epic.1", Status: "closed"},
+		{ID: "gt-epic.2", Status: "open"},
+		{ID: "gt-epic.3", Status: "in_progress"},
+		{ID: "gt-epic.4", Status: "closed"},
+		{ID: "gt-epic.5", Status: "blocked"},
+		{ID: "gt-epic.6", Status: "open"},
+	}
+
+	total, closed, open, inProgress := countChildStatuses(children)
+	if total != 6 {
+		t.Errorf("total = %d, want 6", total)
+	}
+	if closed != 2 {
+		t.Errorf("closed = %d, want 2", closed)
+	}
+	if open != 2 {
+		t.Errorf("open = %d, want 2", open)
+	}
+	if inProgress != 1 {
+		t.Errorf("inProgress = %d, want 1", inProgress)
+	}
+
+	total, closed, open, inProgress = countChildStatuses(nil)
+	if total != 0 || closed != 0 || open != 0 || inProgress != 0 {
+		t.Errorf("countChildStatuses(nil) = %d, %d, %d, %d, want all zero", total, closed, open, inProgress)
+	}
+}
+
 func TestCreateOptions(t *testing.T) {
 	opts := CreateOptions{
 		Title:       "Test issue",
@@ -50,6 +212,71 @@ func TestCreateOptions(t *testing.T) {
 	}
 }
 
+func TestCreateArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  CreateOptions
+		actor string
+		want  []string
+	}{
+		{
+			name: "all fields",
+			opts: CreateOptions{
+				Title:       "Track integration",
+				Type:        "task",
+				Priority:    1,
+				Description: "details",
+				Parent:      "gt-epic",
+				Labels:      []string{"integration", "mq"},
+				Ephemeral:   true,
+			},
+			actor: "gastown/refinery",
+			want: []string{
+				"--title=Track integration",
+				"--labels=gt:task,integration,mq",
+				"--priority=1",
+				"--description=details",
+				"--parent=gt-epic",
+				"--ephemeral",
+				"--actor=gastown/refinery",
+			},
+		},
+		{
+			name: "labels without type",
+			opts: CreateOptions{Title: "x", Priority: -1, Labels: []string{"a"}},
+			want: []string{"--title=x", "--labels=a"},
+		},
+		{
+			name: "negative priority omitted",
+			opts: CreateOptions{Title: "x", Type: "bug", Priority: -1},
+			want: []string{"--title=x", "--labels=gt:bug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := createArgs(tt.opts, tt.actor)
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("createArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCreateOutput(t *testing.T) {
+	issue, err := parseCreateOutput([]byte(`{"id":"gt-x7k2m","title":"Track integration","status":"open"}`))
+	if err != nil {
+		t.Fatalf("parseCreateOutput() error = %v", err)
+	}
+	if issue.ID != "gt-x7k2m" {
+		t.Errorf("ID = %q, want gt-x7k2m", issue.ID)
+	}
+
+	if _, err := parseCreateOutput([]byte("Created gt-x7k2m")); err == nil {
+		t.Error("parseCreateOutput() should fail on non-JSON output")
+	}
+}
+
 // TestUpdateOptions verifies UpdateOptions pointer fields.
 func TestUpdateOptions(t *testing.T) {
 	status := "in_progress"
@@ -66,6 +293,206 @@ func TestUpdateOptions(t *testing.T) {
 	}
 }
 
+func TestUpdateArgs(t *testing.T) {
+	title := "New title"
+	mrType := "merge-request"
+	priority := 1
+	tests := []struct {
+		name string
+		opts UpdateOptions
+		want []string
+	}{
+		{
+			name: "no options",
+			want: []string{"update", "gt-1"},
+		},
+		{
+			name: "add and remove labels",
+			opts: UpdateOptions{AddLabels: []string{"gt:landed", "reviewed"}, RemoveLabels: []string{"gt:pending"}},
+			want: []string{"update", "gt-1", "--add-label=gt:landed", "--add-label=reviewed", "--remove-label=gt:pending"},
+		},
+		{
+			name: "set labels wins over add and remove",
+			opts: UpdateOptions{SetLabels: []string{"a"}, AddLabels: []string{"b"}, RemoveLabels: []string{"c"}},
+			want: []string{"update", "gt-1", "--set-labels=a"},
+		},
+		{
+			name: "type",
+			opts: UpdateOptions{Type: &mrType},
+			want: []string{"update", "gt-1", "--type=merge-request"},
+		},
+		{
+			name: "priority",
+			opts: UpdateOptions{Priority: &priority},
+			want: []string{"update", "gt-1", "--priority=1"},
+		},
+		{
+			name: "title with labels",
+			opts: UpdateOptions{Title: &title, AddLabels: []string{"gt:landed"}},
+			want: []string{"update", "gt-1", "--title=New title", "--add-label=gt:landed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateArgs("gt-1", tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updateArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPriority_Invalid(t *testing.T) {
+	// Rejected before bd runs, so no beads database is needed
+	b := New(t.TempDir())
+	for _, p := range []int{-1, 5} {
+		err := b.SetPriority("gt-1", p)
+		if err == nil || !strings.Contains(err.Error(), "invalid priority") {
+			t.Errorf("SetPriority(%d) error = %v, want invalid priority", p, err)
+		}
+	}
+}
+
+// fakeBdRunner records bd invocations and answers with a fixed result.
+type fakeBdRunner struct {
+	out   string
+	err   error
+	dirs  []string
+	calls [][]string
+}
+
+func (f *fakeBdRunner) Run(dir, name string, args ...string) (string, error) {
+	f.dirs = append(f.dirs, dir)
+	f.calls = append(f.calls, append([]string{name}, args...))
+	return f.out, f.err
+}
+
+func TestBeadsRun_Runner(t *testing.T) {
+	dir := t.TempDir()
+	r := &fakeBdRunner{out: "gt-1"}
+	b := NewWithRunner(dir, r)
+
+	if err := b.SetPriority("gt-1", 0); err != nil {
+		t.Fatalf("SetPriority() error = %v", err)
+	}
+	want := []string{"bd", "--allow-stale", "update", "gt-1", "--priority=0"}
+	if len(r.calls) != 1 || !reflect.DeepEqual(r.calls[0], want) || r.dirs[0] != dir {
+		t.Errorf("ran %v in %v, want %v in %s", r.calls, r.dirs, want, dir)
+	}
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr error
+		wantMsg string
+	}{
+		{name: "not found", err: errors.New("Issue not found: gt-x"), wantErr: ErrNotFound},
+		{name: "not installed", err: &exec.Error{Name: "bd", Err: exec.ErrNotFound}, wantErr: ErrNotInstalled},
+		{name: "bd failure", err: &bdError{err: errors.New("exit status 1"), stderr: "database locked"}, wantMsg: "bd show gt-x: database locked"},
+		{name: "no output", err: &bdError{err: errNoOutput, stderr: "no issue found"}, wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithRunner(dir, &fakeBdRunner{err: tt.err}).Run("show", "gt-x")
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && (err == nil || err.Error() != tt.wantMsg) {
+				t.Errorf("Run() error = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestBdRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX sh")
+	}
+	dir := t.TempDir()
+
+	out, err := bdRunner{}.Run(dir, "sh", "-c", "echo '  ok  '")
+	if err != nil || out != "ok" {
+		t.Errorf("Run() = %q, %v; want trimmed stdout", out, err)
+	}
+
+	// bd's exit-0 bug: only stderr, no stdout
+	_, err = bdRunner{requireOutput: true}.Run(dir, "sh", "-c", "echo 'no issue found' >&2")
+	var be *bdError
+	if !errors.Is(err, errNoOutput) || !errors.As(err, &be) || be.stderr != "no issue found" {
+		t.Errorf("Run() error = %v, want errNoOutput carrying stderr", err)
+	}
+	if _, err := (bdRunner{}).Run(dir, "sh", "-c", "echo warning >&2"); err != nil {
+		t.Errorf("Run() without requireOutput error = %v, want nil", err)
+	}
+
+	_, err = bdRunner{}.Run(dir, "sh", "-c", "echo boom >&2; exit 3")
+	if err == nil || err.Error() != "boom" {
+		t.Errorf("Run() error = %v, want stderr as the message", err)
+	}
+}
+
+func TestUpdateLabel(t *testing.T) {
+	issue := &Issue{ID: "gt-mr", Labels: []string{"gt:merge-request"}}
+	show := func(id string) (*Issue, error) { return issue, nil }
+
+	tests := []struct {
+		name  string
+		label string
+		add   bool
+		want  []string // nil means no bd update
+	}{
+		{name: "add new label", label: "gt:landed", add: true, want: []string{"update", "gt-mr", "--add-label=gt:landed"}},
+		{name: "add existing label is a no-op", label: "gt:merge-request", add: true},
+		{name: "remove existing label", label: "gt:merge-request", want: []string{"update", "gt-mr", "--remove-label=gt:merge-request"}},
+		{name: "remove missing label is a no-op", label: "gt:landed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			run := func(args ...string) ([]byte, error) {
+				got = args
+				return nil, nil
+			}
+			if err := updateLabel("gt-mr", tt.label, tt.add, show, run); err != nil {
+				t.Fatalf("updateLabel() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bd args = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDepListIDs(t *testing.T) {
+	out := []byte(`[{"id":"gt-a","title":"A","status":"open"},{"id":"gt-b","title":"B","status":"closed"}]`)
+	ids, err := parseDepListIDs(out)
+	if err != nil {
+		t.Fatalf("parseDepListIDs() error = %v", err)
+	}
+	if want := []string{"gt-a", "gt-b"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("parseDepListIDs() = %v, want %v", ids, want)
+	}
+
+	if ids, err := parseDepListIDs([]byte("\n")); err != nil || ids != nil {
+		t.Errorf("parseDepListIDs(empty) = %v, %v; want nil, nil", ids, err)
+	}
+	if _, err := parseDepListIDs([]byte("not json")); err == nil {
+		t.Error("parseDepListIDs() should reject malformed output")
+	}
+}
+
+func TestUpdateLabel_ShowError(t *testing.T) {
+	show := func(id string) (*Issue, error) { return nil, ErrNotFound }
+	run := func(args ...string) ([]byte, error) {
+		t.Errorf("bd update should not run when the issue can't be loaded: %q", args)
+		return nil, nil
+	}
+	if err := updateLabel("gt-missing", "gt:landed", true, show, run); !errors.Is(err, ErrNotFound) {
+		t.Errorf("updateLabel() error = %v, want ErrNotFound", err)
+	}
+}
+
 // TestIsBeadsRepo tests repository detection.
 func TestIsBeadsRepo(t *testing.T) {
 	// Test with a non-beads directory
@@ -207,6 +634,28 @@ func TestIntegration(t *testing.T) {
 }
 
 // TestParseMRFields tests parsing MR fields from issue descriptions.
+func TestIsMergeRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  bool
+	}{
+		{"label only (type task, #816)", &Issue{Type: "task", Labels: []string{"gt:merge-request"}}, true},
+		{"type only (pre-label MR)", &Issue{Type: "merge-request"}, true},
+		{"label and type", &Issue{Type: "merge-request", Labels: []string{"gt:merge-request"}}, true},
+		{"neither", &Issue{Type: "task", Labels: []string{"gt:task"}}, false},
+		{"nil issue", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsMergeRequest(tt.issue); got != tt.want {
+				t.Errorf("IsMergeRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseMRFields(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2807,3 +3256,73 @@ func TestCloseAndClearAgentBead_ReasonVariations(t *testing.T) {
 		})
 	}
 }
+
+func TestBulkClose_Helper(t *testing.T) {
+	var tried []string
+	closeOne := func(id string) error {
+		tried = append(tried, id)
+		if id == "gt-bad" {
+			return errors.New("refusing")
+		}
+		return nil
+	}
+
+	closed, errs := bulkClose([]string{"gt-1", "gt-2"}, closeOne)
+	if closed != 2 || len(errs) != 0 {
+		t.Errorf("all succeed: closed=%d errs=%v, want 2 and none", closed, errs)
+	}
+
+	tried = nil
+	closed, errs = bulkClose([]string{"gt-1", "gt-bad", "gt-3"}, closeOne)
+	if closed != 2 {
+		t.Errorf("closed = %d, want 2", closed)
+	}
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "gt-bad: ") {
+		t.Errorf("errs = %v, want one error for gt-bad", errs)
+	}
+	if strings.Join(tried, ",") != "gt-1,gt-bad,gt-3" {
+		t.Errorf("a failure must not stop the rest: tried %v", tried)
+	}
+}
+
+func TestBulkCloseWithReason_FakeBd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake bd is a shell script")
+	}
+	binDir := t.TempDir()
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	script := `#!/bin/sh
+echo "$*" >> "` + logPath + `"
+case "$*" in
+  *gt-bad*) echo "Error: cannot close gt-bad" >&2; exit 1 ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	b := NewIsolated(t.TempDir())
+	closed, errs := b.BulkCloseWithReason("Epic gt-epic landed", []string{"gt-1", "gt-bad", "gt-3"})
+	if closed != 2 {
+		t.Errorf("closed = %d, want 2", closed)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "cannot close gt-bad") {
+		t.Errorf("errs = %v, want bd's error for gt-bad", errs)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(calls) != 3 {
+		t.Fatalf("bd called %d times, want once per ID: %q", len(calls), calls)
+	}
+	for _, call := range calls {
+		if !strings.Contains(call, "close ") || !strings.Contains(call, "--reason=Epic gt-epic landed") {
+			t.Errorf("unexpected bd call %q", call)
+		}
+	}
+}
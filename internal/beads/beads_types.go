@@ -4,12 +4,12 @@ package beads
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // typesSentinel is a marker file indicating custom types have been configured.
@@ -81,6 +81,12 @@ func ResolveRoutingTarget(townRoot, beadID, fallbackDir string) string {
 //
 // This function is thread-safe and idempotent.
 func EnsureCustomTypes(beadsDir string) error {
+	// Set BEADS_DIR explicitly to ensure bd operates on the correct database
+	return ensureCustomTypes(bdRunner{env: append(os.Environ(), "BEADS_DIR="+beadsDir)}, beadsDir)
+}
+
+// ensureCustomTypes is EnsureCustomTypes with the bd runner injected.
+func ensureCustomTypes(r util.Runner, beadsDir string) error {
 	if beadsDir == "" {
 		return fmt.Errorf("empty beads directory")
 	}
@@ -107,13 +113,8 @@ func EnsureCustomTypes(beadsDir string) error {
 
 	// Configure custom types via bd CLI
 	typesList := strings.Join(constants.BeadsCustomTypesList(), ",")
-	cmd := exec.Command("bd", "config", "set", "types.custom", typesList)
-	cmd.Dir = beadsDir
-	// Set BEADS_DIR explicitly to ensure bd operates on the correct database
-	cmd.Env = append(os.Environ(), "BEADS_DIR="+beadsDir)
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("configure custom types in %s: %s: %w",
-			beadsDir, strings.TrimSpace(string(output)), err)
+	if _, err := r.Run(beadsDir, "bd", "config", "set", "types.custom", typesList); err != nil {
+		return fmt.Errorf("configure custom types in %s: %w", beadsDir, err)
 	}
 
 	// Write sentinel file (best effort - don't fail if this fails)
@@ -1,8 +1,11 @@
 package beads
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -196,6 +199,36 @@ func TestEnsureCustomTypes(t *testing.T) {
 	})
 }
 
+func TestEnsureCustomTypes_Runner(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ResetEnsuredDirs()
+	defer ResetEnsuredDirs()
+
+	failing := &fakeBdRunner{err: errors.New("database locked")}
+	err := ensureCustomTypes(failing, beadsDir)
+	if err == nil || !strings.Contains(err.Error(), "database locked") {
+		t.Errorf("ensureCustomTypes() error = %v, want the bd failure", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(beadsDir, typesSentinel)); statErr == nil {
+		t.Error("sentinel written after a failed bd run")
+	}
+
+	r := &fakeBdRunner{}
+	if err := ensureCustomTypes(r, beadsDir); err != nil {
+		t.Fatalf("ensureCustomTypes() error = %v", err)
+	}
+	if len(r.calls) != 1 || r.dirs[0] != beadsDir ||
+		!reflect.DeepEqual(r.calls[0][:4], []string{"bd", "config", "set", "types.custom"}) {
+		t.Errorf("ran %v in %v, want bd config set types.custom in %s", r.calls, r.dirs, beadsDir)
+	}
+	if _, err := os.Stat(filepath.Join(beadsDir, typesSentinel)); err != nil {
+		t.Errorf("sentinel not written: %v", err)
+	}
+}
+
 func TestBeads_getTownRoot(t *testing.T) {
 	// Create a temporary town
 	tmpDir := t.TempDir()
@@ -7,6 +7,51 @@ import (
 	"strings"
 )
 
+// GetDescriptionField returns the value of the first "key: value" line in
+// description whose key matches key case-insensitively. Whitespace around
+// the line, key and value is ignored. Returns "" if the key is not present.
+func GetDescriptionField(description, key string) string {
+	for _, line := range strings.Split(description, "\n") {
+		if k, v, ok := parseDescriptionLine(line); ok && strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// SetDescriptionField sets key to value in description. Lines already
+// holding the key (matched as in GetDescriptionField) are replaced in place;
+// otherwise the field is prepended. Other content is preserved.
+func SetDescriptionField(description, key, value string) string {
+	fieldLine := key + ": " + value
+	if description == "" {
+		return fieldLine
+	}
+
+	lines := strings.Split(description, "\n")
+	found := false
+	for i, line := range lines {
+		if k, _, ok := parseDescriptionLine(line); ok && strings.EqualFold(k, key) {
+			lines[i] = fieldLine
+			found = true
+		}
+	}
+	if !found {
+		lines = append([]string{fieldLine}, lines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseDescriptionLine splits a "key: value" description line, trimming
+// whitespace from both parts. ok is false for lines without a colon.
+func parseDescriptionLine(line string) (key, value string, ok bool) {
+	k, v, found := strings.Cut(strings.TrimSpace(line), ":")
+	if !found {
+		return "", "", false
+	}
+	return strings.TrimSpace(k), strings.TrimSpace(v), true
+}
+
 // Note: AgentFields, ParseAgentFields, FormatAgentDescription, and CreateAgentBead are in beads.go
 
 // ParseAgentFieldsFromDescription is an alias for ParseAgentFields.
@@ -36,20 +81,9 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 	hasFields := false
 
 	for _, line := range strings.Split(issue.Description, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
 		// Look for "key: value" pattern
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:colonIdx])
-		value := strings.TrimSpace(line[colonIdx+1:])
-		if value == "" {
+		key, value, ok := parseDescriptionLine(line)
+		if !ok || value == "" {
 			continue
 		}
 
@@ -142,14 +176,13 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 			}
 
 			// Check if this is an attachment field line
-			colonIdx := strings.Index(trimmed, ":")
-			if colonIdx == -1 {
+			key, _, ok := parseDescriptionLine(trimmed)
+			if !ok {
 				otherLines = append(otherLines, line)
 				continue
 			}
 
-			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
-			if !attachmentKeys[key] {
+			if !attachmentKeys[strings.ToLower(key)] {
 				otherLines = append(otherLines, line)
 			}
 			// Skip attachment field lines - they'll be replaced
@@ -212,20 +245,9 @@ func ParseMRFields(issue *Issue) *MRFields {
 	hasFields := false
 
 	for _, line := range strings.Split(issue.Description, "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
 		// Look for "key: value" pattern
-		colonIdx := strings.Index(line, ":")
-		if colonIdx == -1 {
-			continue
-		}
-
-		key := strings.TrimSpace(line[:colonIdx])
-		value := strings.TrimSpace(line[colonIdx+1:])
-		if value == "" {
+		key, value, ok := parseDescriptionLine(line)
+		if !ok || value == "" {
 			continue
 		}
 
@@ -396,14 +418,13 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 			}
 
 			// Check if this is an MR field line
-			colonIdx := strings.Index(trimmed, ":")
-			if colonIdx == -1 {
+			key, _, ok := parseDescriptionLine(trimmed)
+			if !ok {
 				otherLines = append(otherLines, line)
 				continue
 			}
 
-			key := strings.ToLower(strings.TrimSpace(trimmed[:colonIdx]))
-			if !mrKeys[key] {
+			if !mrKeys[strings.ToLower(key)] {
 				otherLines = append(otherLines, line)
 			}
 			// Skip MR field lines - they'll be replaced
@@ -4,6 +4,129 @@ import (
 	"testing"
 )
 
+func TestGetDescriptionField(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		key         string
+		want        string
+	}{
+		{
+			name:        "empty description",
+			description: "",
+			key:         "reviewer",
+			want:        "",
+		},
+		{
+			name:        "field present",
+			description: "reviewer: alice",
+			key:         "reviewer",
+			want:        "alice",
+		},
+		{
+			name:        "field with surrounding text",
+			description: "Some description\npriority-override: 0\nMore text",
+			key:         "priority-override",
+			want:        "0",
+		},
+		{
+			name:        "case insensitive key",
+			description: "Reviewer: Bob",
+			key:         "REVIEWER",
+			want:        "Bob",
+		},
+		{
+			name:        "field not present",
+			description: "Some description\nreviewer: alice\n",
+			key:         "owner",
+			want:        "",
+		},
+		{
+			name:        "extra whitespace",
+			description: "  reviewer :   carol  ",
+			key:         "reviewer",
+			want:        "carol",
+		},
+		{
+			name:        "key is not a prefix match",
+			description: "reviewers: alice, bob",
+			key:         "reviewer",
+			want:        "",
+		},
+		{
+			name:        "value keeps later colons",
+			description: "link: https://example.com/x",
+			key:         "link",
+			want:        "https://example.com/x",
+		},
+		{
+			name:        "first occurrence wins",
+			description: "reviewer: alice\nreviewer: bob",
+			key:         "reviewer",
+			want:        "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetDescriptionField(tt.description, tt.key); got != tt.want {
+				t.Errorf("GetDescriptionField(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDescriptionField(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		key         string
+		value       string
+		want        string
+	}{
+		{
+			name:        "empty description",
+			description: "",
+			key:         "reviewer",
+			value:       "alice",
+			want:        "reviewer: alice",
+		},
+		{
+			name:        "prepend when missing",
+			description: "Some description",
+			key:         "priority-override",
+			value:       "1",
+			want:        "priority-override: 1\nSome description",
+		},
+		{
+			name:        "replace in place",
+			description: "Some description\n  Reviewer:  alice\nMore text",
+			key:         "reviewer",
+			value:       "bob",
+			want:        "Some description\nreviewer: bob\nMore text",
+		},
+		{
+			name:        "other fields untouched",
+			description: "integration_branch: integration/gt-epic\nreviewers: team",
+			key:         "reviewer",
+			value:       "alice",
+			want:        "reviewer: alice\nintegration_branch: integration/gt-epic\nreviewers: team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SetDescriptionField(tt.description, tt.key, tt.value)
+			if got != tt.want {
+				t.Errorf("SetDescriptionField(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+			if back := GetDescriptionField(got, tt.key); back != tt.value {
+				t.Errorf("GetDescriptionField after set = %q, want %q", back, tt.value)
+			}
+		})
+	}
+}
+
 // --- SynthesisFields (not covered in beads_test.go) ---
 
 func TestParseSynthesisFields(t *testing.T) {
@@ -1,9 +1,14 @@
 package beads
 
 import (
+	"errors"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // Integration branch template constants
@@ -15,6 +20,80 @@ type IssueShower interface {
 	Show(id string) (*Issue, error)
 }
 
+// IssueBatchShower fetches several issues in one lookup.
+// *Beads satisfies this interface via ShowMultiple.
+type IssueBatchShower interface {
+	IssueShower
+	ShowMultiple(ids []string) (map[string]*Issue, error)
+}
+
+// IssueCache memoizes Show lookups so repeated reads of the same issue
+// (e.g., a parent-chain walk followed by reading the source issue) cost a
+// single bd call. It satisfies IssueShower, so it can be passed anywhere
+// an IssueShower is accepted. It is safe for concurrent use.
+type IssueCache struct {
+	shower IssueShower
+	mu     sync.Mutex
+	issues map[string]*Issue
+}
+
+// NewIssueCache wraps shower with a lookup cache.
+func NewIssueCache(shower IssueShower) *IssueCache {
+	return &IssueCache{shower: shower, issues: make(map[string]*Issue)}
+}
+
+// Show returns the cached issue, fetching it on first use.
+// Lookup errors are not cached.
+func (c *IssueCache) Show(id string) (*Issue, error) {
+	c.mu.Lock()
+	issue, ok := c.issues[id]
+	c.mu.Unlock()
+	if ok {
+		return issue, nil
+	}
+	issue, err := c.shower.Show(id)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.issues[id] = issue
+	c.mu.Unlock()
+	return issue, nil
+}
+
+// Prefetch loads the uncached ids in one call when the underlying shower
+// supports ShowMultiple. Otherwise it does nothing and Show fetches lazily.
+// IDs the batch lookup doesn't return are left for Show to report.
+func (c *IssueCache) Prefetch(ids ...string) error {
+	batch, ok := c.shower.(IssueBatchShower)
+	if !ok {
+		return nil
+	}
+
+	var missing []string
+	c.mu.Lock()
+	for _, id := range ids {
+		if _, cached := c.issues[id]; !cached && id != "" {
+			missing = append(missing, id)
+		}
+	}
+	c.mu.Unlock()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	issues, err := batch.ShowMultiple(missing)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	for id, issue := range issues {
+		c.issues[id] = issue
+	}
+	c.mu.Unlock()
+	return nil
+}
+
 // BranchChecker provides branch existence checks without importing the git package.
 // This avoids circular imports between beads and git.
 type BranchChecker interface {
@@ -25,99 +104,60 @@ type BranchChecker interface {
 // GetIntegrationBranchField extracts the integration_branch field from an epic's description.
 // Returns empty string if the field is not found.
 func GetIntegrationBranchField(description string) string {
-	return getMetadataField(description, "integration_branch")
+	return GetDescriptionField(description, "integration_branch")
 }
 
 // GetBaseBranchField extracts the base_branch field from an epic's description.
 // Returns empty string if the field is not found.
 func GetBaseBranchField(description string) string {
-	return getMetadataField(description, "base_branch")
-}
-
-// getMetadataField extracts a key: value field from a description string.
-// The key match is case-insensitive.
-func getMetadataField(description, key string) string {
-	if description == "" {
-		return ""
-	}
-
-	lowerKey := strings.ToLower(key) + ":"
-
-	lines := strings.Split(description, "\n")
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(strings.ToLower(trimmed), lowerKey) {
-			parts := strings.SplitN(trimmed, ":", 2)
-			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1])
-			}
-		}
-	}
-	return ""
+	return GetDescriptionField(description, "base_branch")
 }
 
 // AddIntegrationBranchField adds or updates the integration_branch field in a description.
 func AddIntegrationBranchField(description, branchName string) string {
-	return addMetadataField(description, "integration_branch", branchName)
+	return SetDescriptionField(description, "integration_branch", branchName)
 }
 
 // AddBaseBranchField adds or updates the base_branch field in a description.
 func AddBaseBranchField(description, baseBranch string) string {
-	return addMetadataField(description, "base_branch", baseBranch)
-}
-
-// addMetadataField adds or updates a key: value field in a description.
-func addMetadataField(description, key, value string) string {
-	fieldLine := key + ": " + value
-
-	if description == "" {
-		return fieldLine
-	}
-
-	lowerKey := strings.ToLower(key) + ":"
-
-	lines := strings.Split(description, "\n")
-	var newLines []string
-	found := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(strings.ToLower(trimmed), lowerKey) {
-			newLines = append(newLines, fieldLine)
-			found = true
-		} else {
-			newLines = append(newLines, line)
-		}
-	}
-
-	if !found {
-		newLines = append([]string{fieldLine}, newLines...)
-	}
-
-	return strings.Join(newLines, "\n")
+	return SetDescriptionField(description, "base_branch", baseBranch)
 }
 
 // BuildIntegrationBranchName expands an integration branch template with variables.
 // Variables supported:
 //   - {epic}: Full epic ID (e.g., "RA-123")
 //   - {prefix}: Epic prefix before first hyphen (e.g., "RA")
-//   - {user}: Git user.name (e.g., "klauern")
+//   - {user}: Git user.name, falling back to $USER (e.g., "klauern")
+//   - {date}: Current date as YYYY-MM-DD (e.g., "2026-01-15")
+//   - {year}: Current year as YYYY (e.g., "2026")
+//   - {month}: Current month as MM (e.g., "01")
 //
+// Unknown placeholders (and {user} when no user can be determined) are left
+// literal; callers validate the expanded name before creating a branch.
 // If template is empty, uses DefaultIntegrationBranchTemplate.
 func BuildIntegrationBranchName(template, epicID string) string {
+	return expandIntegrationTemplate(template, epicID, getTemplateUser(), time.Now())
+}
+
+// expandIntegrationTemplate performs the placeholder substitution for
+// BuildIntegrationBranchName with the user and clock supplied by the caller.
+func expandIntegrationTemplate(template, epicID, user string, now time.Time) string {
 	if template == "" {
 		template = DefaultIntegrationBranchTemplate
 	}
 
-	result := template
-	result = strings.ReplaceAll(result, "{epic}", epicID)
-	result = strings.ReplaceAll(result, "{prefix}", ExtractEpicPrefix(epicID))
-
-	if user := getGitUserName(); user != "" {
-		result = strings.ReplaceAll(result, "{user}", user)
+	pairs := []string{
+		"{epic}", epicID,
+		"{prefix}", ExtractEpicPrefix(epicID),
+		"{date}", now.Format("2006-01-02"),
+		"{year}", now.Format("2006"),
+		"{month}", now.Format("01"),
+	}
+	if user != "" {
+		pairs = append(pairs, "{user}", user)
 	}
 
-	return result
+	return strings.NewReplacer(pairs...).Replace(template)
 }
 
 // ExtractEpicPrefix extracts the prefix from an epic ID (before the first hyphen).
@@ -129,14 +169,51 @@ func ExtractEpicPrefix(epicID string) string {
 	return epicID
 }
 
+// getTemplateUser returns the user for the {user} placeholder: git user.name,
+// or $USER when git has no user configured.
+func getTemplateUser() string {
+	if user := getGitUserName(util.ExecRunner{}); user != "" {
+		return user
+	}
+	return os.Getenv("USER")
+}
+
 // getGitUserName returns the git user.name config value, or empty if not set.
-func getGitUserName() string {
-	cmd := exec.Command("git", "config", "user.name")
-	out, err := cmd.Output()
+func getGitUserName(r util.Runner) string {
+	out, err := r.Run("", "git", "config", "user.name")
 	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(string(out))
+	return out
+}
+
+// MaxParentDepth bounds how far parent chains are walked, so a corrupted
+// chain that loops can't hang the caller. Walks down a hierarchy (e.g.,
+// gt bead tree) use the same bound.
+const MaxParentDepth = 10
+
+// ErrParentCycle is returned when a reparent would make an issue its own ancestor.
+var ErrParentCycle = errors.New("parent cycle")
+
+// CheckReparent reports whether parentID can become the parent of id.
+// It walks parentID's ancestry and refuses if id appears in it, since the
+// new link would close a cycle.
+func CheckReparent(bd IssueShower, id, parentID string) error {
+	if id == parentID {
+		return fmt.Errorf("%w: %s cannot be its own parent", ErrParentCycle, id)
+	}
+	currentID := parentID
+	for depth := 0; depth < MaxParentDepth && currentID != ""; depth++ {
+		if currentID == id {
+			return fmt.Errorf("%w: %s is an ancestor of %s", ErrParentCycle, id, parentID)
+		}
+		issue, err := bd.Show(currentID)
+		if err != nil {
+			return fmt.Errorf("looking up issue %s: %w", currentID, err)
+		}
+		currentID = issue.Parent
+	}
+	return nil
 }
 
 // DetectIntegrationBranch checks if an issue is a descendant of an epic that has an integration branch.
@@ -145,10 +222,9 @@ func getGitUserName() string {
 // Checks branch existence via BranchChecker.
 // Returns the integration branch name or "" if not found.
 func DetectIntegrationBranch(bd IssueShower, checker BranchChecker, issueID string) (string, error) {
-	const maxDepth = 10
 	currentID := issueID
 
-	for depth := 0; depth < maxDepth; depth++ {
+	for depth := 0; depth < MaxParentDepth; depth++ {
 		issue, err := bd.Show(currentID)
 		if err != nil {
 			return "", fmt.Errorf("looking up issue %s: %w", currentID, err)
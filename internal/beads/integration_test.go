@@ -1,9 +1,12 @@
 package beads
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetIntegrationBranchField(t *testing.T) {
@@ -203,6 +206,84 @@ func TestBuildIntegrationBranchName(t *testing.T) {
 	}
 }
 
+func TestExpandIntegrationTemplate(t *testing.T) {
+	now := time.Date(2026, time.March, 7, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		epicID   string
+		user     string
+		want     string
+	}{
+		{
+			name:     "date",
+			template: "integration/{date}/{epic}",
+			epicID:   "gt-auth",
+			want:     "integration/2026-03-07/gt-auth",
+		},
+		{
+			name:     "year",
+			template: "release/{year}/{epic}",
+			epicID:   "gt-auth",
+			want:     "release/2026/gt-auth",
+		},
+		{
+			name:     "month",
+			template: "{month}/{epic}",
+			epicID:   "gt-auth",
+			want:     "03/gt-auth",
+		},
+		{
+			name:     "user",
+			template: "{user}/{epic}",
+			epicID:   "gt-auth",
+			user:     "alice",
+			want:     "alice/gt-auth",
+		},
+		{
+			name:     "combined placeholders",
+			template: "{user}/{year}/{month}/{prefix}/{epic}",
+			epicID:   "RA-123",
+			user:     "alice",
+			want:     "alice/2026/03/RA/RA-123",
+		},
+		{
+			name:     "repeated placeholder",
+			template: "{epic}-{date}-{epic}",
+			epicID:   "gt-x",
+			want:     "gt-x-2026-03-07-gt-x",
+		},
+		{
+			name:     "no user leaves placeholder literal",
+			template: "{user}/{epic}",
+			epicID:   "gt-auth",
+			want:     "{user}/gt-auth",
+		},
+		{
+			name:     "unknown placeholder left literal",
+			template: "integration/{team}/{epic}",
+			epicID:   "gt-auth",
+			want:     "integration/{team}/gt-auth",
+		},
+		{
+			name:     "empty template uses default",
+			template: "",
+			epicID:   "gt-auth",
+			want:     "integration/gt-auth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandIntegrationTemplate(tt.template, tt.epicID, tt.user, now)
+			if got != tt.want {
+				t.Errorf("expandIntegrationTemplate(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExtractEpicPrefix(t *testing.T) {
 	tests := []struct {
 		epicID string
@@ -250,9 +331,11 @@ func (m *mockBranchChecker) RemoteBranchExists(remote, name string) (bool, error
 // mockIssueShower implements IssueShower for testing DetectIntegrationBranch.
 type mockIssueShower struct {
 	issues map[string]*Issue
+	calls  int // Number of Show calls
 }
 
 func (m *mockIssueShower) Show(id string) (*Issue, error) {
+	m.calls++
 	issue, ok := m.issues[id]
 	if !ok {
 		return nil, fmt.Errorf("issue %s not found", id)
@@ -260,6 +343,67 @@ func (m *mockIssueShower) Show(id string) (*Issue, error) {
 	return issue, nil
 }
 
+func TestCheckReparent(t *testing.T) {
+	// gt-epic
+	// ├── gt-feature
+	// │   └── gt-task
+	// gt-other
+	shower := &mockIssueShower{issues: map[string]*Issue{
+		"gt-epic":    {ID: "gt-epic", Type: "epic"},
+		"gt-feature": {ID: "gt-feature", Parent: "gt-epic"},
+		"gt-task":    {ID: "gt-task", Parent: "gt-feature"},
+		"gt-other":   {ID: "gt-other", Type: "epic"},
+	}}
+
+	tests := []struct {
+		name      string
+		id        string
+		parentID  string
+		wantCycle bool
+	}{
+		{name: "move under unrelated epic", id: "gt-task", parentID: "gt-other"},
+		{name: "move up to grandparent", id: "gt-task", parentID: "gt-epic"},
+		{name: "epic under its descendant", id: "gt-epic", parentID: "gt-task", wantCycle: true},
+		{name: "direct parent under child", id: "gt-feature", parentID: "gt-task", wantCycle: true},
+		{name: "own parent", id: "gt-task", parentID: "gt-task", wantCycle: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckReparent(shower, tt.id, tt.parentID)
+			if tt.wantCycle {
+				if !errors.Is(err, ErrParentCycle) {
+					t.Errorf("CheckReparent(%s, %s) error = %v, want ErrParentCycle", tt.id, tt.parentID, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("CheckReparent(%s, %s) error = %v, want nil", tt.id, tt.parentID, err)
+			}
+		})
+	}
+
+	t.Run("missing parent", func(t *testing.T) {
+		err := CheckReparent(shower, "gt-task", "gt-missing")
+		if err == nil || errors.Is(err, ErrParentCycle) {
+			t.Errorf("CheckReparent() error = %v, want lookup error", err)
+		}
+	})
+
+	t.Run("looping chain is bounded", func(t *testing.T) {
+		loop := &mockIssueShower{issues: map[string]*Issue{
+			"gt-a": {ID: "gt-a", Parent: "gt-b"},
+			"gt-b": {ID: "gt-b", Parent: "gt-a"},
+		}}
+		if err := CheckReparent(loop, "gt-new", "gt-a"); err != nil {
+			t.Errorf("CheckReparent() error = %v, want nil", err)
+		}
+		if loop.calls > MaxParentDepth {
+			t.Errorf("Show called %d times, want at most %d", loop.calls, MaxParentDepth)
+		}
+	})
+}
+
 func TestDetectIntegrationBranch(t *testing.T) {
 	t.Run("child of epic with metadata and local branch", func(t *testing.T) {
 		shower := &mockIssueShower{issues: map[string]*Issue{
@@ -448,3 +592,162 @@ func TestDetectIntegrationBranch(t *testing.T) {
 		}
 	})
 }
+
+// mockBatchShower adds ShowMultiple to mockIssueShower.
+type mockBatchShower struct {
+	mockIssueShower
+	batchCalls int
+}
+
+func (m *mockBatchShower) ShowMultiple(ids []string) (map[string]*Issue, error) {
+	m.batchCalls++
+	result := make(map[string]*Issue)
+	for _, id := range ids {
+		if issue, ok := m.issues[id]; ok {
+			result[id] = issue
+		}
+	}
+	return result, nil
+}
+
+func TestIssueCache_DetectIntegrationBranchReusesLookups(t *testing.T) {
+	issues := map[string]*Issue{
+		"gt-task":  {ID: "gt-task", Type: "task", Priority: 1, Parent: "gt-epic1"},
+		"gt-epic1": {ID: "gt-epic1", Type: "epic", Description: "No metadata", Parent: "gt-epic2"},
+		"gt-epic2": {ID: "gt-epic2", Type: "epic", Description: "integration_branch: parent/branch"},
+	}
+	checker := &mockBranchChecker{localBranches: map[string]bool{"parent/branch": true}}
+
+	// Uncached: the walk plus a second read of the source issue
+	plain := &mockIssueShower{issues: issues}
+	wantBranch, err := DetectIntegrationBranch(plain, checker, "gt-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.Show("gt-task"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cached: same walk and read
+	underlying := &mockIssueShower{issues: issues}
+	cache := NewIssueCache(underlying)
+	gotBranch, err := DetectIntegrationBranch(cache, checker, "gt-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := cache.Show("gt-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotBranch != wantBranch {
+		t.Errorf("cached DetectIntegrationBranch = %q, uncached = %q", gotBranch, wantBranch)
+	}
+	if source.Priority != 1 {
+		t.Errorf("cached source issue priority = %d, want 1", source.Priority)
+	}
+	if underlying.calls >= plain.calls {
+		t.Errorf("cache made %d lookups, want fewer than uncached %d", underlying.calls, plain.calls)
+	}
+	if underlying.calls != 3 {
+		t.Errorf("cache made %d lookups, want one per distinct issue (3)", underlying.calls)
+	}
+}
+
+func TestIssueCache_Prefetch(t *testing.T) {
+	// *Beads must support batch prefetch
+	var _ IssueBatchShower = (*Beads)(nil)
+
+	shower := &mockBatchShower{mockIssueShower: mockIssueShower{issues: map[string]*Issue{
+		"gt-a": {ID: "gt-a", Title: "A"},
+		"gt-b": {ID: "gt-b", Title: "B"},
+	}}}
+	cache := NewIssueCache(shower)
+
+	if err := cache.Prefetch("gt-a", "gt-b", "gt-missing"); err != nil {
+		t.Fatalf("Prefetch() error = %v", err)
+	}
+	if shower.batchCalls != 1 {
+		t.Errorf("Prefetch made %d batch calls, want 1", shower.batchCalls)
+	}
+
+	for _, id := range []string{"gt-a", "gt-b"} {
+		if _, err := cache.Show(id); err != nil {
+			t.Errorf("Show(%s) error = %v", id, err)
+		}
+	}
+	if shower.calls != 0 {
+		t.Errorf("prefetched issues should not need Show, got %d calls", shower.calls)
+	}
+
+	// Missing issues fall through to Show and report its error
+	if _, err := cache.Show("gt-missing"); err == nil {
+		t.Error("Show(gt-missing) should fail")
+	}
+
+	// Already-cached IDs are not fetched again
+	if err := cache.Prefetch("gt-a"); err != nil {
+		t.Fatal(err)
+	}
+	if shower.batchCalls != 1 {
+		t.Errorf("Prefetch of cached IDs made a batch call")
+	}
+}
+
+func TestIssueCache_ConcurrentShow(t *testing.T) {
+	shower := &mockBatchShower{mockIssueShower: mockIssueShower{issues: map[string]*Issue{
+		"gt-a": {ID: "gt-a"},
+		"gt-b": {ID: "gt-b"},
+	}}}
+	cache := NewIssueCache(shower)
+	if err := cache.Prefetch("gt-a", "gt-b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Concurrent readers of prefetched issues share the cache, as the
+	// dashboard's per-epic workers do
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			if _, err := cache.Show(id); err != nil {
+				t.Errorf("Show(%s) error = %v", id, err)
+			}
+		}([]string{"gt-a", "gt-b"}[i%2])
+	}
+	wg.Wait()
+	if shower.calls != 0 {
+		t.Errorf("prefetched issues should not need Show, got %d calls", shower.calls)
+	}
+}
+
+func TestIssueCache_PrefetchWithoutBatchSupport(t *testing.T) {
+	shower := &mockIssueShower{issues: map[string]*Issue{"gt-a": {ID: "gt-a"}}}
+	cache := NewIssueCache(shower)
+	if err := cache.Prefetch("gt-a"); err != nil {
+		t.Fatalf("Prefetch() error = %v", err)
+	}
+	if shower.calls != 0 {
+		t.Errorf("Prefetch without batch support should be lazy, got %d calls", shower.calls)
+	}
+}
+
+// fakeGitConfigRunner answers git config with a fixed user.name or error.
+type fakeGitConfigRunner struct {
+	out string
+	err error
+}
+
+func (f fakeGitConfigRunner) Run(dir, name string, args ...string) (string, error) {
+	return f.out, f.err
+}
+
+func TestGetGitUserName(t *testing.T) {
+	if got := getGitUserName(fakeGitConfigRunner{out: "Ada"}); got != "Ada" {
+		t.Errorf("getGitUserName() = %q, want %q", got, "Ada")
+	}
+	if got := getGitUserName(fakeGitConfigRunner{out: "ignored", err: errors.New("exit status 1")}); got != "" {
+		t.Errorf("getGitUserName() = %q, want empty when git config fails", got)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+var agentsShowFormat *output.FormatFlag
+
+var agentsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show one agent's status",
+	Long: `Show the status of a single agent: its agent bead, liveness, hooked work
+and mail, as in 'gt status --verbose'.
+
+The agent is named by its address (gastown/witness, gastown/crew/joe,
+mayor) or by its bare name (joe). Names are rig-scoped, so a bare name
+that exists in several rigs is ambiguous: the candidates are listed and
+you must pick one by address.
+
+Examples:
+  gt agents show gastown/witness
+  gt agents show joe
+  gt agents show mayor --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: withFormattedErrors(func() output.Format { return errorFormat(agentsShowFormat) }, runAgentsShow),
+}
+
+func init() {
+	agentsShowFormat = output.NewFormatFlag(agentsShowCmd).WithJSONAlias(agentsShowCmd)
+	agentsCmd.AddCommand(agentsShowCmd)
+}
+
+// agentMatch is an agent found by name, with the hooks of its rig (nil for
+// town-level agents) for renderAgentDetails.
+type agentMatch struct {
+	Agent AgentRuntime
+	Hooks []AgentHookInfo
+}
+
+func runAgentsShow(cmd *cobra.Command, args []string) error {
+	format, err := agentsShowFormat.Resolve()
+	if err != nil {
+		return err
+	}
+	status, err := collectStatus()
+	if err != nil {
+		return err
+	}
+	match, err := findStatusAgent(status, args[0])
+	if err != nil {
+		return err
+	}
+	return printAgentsShow(os.Stdout, match, status.Location, format)
+}
+
+// printAgentsShow writes match in format: the agent record for structured
+// formats, or the gt status --verbose details for text.
+func printAgentsShow(w io.Writer, match agentMatch, townRoot string, format output.Format) error {
+	if format != output.FormatText {
+		return output.FprintFormatted(w, match.Agent, format)
+	}
+	renderAgentDetails(w, match.Agent, "", match.Hooks, townRoot)
+	return nil
+}
+
+// findStatusAgent finds the agent called name among the town-level and rig
+// agents. An exact address (trailing slash optional) wins; otherwise the
+// bare name must match exactly one agent.
+func findStatusAgent(status TownStatus, name string) (agentMatch, error) {
+	want := strings.TrimSuffix(name, "/")
+	var all []agentMatch
+	for _, a := range status.Agents {
+		all = append(all, agentMatch{Agent: a})
+	}
+	for _, r := range status.Rigs {
+		for _, a := range r.Agents {
+			all = append(all, agentMatch{Agent: a, Hooks: r.Hooks})
+		}
+	}
+
+	var byName []agentMatch
+	for _, m := range all {
+		if strings.TrimSuffix(m.Agent.Address, "/") == want {
+			return m, nil
+		}
+		if m.Agent.Name == want {
+			byName = append(byName, m)
+		}
+	}
+
+	switch len(byName) {
+	case 0:
+		return agentMatch{}, fmt.Errorf("no agent named %q (see 'gt status' for agent addresses)", name)
+	case 1:
+		return byName[0], nil
+	}
+	var candidates []string
+	for _, m := range byName {
+		candidates = append(candidates, "  "+strings.TrimSuffix(m.Agent.Address, "/"))
+	}
+	return agentMatch{}, fmt.Errorf("agent name %q is ambiguous; use one of:\n%s", name, strings.Join(candidates, "\n"))
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+func agentsShowTestStatus() TownStatus {
+	return TownStatus{
+		Agents: []AgentRuntime{
+			{Name: "mayor", Address: "mayor/", Role: "coordinator"},
+		},
+		Rigs: []RigStatus{
+			{
+				Name: "gastown",
+				Agents: []AgentRuntime{
+					{Name: "witness", Address: "gastown/witness", Role: "witness"},
+					{Name: "joe", Address: "gastown/crew/joe", Role: "crew"},
+				},
+				Hooks: []AgentHookInfo{{Agent: "gastown/crew/joe", HasWork: true, Molecule: "gt-abc"}},
+			},
+			{
+				Name: "beads",
+				Agents: []AgentRuntime{
+					{Name: "witness", Address: "beads/witness", Role: "witness"},
+				},
+			},
+		},
+	}
+}
+
+func TestFindStatusAgent_Unique(t *testing.T) {
+	status := agentsShowTestStatus()
+	tests := []struct {
+		name        string
+		wantAddress string
+	}{
+		{"joe", "gastown/crew/joe"},
+		{"gastown/crew/joe", "gastown/crew/joe"},
+		{"beads/witness", "beads/witness"},
+		{"mayor", "mayor/"},
+		{"mayor/", "mayor/"},
+	}
+	for _, tt := range tests {
+		m, err := findStatusAgent(status, tt.name)
+		if err != nil {
+			t.Errorf("findStatusAgent(%q) error: %v", tt.name, err)
+			continue
+		}
+		if m.Agent.Address != tt.wantAddress {
+			t.Errorf("findStatusAgent(%q) = %s, want %s", tt.name, m.Agent.Address, tt.wantAddress)
+		}
+	}
+
+	m, _ := findStatusAgent(status, "joe")
+	if len(m.Hooks) != 1 || m.Hooks[0].Molecule != "gt-abc" {
+		t.Errorf("findStatusAgent(joe) hooks = %+v, want the gastown rig's hooks", m.Hooks)
+	}
+}
+
+func TestFindStatusAgent_Ambiguous(t *testing.T) {
+	_, err := findStatusAgent(agentsShowTestStatus(), "witness")
+	if err == nil {
+		t.Fatal("findStatusAgent(witness) succeeded, want an ambiguity error")
+	}
+	for _, want := range []string{"ambiguous", "gastown/witness", "beads/witness"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestFindStatusAgent_NotFound(t *testing.T) {
+	_, err := findStatusAgent(agentsShowTestStatus(), "nobody")
+	if err == nil || !strings.Contains(err.Error(), `no agent named "nobody"`) {
+		t.Errorf("findStatusAgent(nobody) error = %v, want not found", err)
+	}
+}
+
+func TestPrintAgentsShow_Formats(t *testing.T) {
+	m, err := findStatusAgent(agentsShowTestStatus(), "joe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := printAgentsShow(&buf, m, "/town", output.FormatJSON); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var got AgentRuntime
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil || got.Address != "gastown/crew/joe" {
+		t.Errorf("json output = %s (err %v), want joe's agent record", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := printAgentsShow(&buf, m, "/town", output.FormatTOON); err != nil {
+		t.Fatalf("toon: %v", err)
+	}
+	if !strings.Contains(buf.String(), "address: gastown/crew/joe") {
+		t.Errorf("toon output missing address:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := printAgentsShow(&buf, m, "/town", output.FormatText); err != nil {
+		t.Fatalf("text: %v", err)
+	}
+	if buf.Len() == 0 || strings.HasPrefix(buf.String(), "{") {
+		t.Errorf("text output should be the rendered details, got:\n%s", buf.String())
+	}
+}
+
+func TestAgentsShowCmd_FormatFlags(t *testing.T) {
+	for _, name := range []string{"format", "json"} {
+		if agentsShowCmd.Flags().Lookup(name) == nil {
+			t.Errorf("gt agents show has no --%s flag", name)
+		}
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var beadCmd = &cobra.Command{
@@ -45,17 +49,26 @@ var beadShowCmd = &cobra.Command{
 	Short: "Show details of a bead",
 	Long: `Displays the full details of a bead by ID.
 
-This is an alias for 'gt show'. All bd show flags are supported.
+Without gt flags this is an alias for 'gt show', and all bd show flags are
+supported. With --json, --format or --resolve-integration, gt reads the bead
+itself and prints it as the merge queue sees it: the issue fields, labels,
+and the integration_branch and base_branch parsed from the description.
+
+Flags handled by gt:
+  --json                   Output as JSON (same as --format json)
+  --format <text|json>     Output format
+  --resolve-integration    Also resolve the integration branch the bead's
+                           merge requests would target (walks parent epics
+                           and checks the branch exists in the current rig)
 
 Examples:
   gt bead show gt-abc123          # Show a gastown issue
   gt bead show hq-xyz789          # Show a town-level bead
   gt bead show bd-def456          # Show a beads issue
-  gt bead show gt-abc123 --json   # Output as JSON`,
+  gt bead show gt-abc123 --json   # Output as JSON
+  gt bead show gt-task1 --resolve-integration --json`,
 	DisableFlagParsing: true, // Pass all flags through to bd show
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runShow(cmd, args)
-	},
+	RunE:               runBeadShow,
 }
 
 var beadReadCmd = &cobra.Command{
@@ -76,11 +89,130 @@ Examples:
 	},
 }
 
+var beadReparentCmd = &cobra.Command{
+	Use:   "reparent <bead-id> <new-parent>",
+	Short: "Move a bead under a different parent",
+	Long: `Change the parent of a bead.
+
+Integration branch detection follows parent chains, so a misparented task
+can send its merge request to the wrong branch. Reparenting is refused if
+the new parent is the bead itself or one of its descendants.
+
+Use --clear to detach a bead from its parent.
+
+Examples:
+  gt bead reparent gt-task1 gt-epic2    # Move gt-task1 under gt-epic2
+  gt bead reparent gt-task1 --clear     # Detach gt-task1 from its parent`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if beadReparentClear {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: runBeadReparent,
+}
+
+var beadReparentClear bool
+
+var beadRetypeCmd = &cobra.Command{
+	Use:   "retype <bead-id> <type>",
+	Short: "Change a bead's type",
+	Long: `Change the issue type of a bead.
+
+Merge requests are recognised by their gt:merge-request label, but some were
+created with type "task" (#816). Retyping them keeps type filters such as
+'bd list --type merge-request' accurate; 'gt doctor --fix' does this for
+every mistyped merge request (the mr-type-normalization check).
+
+Examples:
+  gt bead retype gt-mr42 merge-request
+  gt bead retype gt-abc123 bug`,
+	Args: cobra.ExactArgs(2),
+	RunE: runBeadRetype,
+}
+
+var beadPriorityCmd = &cobra.Command{
+	Use:   "priority <bead-id> <0-4>",
+	Short: "Change a bead's priority",
+	Long: `Set the priority of a bead, from 0 (highest) to 4.
+
+An epic's priority orders the land queue: 'gt mq integration next' and
+'gt mq dashboard' put the highest-priority ready epic first.
+
+Examples:
+  gt bead priority gt-auth-epic 0
+  gt bead priority gt-abc123 3`,
+	Args: cobra.ExactArgs(2),
+	RunE: runBeadPriority,
+}
+
+var beadSearchCmd = &cobra.Command{
+	Use:   "search <text>",
+	Short: "Search beads by title and description",
+	Long: `Full-text search over beads in the current rig or town.
+
+Uses 'bd search' when available; older bd versions fall back to listing
+and matching the title and description case-insensitively. Filters narrow
+the results the same way they do for 'bd list'.
+
+Examples:
+  gt bead search auth                          # Anything mentioning auth
+  gt bead search "token refresh" --status open
+  gt bead search auth --type merge-request     # Merge requests only
+  gt bead search auth --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadSearch,
+}
+
+var beadHistoryCmd = &cobra.Command{
+	Use:   "history <bead-id>",
+	Short: "Show how a bead's status and fields changed over time",
+	Long: `Show the audit trail of a bead: each recorded change to its status,
+priority, assignee, title, type, parent, labels or description, with when
+it was committed and by whom, oldest first.
+
+History comes from 'bd history' and needs the Dolt beads backend; other
+backends report that history is not supported.
+
+Examples:
+  gt bead history gt-epic1
+  gt bead history gt-mr42 --limit 10
+  gt bead history gt-epic1 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadHistory,
+}
+
+var (
+	beadHistoryLimit  int
+	beadHistoryFormat *output.FormatFlag
+)
+
+var (
+	beadSearchStatus string
+	beadSearchType   string
+	beadSearchLabels []string
+	beadSearchLimit  int
+	beadSearchFormat *output.FormatFlag
+)
+
 func init() {
 	beadMoveCmd.Flags().BoolVarP(&beadMoveDryRun, "dry-run", "n", false, "Show what would be done")
 	beadCmd.AddCommand(beadMoveCmd)
 	beadCmd.AddCommand(beadShowCmd)
 	beadCmd.AddCommand(beadReadCmd)
+	beadReparentCmd.Flags().BoolVar(&beadReparentClear, "clear", false, "Remove the bead's parent instead of setting one")
+	beadCmd.AddCommand(beadReparentCmd)
+	beadCmd.AddCommand(beadRetypeCmd)
+	beadCmd.AddCommand(beadPriorityCmd)
+	beadSearchCmd.Flags().StringVar(&beadSearchStatus, "status", "", "Filter by status (open, closed, all)")
+	beadSearchCmd.Flags().StringVar(&beadSearchType, "type", "", "Filter by type (e.g., task, epic, merge-request)")
+	beadSearchCmd.Flags().StringSliceVar(&beadSearchLabels, "label", nil, "Filter by label (repeatable; all must match)")
+	beadSearchCmd.Flags().IntVar(&beadSearchLimit, "limit", 0, "Maximum number of results (0 = bd default)")
+	beadSearchFormat = output.NewFormatFlag(beadSearchCmd)
+	beadCmd.AddCommand(beadSearchCmd)
+	beadHistoryCmd.Flags().IntVar(&beadHistoryLimit, "limit", 0, "Show only the most recent N changes (0 = all)")
+	beadHistoryFormat = output.NewFormatFlag(beadHistoryCmd)
+	beadCmd.AddCommand(beadHistoryCmd)
 	rootCmd.AddCommand(beadCmd)
 }
 
@@ -191,3 +323,332 @@ func runBeadMove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runBeadReparent(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+	bd := beads.New(resolveBeadDir(beadID))
+
+	if beadReparentClear {
+		if err := bd.ClearParent(beadID); err != nil {
+			return fmt.Errorf("clearing parent of %s: %w", beadID, err)
+		}
+		fmt.Printf("%s Cleared parent of %s\n", style.Bold.Render("✓"), beadID)
+		return nil
+	}
+
+	parentID := args[1]
+	if err := bd.SetParent(beadID, parentID); err != nil {
+		return fmt.Errorf("reparenting %s: %w", beadID, err)
+	}
+	fmt.Printf("%s Moved %s under %s\n", style.Bold.Render("✓"), beadID, parentID)
+	return nil
+}
+
+func runBeadRetype(cmd *cobra.Command, args []string) error {
+	beadID, issueType := args[0], args[1]
+	bd := beads.New(resolveBeadDir(beadID))
+
+	issue, err := bd.Show(beadID)
+	if err != nil {
+		return fmt.Errorf("getting %s: %w", beadID, err)
+	}
+	if issue.Type == issueType {
+		fmt.Printf("%s %s is already a %s\n", style.Dim.Render("○"), beadID, issueType)
+		return nil
+	}
+	if err := bd.SetType(beadID, issueType); err != nil {
+		return fmt.Errorf("retyping %s: %w", beadID, err)
+	}
+	fmt.Printf("%s Changed %s from %s to %s\n", style.Bold.Render("✓"), beadID, issue.Type, issueType)
+	return nil
+}
+
+func runBeadPriority(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+	priority, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(args[1]), "P"))
+	if err != nil {
+		return fmt.Errorf("invalid priority %q: want a number from 0 to 4", args[1])
+	}
+	bd := beads.New(resolveBeadDir(beadID))
+
+	issue, err := bd.Show(beadID)
+	if err != nil {
+		return fmt.Errorf("getting %s: %w", beadID, err)
+	}
+	if issue.Priority == priority {
+		fmt.Printf("%s %s is already P%d\n", style.Dim.Render("○"), beadID, priority)
+		return nil
+	}
+	if err := bd.SetPriority(beadID, priority); err != nil {
+		return fmt.Errorf("setting priority of %s: %w", beadID, err)
+	}
+	fmt.Printf("%s Changed %s from P%d to P%d\n", style.Bold.Render("✓"), beadID, issue.Priority, priority)
+	return nil
+}
+
+func runBeadSearch(cmd *cobra.Command, args []string) error {
+	format, err := beadSearchFormat.Resolve()
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	bd := beads.New(cwd)
+	issues, err := bd.Search(args[0], beads.ListOptions{
+		Status:   beadSearchStatus,
+		Type:     beadSearchType,
+		Labels:   beadSearchLabels,
+		Priority: -1,
+		Limit:    beadSearchLimit,
+	})
+	if err != nil {
+		return fmt.Errorf("searching beads: %w", err)
+	}
+
+	if format != output.FormatText {
+		if issues == nil {
+			issues = []*beads.Issue{}
+		}
+		return output.PrintFormatted(issues, format)
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("(no matching beads)"))
+		return nil
+	}
+	table := style.NewTable(
+		style.Column{Name: "ID", Width: 14},
+		style.Column{Name: "STATUS", Width: 12},
+		style.Column{Name: "TITLE", Width: 60},
+	)
+	for _, issue := range issues {
+		table.AddRow(issue.ID, issue.Status, issue.Title)
+	}
+	fmt.Print(table.Render())
+	return nil
+}
+
+func runBeadHistory(cmd *cobra.Command, args []string) error {
+	format, err := beadHistoryFormat.Resolve()
+	if err != nil {
+		return err
+	}
+	beadID := args[0]
+	bd := beads.New(resolveBeadDir(beadID))
+
+	changes, err := bd.History(beadID)
+	if err != nil {
+		return fmt.Errorf("reading history of %s: %w", beadID, err)
+	}
+	if beadHistoryLimit > 0 && len(changes) > beadHistoryLimit {
+		changes = changes[len(changes)-beadHistoryLimit:]
+	}
+
+	if format != output.FormatText {
+		if changes == nil {
+			changes = []beads.StateChange{}
+		}
+		return output.PrintFormatted(changes, format)
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("(no recorded history)"))
+		return nil
+	}
+	table := style.NewTable(
+		style.Column{Name: "TIME", Width: 22},
+		style.Column{Name: "ACTOR", Width: 24},
+		style.Column{Name: "CHANGE", Width: 60},
+	)
+	for _, c := range changes {
+		table.AddRow(c.Timestamp, c.Actor, formatStateChange(c))
+	}
+	fmt.Print(table.Render())
+	return nil
+}
+
+// formatStateChange renders a change as "field: from → to".
+func formatStateChange(c beads.StateChange) string {
+	switch {
+	case c.Field == "created":
+		return fmt.Sprintf("created (%s)", c.To)
+	case c.Field == "description":
+		return "description edited"
+	case c.From == "":
+		return fmt.Sprintf("%s: set to %s", c.Field, c.To)
+	case c.To == "":
+		return fmt.Sprintf("%s: cleared (was %s)", c.Field, c.From)
+	}
+	return fmt.Sprintf("%s: %s → %s", c.Field, c.From, c.To)
+}
+
+// beadShowRequest is a gt bead show invocation that gt answers itself
+// instead of handing it to bd show.
+type beadShowRequest struct {
+	id                 string
+	format             string
+	json               bool
+	resolveIntegration bool
+}
+
+// parseBeadShowArgs picks gt's own flags out of the raw bead show args.
+// native is false when none are present, in which case the args belong to
+// bd show untouched. gt's flags can't be mixed with bd show flags.
+func parseBeadShowArgs(args []string) (req beadShowRequest, native bool, err error) {
+	var ids, bdFlags []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--json":
+			req.json, native = true, true
+		case arg == "--resolve-integration":
+			req.resolveIntegration, native = true, true
+		case arg == "--format":
+			if i+1 >= len(args) {
+				return req, false, fmt.Errorf("--format requires a value")
+			}
+			i++
+			req.format, native = args[i], true
+		case strings.HasPrefix(arg, "--format="):
+			req.format, native = strings.TrimPrefix(arg, "--format="), true
+		case strings.HasPrefix(arg, "-"):
+			bdFlags = append(bdFlags, arg)
+		default:
+			ids = append(ids, arg)
+		}
+	}
+	if !native {
+		return req, false, nil
+	}
+	if len(bdFlags) > 0 {
+		return req, false, fmt.Errorf("bd show flag %s can't be combined with --json, --format or --resolve-integration", bdFlags[0])
+	}
+	if len(ids) != 1 {
+		return req, false, fmt.Errorf("expected exactly one bead ID, got %d", len(ids))
+	}
+	req.id = ids[0]
+	return req, true, nil
+}
+
+// beadShowFormat resolves the output format for req. --json is an alias
+// for --format json, as on commands with a FormatFlag.
+func beadShowFormat(req beadShowRequest) (output.Format, error) {
+	format, err := output.ResolveFormat(req.format)
+	if err != nil {
+		return "", err
+	}
+	if req.json {
+		if req.format != "" && format != output.FormatJSON {
+			return "", fmt.Errorf("--json conflicts with --format %s", req.format)
+		}
+		return output.FormatJSON, nil
+	}
+	return format, nil
+}
+
+// BeadShowOutput is the payload of gt bead show --json: the issue as bd
+// returns it plus the branch fields the merge queue parses from it.
+type BeadShowOutput struct {
+	*beads.Issue
+	IntegrationBranchField string `json:"integration_branch,omitempty"` // integration_branch: from the description
+	BaseBranchField        string `json:"base_branch,omitempty"`        // base_branch: from the description
+	// ResolvedIntegrationBranch is set only with --resolve-integration;
+	// an empty string means no ancestor epic has an integration branch.
+	ResolvedIntegrationBranch *string `json:"resolved_integration_branch,omitempty"`
+}
+
+func runBeadShow(cmd *cobra.Command, args []string) error {
+	req, native, err := parseBeadShowArgs(args)
+	if err != nil {
+		return err
+	}
+	if !native {
+		return runShow(cmd, args)
+	}
+	format, err := beadShowFormat(req)
+	if err != nil {
+		return err
+	}
+
+	bd := beads.New(resolveBeadDir(req.id))
+	var checker beads.BranchChecker
+	if req.resolveIntegration {
+		townRoot, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		}
+		_, r, err := findCurrentRig(townRoot)
+		if err != nil {
+			return err
+		}
+		g, err := getRigGit(r.Path)
+		if err != nil {
+			return fmt.Errorf("initializing git: %w", err)
+		}
+		checker = g
+	}
+
+	out, err := buildBeadShowOutput(bd, checker, req.id)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatText {
+		return output.PrintFormatted(out, format)
+	}
+	printBeadShowText(out)
+	return nil
+}
+
+// buildBeadShowOutput reads id and parses its branch fields. When checker
+// is non-nil it also resolves the integration branch the way gt mq submit
+// does, reusing the issue already read for the first step of the walk.
+func buildBeadShowOutput(shower beads.IssueShower, checker beads.BranchChecker, id string) (*BeadShowOutput, error) {
+	cache := beads.NewIssueCache(shower)
+	issue, err := cache.Show(id)
+	if err != nil {
+		return nil, fmt.Errorf("getting bead %s: %w", id, err)
+	}
+	out := &BeadShowOutput{
+		Issue:                  issue,
+		IntegrationBranchField: beads.GetIntegrationBranchField(issue.Description),
+		BaseBranchField:        beads.GetBaseBranchField(issue.Description),
+	}
+	if checker != nil {
+		branch, err := beads.DetectIntegrationBranch(cache, checker, id)
+		if err != nil {
+			return nil, fmt.Errorf("resolving integration branch: %w", err)
+		}
+		out.ResolvedIntegrationBranch = &branch
+	}
+	return out, nil
+}
+
+func printBeadShowText(out *BeadShowOutput) {
+	fmt.Printf("%s %s: %s\n", style.Bold.Render("●"), out.ID, out.Title)
+	fmt.Printf("  Type: %s  Status: %s  Priority: P%d\n", out.Type, out.Status, out.Priority)
+	if out.Parent != "" {
+		fmt.Printf("  Parent: %s\n", out.Parent)
+	}
+	if out.Assignee != "" {
+		fmt.Printf("  Assignee: %s\n", out.Assignee)
+	}
+	if len(out.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(out.Labels, ", "))
+	}
+	if out.IntegrationBranchField != "" {
+		fmt.Printf("  Integration branch: %s\n", out.IntegrationBranchField)
+	}
+	if out.BaseBranchField != "" {
+		fmt.Printf("  Base branch: %s\n", out.BaseBranchField)
+	}
+	if out.ResolvedIntegrationBranch != nil {
+		resolved := *out.ResolvedIntegrationBranch
+		if resolved == "" {
+			resolved = style.Dim.Render("(none)")
+		}
+		fmt.Printf("  Resolved integration branch: %s\n", resolved)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+func TestParseBeadShowArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantNative bool
+		want       beadShowRequest
+		wantErr    string
+	}{
+		{name: "plain id passes through", args: []string{"gt-abc"}},
+		{name: "bd flags pass through", args: []string{"gt-abc", "--short"}},
+		{name: "help passes through", args: []string{"--help"}},
+		{name: "json", args: []string{"gt-abc", "--json"}, wantNative: true,
+			want: beadShowRequest{id: "gt-abc", json: true}},
+		{name: "format and resolve", args: []string{"--format", "json", "--resolve-integration", "gt-abc"}, wantNative: true,
+			want: beadShowRequest{id: "gt-abc", format: "json", resolveIntegration: true}},
+		{name: "format equals", args: []string{"gt-abc", "--format=text"}, wantNative: true,
+			want: beadShowRequest{id: "gt-abc", format: "text"}},
+		{name: "mixed with bd flag", args: []string{"gt-abc", "--json", "--short"}, wantErr: "--short"},
+		{name: "two ids", args: []string{"gt-a", "gt-b", "--json"}, wantErr: "exactly one bead ID"},
+		{name: "format without value", args: []string{"gt-abc", "--format"}, wantErr: "requires a value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, native, err := parseBeadShowArgs(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseBeadShowArgs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBeadShowArgs() error = %v", err)
+			}
+			if native != tt.wantNative {
+				t.Fatalf("native = %v, want %v", native, tt.wantNative)
+			}
+			if native && got != tt.want {
+				t.Errorf("parseBeadShowArgs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBeadShowFormat(t *testing.T) {
+	t.Setenv(output.FormatEnv, "")
+	if f, err := beadShowFormat(beadShowRequest{json: true}); err != nil || f != output.FormatJSON {
+		t.Errorf("--json: got %q, %v", f, err)
+	}
+	if f, err := beadShowFormat(beadShowRequest{resolveIntegration: true}); err != nil || f != output.FormatText {
+		t.Errorf("default: got %q, %v", f, err)
+	}
+	if _, err := beadShowFormat(beadShowRequest{json: true, format: "text"}); err == nil {
+		t.Error("expected --json to conflict with --format text")
+	}
+}
+
+func TestBuildBeadShowOutput_ResolvesIntegrationBranch(t *testing.T) {
+	tree := &fakeEpicTree{
+		issues: map[string]*beads.Issue{
+			"gt-epic": {ID: "gt-epic", Type: "epic", Title: "Auth overhaul", Labels: []string{"mq"},
+				Description: "Rework auth.\nintegration_branch: feature/auth\nbase_branch: develop"},
+			"gt-task": {ID: "gt-task", Type: "task", Parent: "gt-epic"},
+		},
+		remoteBranches: map[string]bool{"feature/auth": true},
+	}
+
+	out, err := buildBeadShowOutput(tree, tree, "gt-epic")
+	if err != nil {
+		t.Fatalf("buildBeadShowOutput() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := output.FprintFormatted(&buf, out, output.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", buf.String(), err)
+	}
+	for key, want := range map[string]string{
+		"id":                          "gt-epic",
+		"issue_type":                  "epic",
+		"integration_branch":          "feature/auth",
+		"base_branch":                 "develop",
+		"resolved_integration_branch": "feature/auth",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %q", key, got[key], want)
+		}
+	}
+	if labels, _ := got["labels"].([]any); len(labels) != 1 || labels[0] != "mq" {
+		t.Errorf("labels = %v, want [mq]", got["labels"])
+	}
+
+	// A child task resolves through its parent epic
+	out, err = buildBeadShowOutput(tree, tree, "gt-task")
+	if err != nil {
+		t.Fatalf("buildBeadShowOutput(gt-task) error = %v", err)
+	}
+	if out.ResolvedIntegrationBranch == nil || *out.ResolvedIntegrationBranch != "feature/auth" {
+		t.Errorf("resolved = %v, want feature/auth", out.ResolvedIntegrationBranch)
+	}
+	if out.IntegrationBranchField != "" {
+		t.Errorf("task has no integration_branch field, got %q", out.IntegrationBranchField)
+	}
+
+	// Without a checker nothing is resolved
+	out, err = buildBeadShowOutput(tree, nil, "gt-epic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.ResolvedIntegrationBranch != nil {
+		t.Errorf("resolved = %q without --resolve-integration", *out.ResolvedIntegrationBranch)
+	}
+
+	if _, err := buildBeadShowOutput(tree, nil, "gt-missing"); err == nil {
+		t.Error("expected error for unknown bead")
+	}
+}
+
+func TestFormatStateChange(t *testing.T) {
+	tests := []struct {
+		change beads.StateChange
+		want   string
+	}{
+		{beads.StateChange{Field: "created", To: "open"}, "created (open)"},
+		{beads.StateChange{Field: "status", From: "open", To: "closed"}, "status: open → closed"},
+		{beads.StateChange{Field: "assignee", To: "gastown/crew/max"}, "assignee: set to gastown/crew/max"},
+		{beads.StateChange{Field: "parent", From: "gt-epic"}, "parent: cleared (was gt-epic)"},
+		{beads.StateChange{Field: "description", To: "(edited)"}, "description edited"},
+	}
+	for _, tt := range tests {
+		if got := formatStateChange(tt.change); got != tt.want {
+			t.Errorf("formatStateChange(%+v) = %q, want %q", tt.change, got, tt.want)
+		}
+	}
+}
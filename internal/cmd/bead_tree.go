@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/output"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var beadTreeCmd = &cobra.Command{
+	Use:   "tree <bead-id>",
+	Short: "Show the hierarchy of beads under an epic",
+	Long: `Show every bead under a bead (usually an epic) as an indented tree, with
+each one's status icon (○ open, ▶ in progress, ✓ closed) and the merge
+requests submitted for it. Children that are still open, or have no merge
+request, are what keeps an epic from landing.
+
+An MR is shown under the bead named by its source_issue, or the issue ID in
+its source branch name. The walk stops after the same depth that bounds
+parent-chain lookups; deeper levels are marked as not shown.
+
+Examples:
+  gt bead tree gt-auth-epic
+  gt bead tree gt-auth-epic --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadTree,
+}
+
+var beadTreeFormat *output.FormatFlag
+
+func init() {
+	beadTreeFormat = output.NewFormatFlag(beadTreeCmd)
+	beadCmd.AddCommand(beadTreeCmd)
+}
+
+// BeadTreeNode is one bead in gt bead tree output.
+type BeadTreeNode struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title"`
+	Type      string          `json:"type,omitempty"`
+	Status    string          `json:"status"`
+	MRs       []BeadTreeMR    `json:"merge_requests,omitempty"`
+	Truncated bool            `json:"truncated,omitempty"` // Has children below the depth limit
+	Children  []*BeadTreeNode `json:"children,omitempty"`
+}
+
+// BeadTreeMR is a merge request submitted for a bead in the tree.
+type BeadTreeMR struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func runBeadTree(cmd *cobra.Command, args []string) error {
+	format, err := beadTreeFormat.Resolve()
+	if err != nil {
+		return err
+	}
+	rootID := args[0]
+	bd := beads.New(resolveBeadDir(rootID))
+
+	root, err := bd.Show(rootID)
+	if err != nil {
+		if err == beads.ErrNotFound {
+			return fmt.Errorf("bead '%s' not found", rootID)
+		}
+		return fmt.Errorf("fetching %s: %w", rootID, err)
+	}
+	mrs, err := bd.List(beads.ListOptions{
+		Label:    "gt:merge-request",
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil {
+		return fmt.Errorf("querying merge requests: %w", err)
+	}
+
+	tree, err := buildBeadTree(root, bd.Children, indexMRsByWorkItem(mrs), beads.MaxParentDepth)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatText {
+		return output.PrintFormatted(tree, format)
+	}
+	renderBeadTree(os.Stdout, tree)
+	return nil
+}
+
+// indexMRsByWorkItem groups merge requests by the issue they deliver (see
+// mrWorkItem), oldest first.
+func indexMRsByWorkItem(mrs []*beads.Issue) map[string][]BeadTreeMR {
+	sorted := append([]*beads.Issue(nil), mrs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt < sorted[j].CreatedAt })
+
+	index := map[string][]BeadTreeMR{}
+	for _, mr := range sorted {
+		fields := beads.ParseMRFields(mr)
+		if fields == nil {
+			continue
+		}
+		if issue := mrWorkItem(fields); issue != "" {
+			index[issue] = append(index[issue], BeadTreeMR{ID: mr.ID, Status: mr.Status})
+		}
+	}
+	return index
+}
+
+// buildBeadTree assembles the tree under root, listing each bead's children
+// with children. Beads more than maxDepth levels below root are left out and
+// their parent marked Truncated. A bead reached twice (a corrupted parent
+// link) is listed only the first time.
+func buildBeadTree(root *beads.Issue, children func(parentID string) ([]*beads.Issue, error), mrs map[string][]BeadTreeMR, maxDepth int) (*BeadTreeNode, error) {
+	seen := map[string]bool{}
+	var build func(issue *beads.Issue, depth int) (*BeadTreeNode, error)
+	build = func(issue *beads.Issue, depth int) (*BeadTreeNode, error) {
+		seen[issue.ID] = true
+		node := &BeadTreeNode{
+			ID:     issue.ID,
+			Title:  issue.Title,
+			Type:   issue.Type,
+			Status: issue.Status,
+			MRs:    mrs[issue.ID],
+		}
+		kids, err := children(issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("listing children of %s: %w", issue.ID, err)
+		}
+		if depth >= maxDepth {
+			node.Truncated = len(kids) > 0
+			return node, nil
+		}
+		sort.SliceStable(kids, func(i, j int) bool { return kids[i].ID < kids[j].ID })
+		for _, kid := range kids {
+			if seen[kid.ID] {
+				continue
+			}
+			child, err := build(kid, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, child)
+		}
+		return node, nil
+	}
+	return build(root, 0)
+}
+
+// renderBeadTree writes the tree with box-drawing branches.
+func renderBeadTree(w io.Writer, root *BeadTreeNode) {
+	fmt.Fprintf(w, "%s\n", beadTreeLine(root))
+	var walk func(node *BeadTreeNode, prefix string)
+	walk = func(node *BeadTreeNode, prefix string) {
+		for i, child := range node.Children {
+			branch, indent := "├── ", "│   "
+			if i == len(node.Children)-1 && !node.Truncated {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, beadTreeLine(child))
+			walk(child, prefix+indent)
+		}
+		if node.Truncated {
+			fmt.Fprintf(w, "%s└── %s\n", prefix, style.Dim.Render("… (deeper levels not shown)"))
+		}
+	}
+	walk(root, "")
+}
+
+// beadTreeLine formats one bead: status icon, ID, title and its MRs.
+func beadTreeLine(n *BeadTreeNode) string {
+	line := fmt.Sprintf("%s %s  %s", getStatusIcon(n.Status), n.ID, n.Title)
+	for _, mr := range n.MRs {
+		line += "  " + style.Dim.Render(fmt.Sprintf("[MR %s %s]", mr.ID, getStatusIcon(mr.Status)))
+	}
+	return line
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// fixtureChildren serves bd children lookups from a parent -> children map.
+func fixtureChildren(issues map[string][]*beads.Issue) func(string) ([]*beads.Issue, error) {
+	return func(parentID string) ([]*beads.Issue, error) {
+		return issues[parentID], nil
+	}
+}
+
+// treeIDs flattens a tree into IDs indented by depth, in render order.
+func treeIDs(n *BeadTreeNode, depth int) []string {
+	ids := []string{strings.Repeat(" ", depth) + n.ID}
+	for _, c := range n.Children {
+		ids = append(ids, treeIDs(c, depth+1)...)
+	}
+	return ids
+}
+
+func TestBuildBeadTree(t *testing.T) {
+	epic := &beads.Issue{ID: "gt-epic", Title: "Auth", Type: "epic", Status: "open"}
+	children := fixtureChildren(map[string][]*beads.Issue{
+		"gt-epic": {
+			{ID: "gt-signup", Title: "Signup", Status: "in_progress"},
+			{ID: "gt-login", Title: "Login", Status: "closed"},
+		},
+		"gt-signup": {
+			{ID: "gt-signup.1", Title: "Validation", Status: "open"},
+		},
+		"gt-signup.1": {
+			{ID: "gt-epic", Title: "Auth", Status: "open"}, // corrupted link back to the root
+		},
+	})
+	mrs := indexMRsByWorkItem([]*beads.Issue{
+		{ID: "gt-mr2", Status: "open", CreatedAt: "2026-01-02", Description: "branch: polecat/nux/gt-login\ntarget: main"},
+		{ID: "gt-mr1", Status: "closed", CreatedAt: "2026-01-01", Description: "branch: polecat/nux/x\nsource_issue: gt-login"},
+		{ID: "gt-mr3", Status: "open", Description: "branch: polecat/toast/gt-signup.1"},
+	})
+
+	tree, err := buildBeadTree(epic, children, mrs, beads.MaxParentDepth)
+	if err != nil {
+		t.Fatalf("buildBeadTree() error = %v", err)
+	}
+
+	want := []string{"gt-epic", " gt-login", " gt-signup", "  gt-signup.1"}
+	if got := treeIDs(tree, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+	login := tree.Children[0]
+	if want := []BeadTreeMR{{ID: "gt-mr1", Status: "closed"}, {ID: "gt-mr2", Status: "open"}}; !reflect.DeepEqual(login.MRs, want) {
+		t.Errorf("gt-login MRs = %v, want %v (oldest first)", login.MRs, want)
+	}
+	if sub := tree.Children[1].Children[0]; len(sub.MRs) != 1 || sub.MRs[0].ID != "gt-mr3" {
+		t.Errorf("gt-signup.1 MRs = %v, want gt-mr3 from its branch name", sub.MRs)
+	}
+	if tree.Truncated {
+		t.Error("tree within the depth limit should not be truncated")
+	}
+}
+
+func TestBuildBeadTree_DepthLimit(t *testing.T) {
+	// A chain gt-0 -> gt-1 -> ... -> gt-5
+	chain := map[string][]*beads.Issue{}
+	for i := 0; i < 5; i++ {
+		parent := "gt-" + string(rune('0'+i))
+		chain[parent] = []*beads.Issue{{ID: "gt-" + string(rune('1'+i)), Status: "open"}}
+	}
+	root := &beads.Issue{ID: "gt-0", Status: "open"}
+
+	tree, err := buildBeadTree(root, fixtureChildren(chain), nil, 2)
+	if err != nil {
+		t.Fatalf("buildBeadTree() error = %v", err)
+	}
+	if got, want := treeIDs(tree, 0), []string{"gt-0", " gt-1", "  gt-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %q, want %q", got, want)
+	}
+	leaf := tree.Children[0].Children[0]
+	if !leaf.Truncated || leaf.Children != nil {
+		t.Errorf("gt-2 = %+v, want truncated with no children listed", leaf)
+	}
+
+	var out bytes.Buffer
+	renderBeadTree(&out, tree)
+	if !strings.Contains(out.String(), "deeper levels not shown") {
+		t.Errorf("render = %q, want a truncation marker", out.String())
+	}
+}
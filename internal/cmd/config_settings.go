@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// mergeQueueKeyPrefix is the section of rig settings gt config get/set edit.
+const mergeQueueKeyPrefix = "merge_queue."
+
+var configSettingRig string
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show a rig merge_queue setting",
+	Long: `Show a merge_queue field from the rig's settings/config.json.
+
+Keys are merge_queue.<field>, using the JSON field name. Nothing is printed
+for a field that isn't set (its default applies). Run with no arguments to
+list the supported keys.
+
+Examples:
+  gt config get merge_queue.test_command
+  gt config get merge_queue.integration_branch_template --rig gastown`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a rig merge_queue setting",
+	Long: `Set a merge_queue field in the rig's settings/config.json.
+
+The value is checked against the field's type (true/false for booleans,
+whole numbers for counts) and against the same rules gt applies when loading
+settings, so the file can't be left malformed. Branch and tag templates must
+expand to a valid branch name. Fields gt doesn't know are kept as they are.
+
+Examples:
+  gt config set merge_queue.test_command "go test ./..."
+  gt config set merge_queue.integration_branch_auto_land true
+  gt config set merge_queue.integration_branch_template "feat/{epic}" --rig gastown`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+func init() {
+	configGetCmd.Flags().StringVar(&configSettingRig, "rig", "", "Rig to read (default: current rig)")
+	configSetCmd.Flags().StringVar(&configSettingRig, "rig", "", "Rig to change (default: current rig)")
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, key := range mergeQueueSettingKeys() {
+			fmt.Println(key)
+		}
+		return nil
+	}
+	path, err := configSettingsPath()
+	if err != nil {
+		return err
+	}
+	value, err := getRigSetting(path, args[0])
+	if err != nil {
+		return err
+	}
+	if value != "" {
+		fmt.Println(value)
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	path, err := configSettingsPath()
+	if err != nil {
+		return err
+	}
+	if err := setRigSetting(path, args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("%s = %s\n", args[0], args[1])
+	return nil
+}
+
+// configSettingsPath returns the settings file of --rig, or of the current
+// rig when --rig isn't given.
+func configSettingsPath() (string, error) {
+	if configSettingRig != "" {
+		_, r, err := getRig(configSettingRig)
+		if err != nil {
+			return "", err
+		}
+		return config.RigSettingsPath(r.Path), nil
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	_, r, err := findCurrentRig(townRoot)
+	if err != nil {
+		return "", err
+	}
+	return config.RigSettingsPath(r.Path), nil
+}
+
+// mergeQueueField returns the MergeQueueConfig field for key.
+func mergeQueueField(key string) (reflect.StructField, error) {
+	name, ok := strings.CutPrefix(key, mergeQueueKeyPrefix)
+	if ok {
+		t := reflect.TypeOf(config.MergeQueueConfig{})
+		for i := 0; i < t.NumField(); i++ {
+			if jsonFieldName(t.Field(i)) == name {
+				return t.Field(i), nil
+			}
+		}
+	}
+	return reflect.StructField{}, fmt.Errorf("unknown setting %q (run 'gt config get' to list keys)", key)
+}
+
+// jsonFieldName returns the JSON name of a struct field.
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// mergeQueueSettingKeys returns every key gt config get/set accepts, sorted.
+func mergeQueueSettingKeys() []string {
+	t := reflect.TypeOf(config.MergeQueueConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		keys = append(keys, mergeQueueKeyPrefix+jsonFieldName(t.Field(i)))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseSettingValue converts value to the JSON value for field, rejecting
+// values of the wrong type.
+func parseSettingValue(field reflect.StructField, key, value string) (any, error) {
+	kind := field.Type.Kind()
+	if kind == reflect.Pointer {
+		kind = field.Type.Elem().Kind()
+	}
+	switch kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
+		return b, nil
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number, got %q", key, value)
+		}
+		return n, nil
+	case reflect.String:
+		switch key {
+		case mergeQueueKeyPrefix + "integration_branch_template", mergeQueueKeyPrefix + "tag_on_land":
+			if value == "" {
+				break
+			}
+			if err := validateBranchName(templatePlaceholderRegex.ReplaceAllString(value, "x")); err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("%s can't be set from the command line", key)
+}
+
+// readRawSettings reads a settings file as raw JSON objects so fields gt
+// doesn't model survive a rewrite. A missing file is an empty settings
+// object when missingOK is set.
+func readRawSettings(path string, missingOK bool) (map[string]json.RawMessage, error) {
+	raw := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the rig's settings file
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && missingOK {
+			return raw, nil
+		}
+		return nil, fmt.Errorf("reading settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return raw, nil
+}
+
+// getRigSetting returns the value of key in the settings file at path:
+// strings as-is, other values as JSON. It is "" when the key isn't set.
+func getRigSetting(path, key string) (string, error) {
+	field, err := mergeQueueField(key)
+	if err != nil {
+		return "", err
+	}
+	raw, err := readRawSettings(path, false)
+	if err != nil {
+		return "", err
+	}
+	mq := map[string]json.RawMessage{}
+	if data, ok := raw["merge_queue"]; ok {
+		if err := json.Unmarshal(data, &mq); err != nil {
+			return "", fmt.Errorf("parsing merge_queue: %w", err)
+		}
+	}
+	value, ok := mq[jsonFieldName(field)]
+	if !ok || string(value) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s, nil
+	}
+	return string(value), nil
+}
+
+// setRigSetting sets key to value in the settings file at path, creating
+// the file if needed. The result is validated as rig settings before it is
+// written, and other fields are preserved.
+func setRigSetting(path, key, value string) error {
+	field, err := mergeQueueField(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := parseSettingValue(field, key, value)
+	if err != nil {
+		return err
+	}
+
+	raw, err := readRawSettings(path, true)
+	if err != nil {
+		return err
+	}
+	if _, ok := raw["type"]; !ok {
+		raw["type"] = json.RawMessage(`"rig-settings"`)
+		raw["version"] = json.RawMessage(strconv.Itoa(config.CurrentRigSettingsVersion))
+	}
+	mq := map[string]json.RawMessage{}
+	if data, ok := raw["merge_queue"]; ok && string(data) != "null" {
+		if err := json.Unmarshal(data, &mq); err != nil {
+			return fmt.Errorf("parsing merge_queue: %w", err)
+		}
+	}
+	encoded, err := json.Marshal(parsed)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", key, err)
+	}
+	mq[jsonFieldName(field)] = encoded
+	if raw["merge_queue"], err = json.Marshal(mq); err != nil {
+		return fmt.Errorf("encoding merge_queue: %w", err)
+	}
+
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding settings: %w", err)
+	}
+	if _, err := config.ParseRigSettings(data); err != nil {
+		return fmt.Errorf("%s = %q: %w", key, value, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating settings directory: %w", err)
+	}
+	if err := util.AtomicWriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing settings: %w", err)
+	}
+	return nil
+}
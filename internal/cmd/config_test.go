@@ -581,3 +581,76 @@ func TestConfigDefaultAgent(t *testing.T) {
 		}
 	})
 }
+
+func TestSetRigSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings", "config.json")
+	initial := `{
+  "type": "rig-settings",
+  "version": 1,
+  "merge_queue": {"enabled": true, "on_conflict": "assign_back", "future_field": "keep me"},
+  "theme": {"name": "ocean"}
+}`
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(initial), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setRigSetting(path, "merge_queue.integration_branch_auto_land", "true"); err != nil {
+		t.Fatalf("setting a bool: %v", err)
+	}
+	if err := setRigSetting(path, "merge_queue.test_command", "go test ./..."); err != nil {
+		t.Fatalf("setting a string: %v", err)
+	}
+
+	settings, err := config.LoadRigSettings(path)
+	if err != nil {
+		t.Fatalf("settings no longer load: %v", err)
+	}
+	if !settings.MergeQueue.IsIntegrationBranchAutoLandEnabled() {
+		t.Error("integration_branch_auto_land was not set")
+	}
+	if settings.MergeQueue.TestCommand != "go test ./..." {
+		t.Errorf("test_command = %q", settings.MergeQueue.TestCommand)
+	}
+	if got, err := getRigSetting(path, "merge_queue.test_command"); err != nil || got != "go test ./..." {
+		t.Errorf("getRigSetting(test_command) = %q, %v", got, err)
+	}
+	if got, err := getRigSetting(path, "merge_queue.integration_branch_auto_land"); err != nil || got != "true" {
+		t.Errorf("getRigSetting(integration_branch_auto_land) = %q, %v", got, err)
+	}
+	if got, err := getRigSetting(path, "merge_queue.tag_on_land"); err != nil || got != "" {
+		t.Errorf("getRigSetting(unset) = %q, %v; want empty", got, err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, keep := range []string{`"future_field": "keep me"`, `"theme"`, `"on_conflict": "assign_back"`} {
+		if !strings.Contains(string(data), keep) {
+			t.Errorf("settings lost %s:\n%s", keep, data)
+		}
+	}
+}
+
+func TestSetRigSetting_Rejects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	tests := []struct {
+		key, value, wantErr string
+	}{
+		{"merge_queue.no_such_field", "x", "unknown setting"},
+		{"namepool.style", "x", "unknown setting"},
+		{"merge_queue.run_tests", "yes please", "must be true or false"},
+		{"merge_queue.max_concurrent", "two", "must be a whole number"},
+		{"merge_queue.integration_branch_template", "bad name/{epic}", "invalid characters"},
+		{"merge_queue.on_conflict", "merge_harder", "invalid on_conflict"},
+	}
+	for _, tt := range tests {
+		err := setRigSetting(path, tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("setRigSetting(%s, %q) error = %v, want %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("rejected values should not create the settings file")
+	}
+}
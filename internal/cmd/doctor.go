@@ -5,17 +5,23 @@ import (
 	"os"
 	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/workspace"
-	"github.com/spf13/cobra"
 )
 
 var (
 	doctorFix             bool
+	doctorPlan            bool
+	doctorJSON            bool
 	doctorVerbose         bool
 	doctorRig             string
 	doctorRestartSessions bool
 	doctorSlow            string
+	doctorFailOn          string
+	doctorFormat          *output.FormatFlag
 )
 
 var doctorCmd = &cobra.Command{
@@ -32,6 +38,8 @@ Workspace checks:
   - town-config-valid        Check mayor/town.json is valid
   - rigs-registry-exists     Check mayor/rigs.json exists (fixable)
   - rigs-registry-valid      Check registered rigs exist (fixable)
+  - unregistered-rigs        Detect rig directories missing from rigs.json
+  - worktree-health          Detect missing, branchless, or detached rig worktrees (fixable)
   - mayor-exists             Check mayor/ directory structure
 
 Town root protection:
@@ -50,6 +58,7 @@ Cleanup checks (fixable):
   - orphan-processes         Detect orphaned Claude processes
   - wisp-gc                  Detect and clean abandoned wisps (>1h)
   - stale-beads-redirect     Detect stale files in .beads directories with redirects
+  - mr-type-normalization    Detect merge requests not typed merge-request
 
 Clone divergence checks:
   - persistent-role-branches Detect crew/witness/refinery not on main
@@ -75,6 +84,12 @@ Routing checks (fixable):
   - routes-config            Check beads routing configuration
   - prefix-mismatch          Detect rigs.json vs routes.jsonl prefix mismatches (fixable)
   - database-prefix          Detect database vs routes.jsonl prefix mismatches (fixable)
+  - beads-route-consistency  Detect routes.jsonl prefixes that don't match actual issue IDs (fixable)
+
+Agent configuration checks:
+  - agent-tmux-config        Verify role agents have proper Tmux configuration
+  - role-config-drift        Detect rig role_agents overrides that leave stale town fields
+  - runtime-binary-check     Verify each role's runtime command (claude, opencode, ...) is installed
 
 Session hook checks:
   - session-hooks            Check settings.local.json use session-start.sh
@@ -87,24 +102,62 @@ Patrol checks:
   - patrol-plugins-accessible Verify plugin directories
   - patrol-roles-have-prompts Verify role prompts exist
 
+Custom checks:
+  Towns can add their own checks in settings/config.json under
+  custom_checks, each {"name", "command", "description", "fix_hint",
+  "timeout_seconds"}. The command runs through sh -c in the town root;
+  exit 0 is OK, 1 a warning, 2 (or any other failure) an error. The first
+  line of its output is the message and the rest are details. They appear
+  under Custom in the report.
+
 Use --fix to attempt automatic fixes for issues that support it.
+Use --fix --plan to preview what each fix would change without applying it.
+Add --json to print the plan as a JSON array of {"check", "plan"} entries,
+one per check that found a problem it can fix, for CI approval steps.
+Use --format json to print every check result (name, status, message,
+category, details, fix_hint) as a JSON array instead of the streamed report.
+--format toon prints the same results as a compact TOON table, easier to
+scan in a terminal.
 Use --rig to check a specific rig instead of the entire workspace.
-Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).`,
-	RunE: runDoctor,
+Use --slow to highlight slow checks (default threshold: 1s, e.g. --slow=500ms).
+Use --fail-on to set the exit-code threshold: ok, warning, error (default), or never.
+For example, --fail-on=error lets CI treat migration warnings as non-blocking.`,
+	RunE: withFormattedErrors(func() output.Format { return doctorErrorFormat() }, runDoctor),
 }
 
 func init() {
 	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Attempt to automatically fix issues")
+	doctorCmd.Flags().BoolVar(&doctorPlan, "plan", false, "Show what --fix would change without applying it (use with --fix)")
+	doctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Print the --fix --plan result as JSON")
 	doctorCmd.Flags().BoolVarP(&doctorVerbose, "verbose", "v", false, "Show detailed output")
 	doctorCmd.Flags().StringVar(&doctorRig, "rig", "", "Check specific rig only")
 	doctorCmd.Flags().BoolVar(&doctorRestartSessions, "restart-sessions", false, "Restart patrol sessions when fixing stale settings (use with --fix)")
 	doctorCmd.Flags().StringVar(&doctorSlow, "slow", "", "Highlight slow checks (optional threshold, default 1s)")
 	// Allow --slow without a value (uses default 1s)
 	doctorCmd.Flags().Lookup("slow").NoOptDefVal = "1s"
+	doctorFormat = output.NewFormatFlag(doctorCmd)
+	doctorCmd.Flags().StringVar(&doctorFailOn, "fail-on", "error", "Exit non-zero at this severity: ok, warning, error, never")
 	rootCmd.AddCommand(doctorCmd)
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorPlan && !doctorFix {
+		return fmt.Errorf("--plan requires --fix")
+	}
+	if doctorJSON && !doctorPlan {
+		return fmt.Errorf("--json requires --fix --plan")
+	}
+	format, err := doctorFormat.Resolve()
+	if err != nil {
+		return err
+	}
+	if doctorJSON {
+		if cmd.Flags().Changed("format") && format != output.FormatJSON {
+			return fmt.Errorf("--json conflicts with --format %s", format)
+		}
+		format = output.FormatJSON
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -142,9 +195,11 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewFormulaCheck())
 	d.Register(doctor.NewPrefixConflictCheck())
 	d.Register(doctor.NewRigNameMismatchCheck())
+	d.Register(doctor.NewUnregisteredRigCheck())
 	d.Register(doctor.NewPrefixMismatchCheck())
 	d.Register(doctor.NewDatabasePrefixCheck())
 	d.Register(doctor.NewRoutesCheck())
+	d.Register(doctor.NewRouteConsistencyCheck())
 	d.Register(doctor.NewRigRoutesJSONLCheck())
 	d.Register(doctor.NewRoutingModeCheck())
 	d.Register(doctor.NewOrphanSessionCheck())
@@ -171,11 +226,14 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewPatrolRolesHavePromptsCheck())
 	d.Register(doctor.NewAgentBeadsCheck())
 	d.Register(doctor.NewStaleAgentBeadsCheck())
+	d.Register(doctor.NewMRTypeNormalizationCheck())
 	d.Register(doctor.NewRigBeadsCheck())
 	d.Register(doctor.NewRoleBeadsCheck())
 
 	// Agent configuration checks
 	d.Register(doctor.NewAgentTmuxConfigCheck())
+	d.Register(doctor.NewRoleConfigDriftCheck())
+	d.Register(doctor.NewRuntimeBinaryCheck())
 
 	// NOTE: StaleAttachmentsCheck removed - staleness detection belongs in Deacon molecule
 
@@ -195,6 +253,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// Crew workspace checks
 	d.Register(doctor.NewCrewStateCheck())
 	d.Register(doctor.NewCrewWorktreeCheck())
+	d.Register(doctor.NewWorktreeHealthCheck())
 	d.Register(doctor.NewCommandsCheck())
 
 	// Lifecycle hygiene checks
@@ -218,6 +277,15 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		d.RegisterAll(doctor.RigChecks()...)
 	}
 
+	// Town-defined and externally registered checks run last
+	registerCustomChecks(townRoot)
+	d.RegisterAll(doctor.RegisteredChecks()...)
+
+	failOn, err := doctor.ParseFailOn(doctorFailOn)
+	if err != nil {
+		return err
+	}
+
 	// Parse slow threshold (0 = disabled)
 	var slowThreshold time.Duration
 	if doctorSlow != "" {
@@ -228,10 +296,32 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	var report *doctor.Report
+	if format != output.FormatText {
+		// Run silently so stdout carries only the payload
+		var payload any
+		switch {
+		case doctorPlan:
+			report = d.Plan(ctx)
+			payload = report.FixPlan()
+		case doctorFix:
+			report = d.Fix(ctx)
+			payload = report.Results()
+		default:
+			report = d.Run(ctx)
+			payload = report.Results()
+		}
+		if err := output.PrintFormatted(payload, format); err != nil {
+			return err
+		}
+		return doctorFailOnError(report, failOn)
+	}
+
 	// Run checks with streaming output
 	fmt.Println() // Initial blank line
-	var report *doctor.Report
-	if doctorFix {
+	if doctorPlan {
+		report = d.PlanStreaming(ctx, os.Stdout, slowThreshold)
+	} else if doctorFix {
 		report = d.FixStreaming(ctx, os.Stdout, slowThreshold)
 	} else {
 		report = d.RunStreaming(ctx, os.Stdout, slowThreshold)
@@ -240,11 +330,51 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	// Print summary (checks were already printed during streaming)
 	report.PrintSummaryOnly(os.Stdout, doctorVerbose, slowThreshold)
 
-	// Exit with error code if there are errors
-	if report.HasErrors() {
-		return fmt.Errorf("doctor found %d error(s)", report.Summary.Errors)
+	return doctorFailOnError(report, failOn)
+}
+
+// registerCustomChecks registers the custom_checks from town settings as
+// shell checks. Entries missing a name or command are reported and skipped.
+func registerCustomChecks(townRoot string) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: custom checks not loaded: %v\n", err)
+		return
+	}
+	for i, cc := range settings.CustomChecks {
+		if cc.Name == "" || cc.Command == "" {
+			fmt.Fprintf(os.Stderr, "warning: custom_checks[%d] needs both name and command; skipped\n", i)
+			continue
+		}
+		doctor.RegisterCheck(doctor.NewShellCheck(cc))
 	}
+}
 
-	return nil
+// doctorErrorFormat is the format errors are reported in: JSON when either
+// --json or --format json was given.
+func doctorErrorFormat() output.Format {
+	if doctorJSON {
+		return output.FormatJSON
+	}
+	if format, err := doctorFormat.Resolve(); err == nil {
+		return format
+	}
+	return output.FormatText
 }
 
+// doctorFailOnError returns an error when the report's worst status meets
+// the --fail-on threshold, so the command exits non-zero.
+func doctorFailOnError(report *doctor.Report, failOn doctor.FailOn) error {
+	if report.ShouldFail(failOn) {
+		switch report.WorstStatus() {
+		case doctor.StatusError:
+			return fmt.Errorf("doctor found %d error(s)", report.Summary.Errors)
+		case doctor.StatusWarning:
+			return fmt.Errorf("doctor found %d warning(s)", report.Summary.Warnings)
+		default:
+			return fmt.Errorf("doctor ran %d check(s) with --fail-on=ok", report.Summary.Total)
+		}
+	}
+
+	return nil
+}
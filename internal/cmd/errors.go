@@ -3,6 +3,9 @@ package cmd
 import (
 	"errors"
 	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/output"
 )
 
 // SilentExitError signals that the command should exit with a specific code
@@ -34,3 +37,42 @@ func IsSilentExit(err error) (int, bool) {
 	}
 	return 0, false
 }
+
+// withFormattedErrors wraps a command's RunE so a failure is reported by
+// output.PrintError in the command's output format (a JSON object on stderr
+// under --format json, an error: line under toon) instead of cobra's plain
+// "Error:" line. format is called only on failure, after flags are parsed.
+func withFormattedErrors(format func() output.Format, run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := run(cmd, args)
+		if err == nil {
+			return nil
+		}
+		if _, ok := IsSilentExit(err); ok {
+			return err
+		}
+		output.PrintError(err, format())
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return NewSilentExit(1)
+	}
+}
+
+// errorFormat is the format to report errors in for a command with a
+// --format flag: the resolved format, or text if the flag itself is invalid.
+func errorFormat(f *output.FormatFlag) output.Format {
+	format, err := f.Resolve()
+	if err != nil {
+		return output.FormatText
+	}
+	return format
+}
+
+// jsonErrorFormat is the format to report errors in for a command with a
+// --json boolean flag.
+func jsonErrorFormat(jsonFlag bool) output.Format {
+	if jsonFlag {
+		return output.FormatJSON
+	}
+	return output.FormatText
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/output"
 )
 
 func TestSilentExitError_Error(t *testing.T) {
@@ -90,3 +98,87 @@ func TestSilentExitError_Is(t *testing.T) {
 		t.Errorf("errors.As extracted code = %d, want 1", target.Code)
 	}
 }
+
+// captureStderr returns what fn writes to os.Stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("create pipe: %v", err)
+	}
+	os.Stderr = w
+
+	fn()
+
+	_ = w.Close()
+	os.Stderr = old
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		t.Fatalf("read stderr: %v", err)
+	}
+	_ = r.Close()
+	return buf.String()
+}
+
+func TestWithFormattedErrors(t *testing.T) {
+	jsonFormat := func() output.Format { return output.FormatJSON }
+	fail := func(*cobra.Command, []string) error { return errors.New("boom") }
+
+	cmd := &cobra.Command{}
+	var err error
+	stderr := captureStderr(t, func() {
+		err = withFormattedErrors(jsonFormat, fail)(cmd, nil)
+	})
+
+	if code, ok := IsSilentExit(err); !ok || code != 1 {
+		t.Errorf("error = %v, want silent exit 1", err)
+	}
+	if !cmd.SilenceErrors || !cmd.SilenceUsage {
+		t.Error("cobra should be told not to print the error or usage again")
+	}
+	var payload output.ErrorPayload
+	if jsonErr := json.Unmarshal([]byte(stderr), &payload); jsonErr != nil || payload.Error != "boom" {
+		t.Errorf("stderr = %q, want {\"error\":\"boom\"}", stderr)
+	}
+
+	succeed := func(*cobra.Command, []string) error { return nil }
+	if err := withFormattedErrors(jsonFormat, succeed)(&cobra.Command{}, nil); err != nil {
+		t.Errorf("success path error = %v", err)
+	}
+
+	// A silent exit already carries its own status; it is passed through
+	silent := func(*cobra.Command, []string) error { return NewSilentExit(2) }
+	stderr = captureStderr(t, func() {
+		err = withFormattedErrors(jsonFormat, silent)(&cobra.Command{}, nil)
+	})
+	if code, _ := IsSilentExit(err); code != 2 || stderr != "" {
+		t.Errorf("silent exit: code %d, stderr %q; want 2 and nothing printed", code, stderr)
+	}
+}
+
+func TestMqIntegrationStatus_JSONErrorOnStderr(t *testing.T) {
+	t.Setenv("GT_TOWN_ROOT", "")
+	t.Chdir(t.TempDir())
+	if err := mqIntegrationStatusCmd.Flags().Set("format", "json"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = mqIntegrationStatusCmd.Flags().Set("format", "") })
+
+	var err error
+	stderr := captureStderr(t, func() {
+		err = mqIntegrationStatusCmd.RunE(mqIntegrationStatusCmd, []string{"gt-epic"})
+	})
+	if err == nil {
+		t.Fatal("expected failure outside a workspace")
+	}
+
+	var payload output.ErrorPayload
+	if jsonErr := json.Unmarshal([]byte(stderr), &payload); jsonErr != nil {
+		t.Fatalf("stderr is not a JSON error: %q", stderr)
+	}
+	if !strings.Contains(payload.Error, "not in a Gas Town workspace") {
+		t.Errorf("error = %q", payload.Error)
+	}
+}
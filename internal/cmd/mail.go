@@ -25,11 +25,14 @@ var (
 	mailInboxIdentity string
 	mailCheckInject   bool
 	mailCheckJSON     bool
+	mailCheckCount    bool
 	mailCheckIdentity string
 	mailThreadJSON    bool
 	mailReplySubject  string
 	mailReplyMessage  string
-	mailStdin         bool // Read message body from stdin
+	mailStdin         bool   // Read message body from stdin
+	mailSendTo        string // Recipient via --to (alternative to the positional address)
+	mailBodyFile      string // Read message body from a file ("-" for stdin)
 
 	// Search flags
 	mailSearchFrom    string
@@ -47,6 +50,16 @@ var (
 	// Archive flags
 	mailArchiveStale  bool
 	mailArchiveDryRun bool
+
+	// Prune flags
+	mailPruneOlderThan string
+	mailPruneReadOnly  bool
+	mailPruneDryRun    bool
+
+	// Broadcast flags
+	mailBroadcastSubject string
+	mailBroadcastBody    string
+	mailBroadcastRole    string
 )
 
 var mailCmd = &cobra.Command{
@@ -92,7 +105,7 @@ COMMANDS:
 }
 
 var mailSendCmd = &cobra.Command{
-	Use:   "send <address>",
+	Use:   "send [<address>]",
 	Short: "Send a message",
 	Long: `Send a message to an agent.
 
@@ -122,6 +135,12 @@ Priority levels:
 
 Use --urgent as shortcut for --priority 0.
 
+The recipient may be given as the argument or with --to. The body comes
+from --message/--body, --body-file (a path, or "-" for stdin), or --stdin.
+With none of these, the body is read from stdin when stdin is a pipe or
+file, so 'some-cmd | gt mail send mayor/ -s "Report"' works. Sending to an
+address with no matching agent fails with an error naming the address.
+
 Examples:
   gt mail send greenplace/Toast -s "Status check" -m "How's that bug fix going?"
   gt mail send mayor/ -s "Work complete" -m "Finished gt-abc"
@@ -132,6 +151,8 @@ Examples:
   gt mail send --self -s "Handoff" -m "Context for next session"
   gt mail send greenplace/Toast -s "Update" -m "Progress report" --cc overseer
   gt mail send list:oncall -s "Alert" -m "System down"
+  gt mail send --to mayor/ -s "Report" --body-file report.md
+  git log -5 --oneline | gt mail send --to mayor/ -s "Recent commits"
 
   # Read body from stdin (avoids shell quoting issues):
   gt mail send mayor/ -s "Update" --stdin <<'BODY'
@@ -271,11 +292,15 @@ Exit codes (--inject mode):
   0 - Always (hooks should never block)
   Output: system-reminder if mail exists, silent if no mail
 
+Exit codes (--count-only mode):
+  0 - Always; prints only the unread count (e.g., "3")
+
 Use --identity for polecats to explicitly specify their identity.
 
 Examples:
   gt mail check                           # Simple check (auto-detect identity)
   gt mail check --inject                  # For hooks
+  test $(gt mail check --count-only) -gt 0   # For shell scripts
   gt mail check --identity greenplace/Toast  # Explicit polecat identity`,
 	RunE: runMailCheck,
 }
@@ -388,6 +413,25 @@ Examples:
 	RunE: runMailClear,
 }
 
+var mailPruneCmd = &cobra.Command{
+	Use:     "prune [target]",
+	Aliases: []string{"gc"},
+	Short:   "Remove old messages from an inbox",
+	Long: `Remove messages older than a cutoff from an inbox.
+
+Mailboxes otherwise keep read messages forever, which slows inbox listing.
+--older-than takes a duration with an optional day suffix (72h, 30d).
+Use --read-only to keep unread messages however old they are, and
+--dry-run to list what would be removed.
+
+Examples:
+  gt mail prune --older-than 30d
+  gt mail prune --older-than 7d --read-only
+  gt mail prune gastown/witness --older-than 14d --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMailPrune,
+}
+
 var mailSearchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search messages by content",
@@ -449,12 +493,32 @@ Examples:
 	RunE: runMailAnnounces,
 }
 
+var mailBroadcastCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Send a message to every agent in the town",
+	Long: `Send a town-wide announcement to every agent's mailbox.
+
+Each agent (town-level and in every rig) receives its own copy. Agents whose
+mailbox can't be reached are reported, but delivery to the rest continues.
+
+Use --role to target only agents of one role (e.g., witness, refinery,
+polecat, crew, deacon).
+
+Examples:
+  gt mail broadcast -s "Maintenance" -m "Town maintenance in 10 minutes"
+  gt mail broadcast --role witness -s "Patrol" -m "Pause patrols until 14:00"`,
+	Args: cobra.NoArgs,
+	RunE: runMailBroadcast,
+}
+
 func init() {
 	// Send flags
 	mailSendCmd.Flags().StringVarP(&mailSubject, "subject", "s", "", "Message subject (required)")
 	mailSendCmd.Flags().StringVarP(&mailBody, "message", "m", "", "Message body")
 	mailSendCmd.Flags().StringVar(&mailBody, "body", "", "Alias for --message")
 	mailSendCmd.Flags().BoolVar(&mailStdin, "stdin", false, "Read message body from stdin (avoids shell quoting issues)")
+	mailSendCmd.Flags().StringVar(&mailSendTo, "to", "", "Recipient address (alternative to the positional address)")
+	mailSendCmd.Flags().StringVar(&mailBodyFile, "body-file", "", "Read message body from a file (\"-\" for stdin)")
 	mailSendCmd.Flags().IntVar(&mailPriority, "priority", 2, "Message priority (0=urgent, 1=high, 2=normal, 3=low, 4=backlog)")
 	mailSendCmd.Flags().BoolVar(&mailUrgent, "urgent", false, "Set priority=0 (urgent)")
 	mailSendCmd.Flags().StringVar(&mailType, "type", "notification", "Message type (task, scavenge, notification, reply)")
@@ -480,6 +544,7 @@ func init() {
 	// Check flags
 	mailCheckCmd.Flags().BoolVar(&mailCheckInject, "inject", false, "Output format for Claude Code hooks")
 	mailCheckCmd.Flags().BoolVar(&mailCheckJSON, "json", false, "Output as JSON")
+	mailCheckCmd.Flags().BoolVar(&mailCheckCount, "count-only", false, "Print only the unread count and exit 0")
 	mailCheckCmd.Flags().StringVar(&mailCheckIdentity, "identity", "", "Explicit identity for inbox (e.g., greenplace/Toast)")
 	mailCheckCmd.Flags().StringVar(&mailCheckIdentity, "address", "", "Alias for --identity")
 
@@ -508,6 +573,18 @@ func init() {
 	mailArchiveCmd.Flags().BoolVar(&mailArchiveStale, "stale", false, "Archive messages sent before session start")
 	mailArchiveCmd.Flags().BoolVarP(&mailArchiveDryRun, "dry-run", "n", false, "Show what would be archived without archiving")
 
+	// Prune flags
+	mailPruneCmd.Flags().StringVar(&mailPruneOlderThan, "older-than", "", "Remove messages older than this (e.g., 72h, 30d) (required)")
+	mailPruneCmd.Flags().BoolVar(&mailPruneReadOnly, "read-only", false, "Only remove messages that have been read")
+	mailPruneCmd.Flags().BoolVarP(&mailPruneDryRun, "dry-run", "n", false, "Show what would be removed without removing")
+	_ = mailPruneCmd.MarkFlagRequired("older-than")
+
+	// Broadcast flags
+	mailBroadcastCmd.Flags().StringVarP(&mailBroadcastSubject, "subject", "s", "", "Message subject (required)")
+	mailBroadcastCmd.Flags().StringVarP(&mailBroadcastBody, "message", "m", "", "Message body")
+	mailBroadcastCmd.Flags().StringVar(&mailBroadcastRole, "role", "", "Only send to agents with this role (e.g., witness, polecat)")
+	_ = mailBroadcastCmd.MarkFlagRequired("subject")
+
 	// Add subcommands
 	mailCmd.AddCommand(mailSendCmd)
 	mailCmd.AddCommand(mailInboxCmd)
@@ -523,8 +600,10 @@ func init() {
 	mailCmd.AddCommand(mailClaimCmd)
 	mailCmd.AddCommand(mailReleaseCmd)
 	mailCmd.AddCommand(mailClearCmd)
+	mailCmd.AddCommand(mailPruneCmd)
 	mailCmd.AddCommand(mailSearchCmd)
 	mailCmd.AddCommand(mailAnnouncesCmd)
+	mailCmd.AddCommand(mailBroadcastCmd)
 
 	rootCmd.AddCommand(mailCmd)
 }
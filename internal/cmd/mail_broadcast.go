@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+func runMailBroadcast(cmd *cobra.Command, args []string) error {
+	// All mail uses town beads (two-level architecture)
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	from := detectSender()
+	router := mail.NewRouter(workDir)
+
+	delivered, err := router.BroadcastToRole(from, mailBroadcastSubject, mailBroadcastBody, mailBroadcastRole)
+	if err != nil {
+		if delivered == 0 {
+			return fmt.Errorf("broadcast failed: %w", err)
+		}
+		// Partial failure: some mailboxes missing, the rest received it
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+	}
+
+	target := "@all"
+	if mailBroadcastRole != "" {
+		target = "@" + mailBroadcastRole
+	}
+	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(target, mailBroadcastSubject))
+
+	fmt.Printf("%s Broadcast delivered to %d agent(s)\n", style.Bold.Render("✓"), delivered)
+	fmt.Printf("  Subject: %s\n", mailBroadcastSubject)
+	if mailBroadcastRole != "" {
+		fmt.Printf("  Role: %s\n", mailBroadcastRole)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -10,7 +12,55 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 )
 
+// validateMailCheckFlags rejects output modes that can't be combined.
+func validateMailCheckFlags(countOnly, inject, jsonOut bool) error {
+	if countOnly && inject {
+		return errors.New("--count-only and --inject are mutually exclusive")
+	}
+	if countOnly && jsonOut {
+		return errors.New("--count-only and --json are mutually exclusive")
+	}
+	return nil
+}
+
+// openMailbox returns the mailbox for address. All mail uses town beads
+// (two-level architecture), so this works from anywhere in the workspace.
+func openMailbox(address string) (*mail.Mailbox, error) {
+	workDir, err := findMailWorkDir()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	mailbox, err := mail.NewRouter(workDir).GetMailbox(address)
+	if err != nil {
+		return nil, fmt.Errorf("getting mailbox for %s: %w", address, err)
+	}
+	return mailbox, nil
+}
+
+// unreadMail returns the unread messages in address's inbox.
+func unreadMail(address string) ([]*mail.Message, error) {
+	mailbox, err := openMailbox(address)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, fmt.Errorf("listing unread mail for %s: %w", address, err)
+	}
+	return messages, nil
+}
+
+// printMailCount writes the unread count as a bare integer line, with no
+// styling, so it can be used directly in shell comparisons.
+func printMailCount(w io.Writer, unread int) {
+	fmt.Fprintln(w, unread)
+}
+
 func runMailCheck(cmd *cobra.Command, args []string) error {
+	if err := validateMailCheckFlags(mailCheckCount, mailCheckInject, mailCheckJSON); err != nil {
+		return err
+	}
+
 	// Determine which inbox (priority: --identity flag, auto-detect)
 	address := ""
 	if mailCheckIdentity != "" {
@@ -19,25 +69,13 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 		address = detectSender()
 	}
 
-	// All mail uses town beads (two-level architecture)
-	workDir, err := findMailWorkDir()
+	mailbox, err := openMailbox(address)
 	if err != nil {
 		if mailCheckInject {
-			fmt.Fprintf(os.Stderr, "gt mail check: workspace lookup failed: %v\n", err)
+			fmt.Fprintf(os.Stderr, "gt mail check: %v\n", err)
 			return nil
 		}
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
-	}
-
-	// Get mailbox
-	router := mail.NewRouter(workDir)
-	mailbox, err := router.GetMailbox(address)
-	if err != nil {
-		if mailCheckInject {
-			fmt.Fprintf(os.Stderr, "gt mail check: mailbox error for %s: %v\n", address, err)
-			return nil
-		}
-		return fmt.Errorf("getting mailbox: %w", err)
+		return err
 	}
 
 	// Count unread
@@ -50,6 +88,12 @@ func runMailCheck(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("counting messages: %w", err)
 	}
 
+	// Count-only output: bare integer for shell scripts
+	if mailCheckCount {
+		printMailCount(os.Stdout, unread)
+		return nil
+	}
+
 	// JSON output
 	if mailCheckJSON {
 		result := map[string]interface{}{
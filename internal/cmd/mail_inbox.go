@@ -555,3 +555,58 @@ func runMailClear(cmd *cobra.Command, args []string) error {
 		style.Bold.Render("✓"), deleted, address)
 	return nil
 }
+
+func runMailPrune(cmd *cobra.Command, args []string) error {
+	age, err := parseDuration(mailPruneOlderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than %q: %w", mailPruneOlderThan, err)
+	}
+	if age <= 0 {
+		return fmt.Errorf("--older-than must be positive, got %s", mailPruneOlderThan)
+	}
+	cutoff := time.Now().Add(-age)
+
+	address := detectSender()
+	if len(args) > 0 {
+		address = args[0]
+	}
+	mailbox, err := getMailbox(address)
+	if err != nil {
+		return err
+	}
+
+	which := "messages"
+	if mailPruneReadOnly {
+		which = "read messages"
+	}
+
+	if mailPruneDryRun {
+		candidates, err := mailbox.PruneCandidates(cutoff, mailPruneReadOnly)
+		if err != nil {
+			return fmt.Errorf("listing messages: %w", err)
+		}
+		if len(candidates) == 0 {
+			fmt.Printf("%s No %s older than %s in %s\n", style.Success.Render("✓"), which, mailPruneOlderThan, address)
+			return nil
+		}
+		fmt.Printf("%s Would remove %d %s older than %s from %s:\n", style.Dim.Render("(dry-run)"), len(candidates), which, mailPruneOlderThan, address)
+		for _, msg := range candidates {
+			fmt.Printf("  %s %s %s\n", style.Dim.Render(msg.ID), style.Dim.Render(msg.Timestamp.Format("2006-01-02")), msg.Subject)
+		}
+		return nil
+	}
+
+	removed, err := mailbox.Prune(cutoff, mailPruneReadOnly)
+	if err != nil {
+		if removed > 0 {
+			fmt.Printf("%s Removed %d %s from %s before failing\n", style.Bold.Render("⚠"), removed, which, address)
+		}
+		return fmt.Errorf("pruning %s: %w", address, err)
+	}
+	if removed == 0 {
+		fmt.Printf("%s No %s older than %s in %s\n", style.Success.Render("✓"), which, mailPruneOlderThan, address)
+		return nil
+	}
+	fmt.Printf("%s Removed %d %s older than %s from %s\n", style.Bold.Render("✓"), removed, which, mailPruneOlderThan, address)
+	return nil
+}
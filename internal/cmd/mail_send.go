@@ -17,17 +17,12 @@ import (
 )
 
 func runMailSend(cmd *cobra.Command, args []string) error {
-	// Handle --stdin: read message body from stdin (avoids shell quoting issues)
-	if mailStdin {
-		if mailBody != "" {
-			return fmt.Errorf("cannot use --stdin with --message/-m")
-		}
-		data, err := io.ReadAll(os.Stdin)
-		if err != nil {
-			return fmt.Errorf("reading stdin: %w", err)
-		}
-		mailBody = strings.TrimRight(string(data), "\n")
+	// Body from --message, --body-file, --stdin, or piped stdin
+	body, err := readMailBody(mailBody, mailBodyFile, mailStdin, os.Stdin, stdinIsPiped())
+	if err != nil {
+		return err
 	}
+	mailBody = body
 
 	var to string
 
@@ -56,10 +51,11 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 		if to == "" {
 			return fmt.Errorf("cannot determine identity (role: %s)", ctx.Role)
 		}
-	} else if len(args) > 0 {
-		to = args[0]
 	} else {
-		return fmt.Errorf("address required (or use --self)")
+		to, err = mailSendAddress(args, mailSendTo)
+		if err != nil {
+			return err
+		}
 	}
 
 	// All mail uses town beads (two-level architecture)
@@ -145,68 +141,137 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 
 	// Route based on recipient type, collecting errors instead of failing early
 	router := mail.NewRouter(workDir)
-	var recipientAddrs []string
-	var sendErrs []string
+	recipientAddrs, sendErrs := deliverMail(router, msg, recipients)
+
+	if len(sendErrs) > 0 {
+		if len(recipientAddrs) == 0 {
+			return fmt.Errorf("all sends failed: %s", strings.Join(sendErrs, "; "))
+		}
+		fmt.Fprintf(os.Stderr, "⚠ Some deliveries failed: %s\n", strings.Join(sendErrs, "; "))
+	}
+
+	// Log mail event to activity feed
+	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
+
+	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
+	fmt.Printf("  Subject: %s\n", mailSubject)
+
+	// Show resolved recipients if fan-out occurred
+	if len(recipientAddrs) > 1 || (len(recipientAddrs) == 1 && recipientAddrs[0] != to) {
+		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
+	}
+
+	if len(msg.CC) > 0 {
+		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
+	}
+	if msg.Type != mail.TypeNotification {
+		fmt.Printf("  Type: %s\n", msg.Type)
+	}
+
+	return nil
+}
 
+// mailSender delivers a message to msg.To. *mail.Router satisfies this interface.
+type mailSender interface {
+	Send(msg *mail.Message) error
+}
+
+// deliverMail sends msg to each resolved recipient: queues and channels get
+// the single message, agents each get their own copy. It returns the
+// addresses delivered to and an error string per failed delivery.
+func deliverMail(sender mailSender, msg *mail.Message, recipients []mail.Recipient) (delivered, failed []string) {
 	for _, rec := range recipients {
 		switch rec.Type {
 		case mail.RecipientQueue:
 			// Queue messages: single message, workers claim
 			msg.To = rec.Address
-			if err := router.Send(msg); err != nil {
-				sendErrs = append(sendErrs, fmt.Sprintf("queue %s: %v", rec.Address, err))
+			if err := sender.Send(msg); err != nil {
+				failed = append(failed, fmt.Sprintf("queue %s: %v", rec.Address, err))
 				continue
 			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
+			delivered = append(delivered, rec.Address)
 
 		case mail.RecipientChannel:
 			// Channel messages: single message, broadcast
 			msg.To = rec.Address
-			if err := router.Send(msg); err != nil {
-				sendErrs = append(sendErrs, fmt.Sprintf("channel %s: %v", rec.Address, err))
+			if err := sender.Send(msg); err != nil {
+				failed = append(failed, fmt.Sprintf("channel %s: %v", rec.Address, err))
 				continue
 			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
+			delivered = append(delivered, rec.Address)
 
 		default:
 			// Direct/agent messages: fan out to each recipient
 			msgCopy := *msg
 			msgCopy.To = rec.Address
 			msgCopy.ID = "" // Each fan-out copy gets its own unique ID
-			if err := router.Send(&msgCopy); err != nil {
-				sendErrs = append(sendErrs, fmt.Sprintf("%s: %v", rec.Address, err))
+			if err := sender.Send(&msgCopy); err != nil {
+				failed = append(failed, fmt.Sprintf("%s: %v", rec.Address, err))
 				continue
 			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
+			delivered = append(delivered, rec.Address)
 		}
 	}
+	return delivered, failed
+}
 
-	if len(sendErrs) > 0 {
-		if len(recipientAddrs) == 0 {
-			return fmt.Errorf("all sends failed: %s", strings.Join(sendErrs, "; "))
-		}
-		fmt.Fprintf(os.Stderr, "⚠ Some deliveries failed: %s\n", strings.Join(sendErrs, "; "))
+// mailSendAddress returns the recipient from the positional argument or
+// --to. Giving both is allowed only if they agree.
+func mailSendAddress(args []string, to string) (string, error) {
+	switch {
+	case len(args) > 0 && to != "" && args[0] != to:
+		return "", fmt.Errorf("recipient given twice: %q and --to %q", args[0], to)
+	case len(args) > 0:
+		return args[0], nil
+	case to != "":
+		return to, nil
 	}
+	return "", fmt.Errorf("address required (pass <address>, --to, or --self)")
+}
 
-	// Log mail event to activity feed
-	_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
-
-	fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-	fmt.Printf("  Subject: %s\n", mailSubject)
-
-	// Show resolved recipients if fan-out occurred
-	if len(recipientAddrs) > 1 || (len(recipientAddrs) == 1 && recipientAddrs[0] != to) {
-		fmt.Printf("  Recipients: %s\n", strings.Join(recipientAddrs, ", "))
+// readMailBody picks the message body from exactly one source: body
+// (--message/--body), bodyFile (--body-file, "-" for stdin), or stdin
+// (--stdin). With no source, stdin is read if piped, so output can be
+// piped straight into a message; otherwise the body is empty.
+func readMailBody(body, bodyFile string, useStdin bool, stdin io.Reader, piped bool) (string, error) {
+	sources := 0
+	for _, set := range []bool{body != "", bodyFile != "", useStdin} {
+		if set {
+			sources++
+		}
 	}
-
-	if len(msg.CC) > 0 {
-		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
+	if sources > 1 {
+		return "", fmt.Errorf("use only one of --message/--body, --body-file, and --stdin")
 	}
-	if msg.Type != mail.TypeNotification {
-		fmt.Printf("  Type: %s\n", msg.Type)
+
+	switch {
+	case body != "":
+		return body, nil
+	case bodyFile != "" && bodyFile != "-":
+		data, err := os.ReadFile(bodyFile)
+		if err != nil {
+			return "", fmt.Errorf("reading body file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\n"), nil
+	case bodyFile == "-" || useStdin || piped:
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading stdin: %w", err)
+		}
+		return strings.TrimRight(string(data), "\n"), nil
 	}
+	return "", nil
+}
 
-	return nil
+// stdinIsPiped reports whether stdin is a pipe or regular file rather than
+// a terminal or device (such as /dev/null), so reading it won't block on a
+// user who isn't typing.
+func stdinIsPiped() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeNamedPipe != 0 || info.Mode().IsRegular()
 }
 
 // generateThreadID creates a random thread ID for new message threads.
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestReadMailBody(t *testing.T) {
+	bodyFile := filepath.Join(t.TempDir(), "body.md")
+	if err := os.WriteFile(bodyFile, []byte("from file\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		bodyFile string
+		useStdin bool
+		piped    bool
+		want     string
+		wantErr  string
+	}{
+		{name: "message flag", body: "inline", piped: true, want: "inline"},
+		{name: "body file", bodyFile: bodyFile, want: "from file"},
+		{name: "body file dash reads stdin", bodyFile: "-", want: "from stdin"},
+		{name: "stdin flag", useStdin: true, want: "from stdin"},
+		{name: "piped stdin with no flags", piped: true, want: "from stdin"},
+		{name: "terminal stdin with no flags", want: ""},
+		{name: "message and stdin", body: "inline", useStdin: true, wantErr: "only one of"},
+		{name: "message and body file", body: "inline", bodyFile: bodyFile, wantErr: "only one of"},
+		{name: "missing body file", bodyFile: filepath.Join(t.TempDir(), "nope"), wantErr: "reading body file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readMailBody(tt.body, tt.bodyFile, tt.useStdin, strings.NewReader("from stdin\n\n"), tt.piped)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("readMailBody() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readMailBody() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readMailBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMailSendAddress(t *testing.T) {
+	if got, err := mailSendAddress([]string{"mayor/"}, ""); err != nil || got != "mayor/" {
+		t.Errorf("positional: got %q, %v", got, err)
+	}
+	if got, err := mailSendAddress(nil, "gastown/witness"); err != nil || got != "gastown/witness" {
+		t.Errorf("--to: got %q, %v", got, err)
+	}
+	if got, err := mailSendAddress([]string{"mayor/"}, "mayor/"); err != nil || got != "mayor/" {
+		t.Errorf("matching positional and --to: got %q, %v", got, err)
+	}
+	if _, err := mailSendAddress([]string{"mayor/"}, "deacon/"); err == nil {
+		t.Error("expected error for conflicting positional and --to")
+	}
+	if _, err := mailSendAddress(nil, ""); err == nil || !strings.Contains(err.Error(), "address required") {
+		t.Errorf("expected address required error, got %v", err)
+	}
+}
+
+// fixtureMailSender delivers into legacy JSONL mailboxes under dir, one per
+// known address, and rejects any other address like the router does.
+type fixtureMailSender struct {
+	dir   string
+	known map[string]bool
+}
+
+func (f *fixtureMailSender) mailbox(address string) *mail.Mailbox {
+	return mail.NewMailbox(filepath.Join(f.dir, strings.ReplaceAll(address, "/", "_")+".jsonl"))
+}
+
+func (f *fixtureMailSender) Send(msg *mail.Message) error {
+	if !f.known[msg.To] {
+		return fmt.Errorf("invalid recipient %q: no agent found", msg.To)
+	}
+	return f.mailbox(msg.To).Append(msg)
+}
+
+func TestDeliverMail_FixtureMailbox(t *testing.T) {
+	sender := &fixtureMailSender{
+		dir:   t.TempDir(),
+		known: map[string]bool{"gastown/witness": true, "gastown/crew/max": true},
+	}
+	msg := mail.NewMessage("mayor/", "gastown/", "Swarm starting", "All hands")
+	recipients := []mail.Recipient{
+		{Address: "gastown/witness", Type: mail.RecipientAgent},
+		{Address: "gastown/crew/max", Type: mail.RecipientAgent},
+		{Address: "gastown/ghost", Type: mail.RecipientAgent},
+	}
+
+	delivered, failed := deliverMail(sender, msg, recipients)
+
+	if strings.Join(delivered, ",") != "gastown/witness,gastown/crew/max" {
+		t.Errorf("delivered = %v", delivered)
+	}
+	if len(failed) != 1 || !strings.Contains(failed[0], `invalid recipient "gastown/ghost"`) {
+		t.Errorf("failed = %v, want one invalid recipient error for gastown/ghost", failed)
+	}
+
+	for _, addr := range delivered {
+		msgs, err := sender.mailbox(addr).List()
+		if err != nil {
+			t.Fatalf("listing %s: %v", addr, err)
+		}
+		if len(msgs) != 1 {
+			t.Fatalf("%s has %d messages, want 1", addr, len(msgs))
+		}
+		got := msgs[0]
+		if got.From != "mayor/" || got.To != addr || got.Subject != "Swarm starting" || got.Body != "All hands" {
+			t.Errorf("%s received %+v", addr, got)
+		}
+	}
+}
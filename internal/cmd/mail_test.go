@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
@@ -296,3 +297,44 @@ func TestAnnounceMessageParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintMailCount(t *testing.T) {
+	for _, unread := range []int{0, 3, 42} {
+		var buf bytes.Buffer
+		printMailCount(&buf, unread)
+		if want := fmt.Sprintf("%d\n", unread); buf.String() != want {
+			t.Errorf("printMailCount(%d) = %q, want %q", unread, buf.String(), want)
+		}
+	}
+}
+
+func TestValidateMailCheckFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		countOnly bool
+		inject    bool
+		jsonOut   bool
+		wantErr   string
+	}{
+		{name: "count-only alone", countOnly: true},
+		{name: "inject alone", inject: true},
+		{name: "json alone", jsonOut: true},
+		{name: "count-only with inject", countOnly: true, inject: true, wantErr: "--inject"},
+		{name: "count-only with json", countOnly: true, jsonOut: true, wantErr: "--json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMailCheckFlags(tt.countOnly, tt.inject, tt.jsonOut)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // MQ command flags
@@ -42,17 +46,71 @@ var (
 	// Status command flags
 	mqStatusJSON bool
 
+	// Dashboard flags
+	mqDashboardFormat      *output.FormatFlag
+	mqDashboardReadyOnly   bool
+	mqDashboardConcurrency int
+
+	// Integration gc flags
+	mqIntegrationGCDryRun bool
+
+	// Integration next flags
+	mqIntegrationNextRig    string
+	mqIntegrationNextQuiet  bool
+	mqIntegrationNextFormat *output.FormatFlag
+
 	// Integration land flags
 	mqIntegrationLandForce     bool
 	mqIntegrationLandSkipTests bool
+	mqIntegrationLandRunTests  bool
 	mqIntegrationLandDryRun    bool
+	mqIntegrationLandYes       bool
+	mqIntegrationLandRepair    bool
+	mqIntegrationLandUpTo      string
+	mqIntegrationLandChildren  bool
+	mqIntegrationLandStrict    bool
+	mqIntegrationLandSign      bool
+
+	// Integration reassign-target flags
+	mqIntegrationReassignFrom   string
+	mqIntegrationReassignTo     string
+	mqIntegrationReassignDryRun bool
+
+	// Integration adopt-mrs flags
+	mqIntegrationAdoptDryRun bool
+	mqIntegrationAdoptYes    bool
 
 	// Integration status flags
-	mqIntegrationStatusJSON bool
+	mqIntegrationStatusFormat     *output.FormatFlag
+	mqIntegrationStatusOutputFile string
+	mqIntegrationStatusSince      string
+	mqIntegrationStatusWorker     string
+	mqIntegrationStatusWatch      bool
+	mqIntegrationStatusInterval   = watchInterval(5 * time.Second)
+	mqIntegrationStatusSelect     string
+	mqIntegrationStatusChildren   bool
+	mqIntegrationStatusQuiet      bool
+
+	// Integration flags shared by all subcommands
+	mqIntegrationNoFetch    bool
+	mqIntegrationGitTimeout time.Duration
+
+	// Integration list flags
+	mqIntegrationListJSON      bool
+	mqIntegrationListReadyOnly bool
 
 	// Integration create flags
 	mqIntegrationCreateBranch     string
 	mqIntegrationCreateBaseBranch string
+	mqIntegrationCreateTarget     string
+	mqIntegrationCreateAdopt      bool
+	mqIntegrationCreateJSON       bool
+
+	// Integration name flags
+	mqIntegrationNameTemplate string
+
+	// Rig override for commands run outside a workspace
+	mqRigPath string
 )
 
 var mqCmd = &cobra.Command{
@@ -66,7 +124,11 @@ var mqCmd = &cobra.Command{
 Alias: 'gt mr' is equivalent to 'gt mq' (merge request vs merge queue).
 
 The merge queue tracks work branches from polecats waiting to be merged.
-Use these commands to view, submit, retry, and manage merge requests.`,
+Use these commands to view, submit, retry, and manage merge requests.
+
+Commands that act on the current rig find it from the working directory.
+Use --rig-path <dir> to name the rig directory instead, e.g. from CI where
+the checkout isn't inside a town; it must hold .repo.git or mayor/rig.`,
 }
 
 var mqSubmitCmd = &cobra.Command{
@@ -143,7 +205,7 @@ Examples:
   gt mq list greenplace --status=open
   gt mq list greenplace --worker=Nux`,
 	Args: cobra.ExactArgs(1),
-	RunE: runMQList,
+	RunE: withFormattedErrors(func() output.Format { return jsonErrorFormat(mqListJSON) }, runMQList),
 }
 
 var mqRejectCmd = &cobra.Command{
@@ -172,7 +234,34 @@ blockers, and processing history.
 Example:
   gt mq status gp-mr-abc123`,
 	Args: cobra.ExactArgs(1),
-	RunE: runMqStatus,
+	RunE: withFormattedErrors(func() output.Format { return jsonErrorFormat(mqStatusJSON) }, runMqStatus),
+}
+
+var mqDashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show integration branch readiness across all rigs",
+	Long: `Show every epic with an integration branch, across all rigs in the town.
+
+For each rig, integration branches are discovered as in
+'gt mq integration list' and each epic's status is computed as in
+'gt mq integration status'. The table is grouped by rig, with epics that
+are ready to land highlighted. The ready epic to land first (highest
+priority, then oldest; see 'gt mq integration next') is shown on top.
+
+Use --ready-only to list only epics that are ready to land, and
+--format json (or --toon for compact TOON) for machine-readable output.
+
+Rigs are fetched, and epic statuses computed, in parallel: --concurrency
+bounds how many run at once (default: the number of CPUs). An epic whose
+status can't be read is listed under its rig's errors without stopping
+the dashboard.
+
+Examples:
+  gt mq dashboard
+  gt mq dashboard --ready-only
+  gt mq dashboard --toon
+  gt mq dashboard --format json --no-fetch`,
+	RunE: withFormattedErrors(func() output.Format { return errorFormat(mqDashboardFormat) }, runMqDashboard),
 }
 
 var mqIntegrationCmd = &cobra.Command{
@@ -188,7 +277,14 @@ branch is landed to main as a single atomic unit.
 Commands:
   create  Create an integration branch for an epic
   land    Merge integration branch to main
-  status  Show integration branch status`,
+  status  Show integration branch status
+  list    List all integration branches in the rig
+  next    Show the ready epic to land first, across the town
+
+Subcommands fetch origin with --prune first, so branches deleted on origin
+(for example by a land from another clone) drop out of the remote view.
+Use --no-fetch with any subcommand to skip fetching from origin and work
+from local refs only (e.g., when offline). Remote state may be stale.`,
 }
 
 var mqIntegrationCreateCmd = &cobra.Command{
@@ -207,20 +303,56 @@ Branch naming:
 Template variables:
   {epic}   - Full epic ID (e.g., "RA-123")
   {prefix} - Epic prefix before first hyphen (e.g., "RA")
-  {user}   - Git user.name (e.g., "klauern")
+  {user}   - Git user.name, or $USER if unset (e.g., "klauern")
+  {date}   - Current date as YYYY-MM-DD (e.g., "2026-01-15")
+  {year}   - Current year as YYYY (e.g., "2026")
+  {month}  - Current month as MM (e.g., "01")
+
+Unknown placeholders are left as-is; the expanded name must still be a
+valid git branch name.
+
+Base ref:
+  Default: origin/main
+  --base-branch <branch>          Branch from origin/<branch>
+  --base-branch refs/tags/<tag>   Branch from a release tag (used verbatim)
+  --base-branch <sha>             Branch from a commit SHA (used verbatim)
+
+Land target:
+  The epic lands back into its base branch. A tag or SHA base isn't a branch,
+  so it lands into main unless --target names another branch.
 
 Actions:
   1. Verify epic exists
-  2. Create branch from main (using template or --branch)
+  2. Create branch from base ref (using template or --branch)
   3. Push to origin
   4. Store actual branch name in epic metadata
 
+Adopting an existing branch:
+  --adopt (or --from-existing) records a branch that was created by hand
+  before using the merge queue. The branch must already exist locally or on
+  origin; it is not recreated or pushed. --target (or a branch
+  --base-branch), if given, is stored as where the epic lands.
+
+JSON output (--json):
+  Prints {"epic", "branch", "base_branch", "base_ref", "created"} instead of progress
+  text, for automation that needs the resulting branch name. created is
+  false when an existing branch was adopted.
+
 Examples:
   gt mq integration create gt-auth-epic
   # Creates integration/gt-auth-epic (default)
 
   gt mq integration create RA-123 --branch "klauern/PROJ-1234/{epic}"
-  # Creates klauern/PROJ-1234/RA-123`,
+  # Creates klauern/PROJ-1234/RA-123
+
+  gt mq integration create gt-auth-epic --base-branch refs/tags/v1.2.0
+  # Creates integration/gt-auth-epic from the v1.2.0 tag; lands into main
+
+  gt mq integration create gt-auth-epic --base-branch refs/tags/v1.2.0 --target release/1.2
+  # Same, but lands into release/1.2
+
+  gt mq integration create gt-auth-epic --branch feature/auth --adopt
+  # Records the existing feature/auth branch for gt-auth-epic`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMqIntegrationCreate,
 }
@@ -235,26 +367,243 @@ as a single atomic merge commit.
 
 Actions:
   1. Verify all MRs targeting integration/<epic> are merged
-  2. Verify integration branch exists
+  2. Verify integration branch exists (and warn if the epic still has
+     open blocking dependencies)
   3. Merge integration/<epic> to main (--no-ff)
   4. Run tests on main
-  5. Push to origin
+  5. Push to origin, then check main contains the integration branch tip
   6. Delete integration branch
   7. Update epic status
 
 Options:
   --force       Land even if some MRs still open
   --skip-tests  Skip test run
+  --run-tests   Run tests even if merge_queue.run_tests is false
   --dry-run     Preview only, make no changes
+  --yes         Skip the confirmation prompt
+  --repair      Finish an interrupted land (see below)
+  --up-to <mr>  Land only up through one merged MR (see below)
+  --close-children  After the epic closes, close its still-open children
+  --strict-base     Refuse if the target moved on origin during the land
+
+The plan is shown and confirmed before anything is merged or pushed. Without
+a terminal on stdin (scripts, CI), land refuses unless --yes is given.
+
+Test command:
+  Runs merge_queue.test_command through the shell, bounded by
+  merge_queue.test_timeout_seconds if set. A rig whose tests run elsewhere
+  (e.g., CI) can set merge_queue.run_tests to false to skip them on every
+  land; --skip-tests and --run-tests override the setting. Shared towns can
+  restrict which executables may run via allowed_test_commands in town
+  settings or the GT_ALLOWED_TEST_COMMANDS environment variable
+  (comma-separated).
+
+Push retries:
+  A push rejected because origin/<target> moved is retried after pulling
+  again; a push that fails on a network error is retried after a backoff
+  (2s, 4s, ...). merge_queue.push_retries caps the retries (default 2, 0 to
+  disable). Other push failures, such as a protected branch hook, fail at once.
+
+Tagging:
+  If merge_queue.tag_on_land is set (e.g., "epic/{epic}"), an annotated tag
+  is created at the merge commit and pushed to origin after a successful push.
+
+Signing:
+  For targets that require signed commits, set merge_queue.sign_commits (or
+  pass --sign) to GPG-sign the merge commit and the tag_on_land tag.
+  merge_queue.signing_key picks the key; otherwise git's user.signingkey is
+  used. If gpg can't sign (no such key, no agent), the land stops before
+  pushing.
+
+Mail check:
+  If merge_queue.check_mail_before_land is true, land refuses while the
+  landing agent has unread mail whose subject mentions the epic ID (e.g., a
+  reviewer blocking it). --force lands anyway.
+
+Events:
+  If GT_EVENTS_FILE is set, each step (land_started, land_merged,
+  land_tests_passed, land_pushed, land_completed, or land_failed) is appended
+  to that file as a JSON line with a timestamp, epic and branch, for shipping
+  to a log aggregator.
+
+Repair:
+  If a previous land pushed the merge but stopped before cleaning up, land
+  refuses to merge again. --repair checks that the integration branch is
+  already merged into the target and runs only the remaining steps: tag (if
+  configured and not already present), delete the branch, close the epic.
+
+Strict base (--strict-base):
+  Normally the land worktree pulls origin/<target> and a failed pull is
+  skipped, so the merge may be built on a stale target and the push rejected.
+  With --strict-base, origin/<target> is fetched again just before merging;
+  if it has commits the worktree doesn't, land refuses with "target branch
+  advanced; re-run" instead. Not allowed with --no-fetch.
+
+Closing children (--close-children):
+  Tasks under the epic that were finished but never closed are closed after
+  the epic, with a reason noting the epic landed. Each child is closed
+  separately; failures are listed but don't fail the land. Also honoured by
+  --repair; not allowed with --up-to, which leaves the epic open.
+
+Partial land (--up-to):
+  Requires merge_queue.partial_land: true in rig settings. Lands the
+  integration branch only up through the merge commit recorded on the given
+  (merged) MR, by merging that commit into the target in the land worktree.
+  The integration branch and epic stay open; a later land brings the rest.
+  Open MRs don't block a partial land. Limitations:
+    - The MR must have a merge_commit recorded that is on the branch's
+      first-parent history and not yet in the target.
+    - Everything merged before that commit lands too, including commits
+      pushed to the branch directly. MRs can't be picked individually.
+    - No tag is created and nothing is deleted or closed.
+
+Nested epics:
+  If the epic's parent (or a further ancestor) epic has its own integration
+  branch, the child lands into that branch instead of its base branch. Land
+  the parent afterwards to carry the work on to main.
 
 Examples:
   gt mq integration land gt-auth-epic
   gt mq integration land gt-auth-epic --dry-run
-  gt mq integration land gt-auth-epic --force --skip-tests`,
+  gt mq integration land gt-auth-epic --force --skip-tests
+  gt mq integration land gt-auth-epic --yes
+  gt mq integration land gt-auth-epic --repair
+  gt mq integration land gt-auth-epic --close-children
+  gt mq integration land gt-auth-epic --up-to gt-mr-abc --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMqIntegrationLand,
 }
 
+var mqIntegrationAbortCmd = &cobra.Command{
+	Use:   "abort <epic-id>",
+	Short: "Clean up after an interrupted land",
+	Long: `Clean up the partial state left by a failed or interrupted land.
+
+If 'gt mq integration land' fails after the merge but before the push (or is
+interrupted), the temporary land worktree is left behind. Abort:
+  - Aborts the merge in progress in the land worktree, if any
+  - Removes the land worktree (.land-worktree) and prunes its git entry
+
+Nothing is pushed or deleted on origin, and the integration branch is left
+intact, so the land can simply be retried. Safe to run when no land is in
+progress.
+
+Examples:
+  gt mq integration abort gt-auth-epic`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMqIntegrationAbort,
+}
+
+var mqIntegrationGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete integration branches whose epics have landed",
+	Long: `Prune integration branches left behind after their epic landed.
+
+A land deletes its integration branch, but the delete can be skipped or fail,
+leaving branches to pile up on origin. gc finds branches in the current rig
+matching integration_branch_template whose epic is closed and whose tip is
+already contained in the branch it lands into (the parent epic's integration
+branch for nested epics, else the epic's base branch). Each such branch is
+deleted from origin and locally.
+
+Branches with open epics, or with commits not yet in their target, are never
+touched. Use --dry-run to list what would be deleted.
+
+Examples:
+  gt mq integration gc --dry-run
+  gt mq integration gc`,
+	Args: cobra.NoArgs,
+	RunE: runMqIntegrationGC,
+}
+
+var mqIntegrationReassignTargetCmd = &cobra.Command{
+	Use:   "reassign-target",
+	Short: "Point open MRs at a renamed integration branch",
+	Long: `Rewrite the target of open merge requests from one branch to another.
+
+When an epic's integration branch is renamed or recreated under a new name,
+MRs submitted against the old name still carry it in their target field and
+no longer show up in status or land. reassign-target finds every MR in the
+current rig that isn't closed and targets --from, and sets its target to --to.
+The rest of each MR's description is left as it is.
+
+Use --dry-run to list the MRs that would change.
+
+Examples:
+  gt mq integration reassign-target --from integration/gt-auth --to feat/gt-auth --dry-run
+  gt mq integration reassign-target --from integration/gt-auth --to feat/gt-auth`,
+	Args: cobra.NoArgs,
+	RunE: runMqIntegrationReassignTarget,
+}
+
+var mqIntegrationAdoptMRsCmd = &cobra.Command{
+	Use:   "adopt-mrs <epic-id>",
+	Short: "Retarget open MRs for an epic's work onto its integration branch",
+	Long: `Move open merge requests for an epic's work onto its integration branch.
+
+After adopting an existing branch with 'gt mq integration create --adopt',
+MRs already submitted for the epic's tasks still target main (or wherever
+they were sent) and don't show up in the epic's status. adopt-mrs finds the
+open MRs whose work item is the epic, one of its descendants, or a
+hierarchical child ID (<epic>.N). The work item is the MR's source_issue, or
+the issue ID in its source branch name (e.g., polecat/nux/gt-auth.2).
+
+Each match has its target set to the integration branch and is parented
+under the epic. The MRs are listed and confirmed first; without a terminal
+on stdin, --yes is required. Use --dry-run to list them without changing
+anything.
+
+Examples:
+  gt mq integration adopt-mrs gt-auth-epic --dry-run
+  gt mq integration adopt-mrs gt-auth-epic --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMqIntegrationAdoptMRs,
+}
+
+var mqIntegrationNameCmd = &cobra.Command{
+	Use:   "name <epic-id>",
+	Short: "Print the integration branch name create would use",
+	Long: `Print the integration branch name 'gt mq integration create' would use
+for an epic, and nothing else.
+
+The name comes from merge_queue.integration_branch_template in the current
+rig's settings (default: integration/{epic}), or from --template, expanded
+and validated exactly as create does. Nothing is created and the epic is not
+looked up, so this is safe to call from tooling.
+
+Time-based placeholders ({date}, {year}, {month}) expand to today's values
+and {user} to the current git user, so the name only matches a later create
+run by the same user on the same day.
+
+Examples:
+  gt mq integration name gt-auth-epic
+  gt mq integration name gt-auth-epic --template "{prefix}/{epic}"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMqIntegrationName,
+}
+
+var mqIntegrationNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the ready epic to land first",
+	Long: `Pick the next epic to land from every epic that is ready to land.
+
+Ready epics are found across all rigs in the town, as in 'gt mq dashboard',
+or in one rig with --rig. They are ordered by the epic bead's priority
+(P0 first), then by age (oldest epic first), and the first is printed.
+
+This is the ordering an auto-land patrol should follow. Use --quiet to
+print just the epic ID for scripts; nothing is printed when no epic is
+ready.
+
+Examples:
+  gt mq integration next
+  gt mq integration next --rig gastown
+  gt mq integration next --quiet
+  gt mq integration next --format json`,
+	Args: cobra.NoArgs,
+	RunE: withFormattedErrors(func() output.Format { return errorFormat(mqIntegrationNextFormat) }, runMqIntegrationNext),
+}
+
 var mqIntegrationStatusCmd = &cobra.Command{
 	Use:   "status <epic-id>",
 	Short: "Show integration branch status for an epic",
@@ -265,11 +614,65 @@ Shows:
   - Number of commits ahead of main
   - Merged MRs (closed, targeting integration branch)
   - Pending MRs (open, targeting integration branch)
+  - Open blocking dependencies of the epic (blocked_by), which hold the land
 
-Example:
-  gt mq integration status gt-auth-epic`,
+Use --format json (or GT_OUTPUT_FORMAT=json) for machine-readable output;
+--json is a deprecated alias. Use --output-file to save the JSON status for
+archival; the human-readable summary is still printed to stdout unless a
+structured --format is also given. Use --select to print a single field of
+the status by its JSON path (e.g., ready_to_land or merged_mrs.0.id) without
+piping to jq.
+
+Use --since to only list merged MRs closed within a recent window (e.g., 24h,
+7d). Use --worker to only list MRs submitted by one worker. Totals and
+readiness still count every MR.
+
+Use --children to list the epic's children that are not yet closed, answering
+"what's blocking this land?" They are also included as open_children in JSON.
+
+Use --quiet (-q) in scripts that only need readiness: nothing is printed and
+the exit code is 0 when the epic is ready to land, 1 otherwise. Errors are
+still reported on stderr.
+
+Use --watch to re-render the status every --interval (a duration such as
+30s or 1m; a bare number is seconds) while an epic's children close. Changes since the previous refresh (children closed, MRs
+merged, ready-to-land) are highlighted above the status.
+
+Examples:
+  gt mq integration status gt-auth-epic
+  gt mq integration status gt-auth-epic --since 7d
+  gt mq integration status gt-auth-epic --worker nux
+  gt mq integration status gt-auth-epic --children
+  gt mq integration status gt-auth-epic --watch --interval 30s
+  gt mq integration status gt-auth-epic --select ready_to_land
+  gt mq integration status gt-auth-epic --quiet && gt mq integration land gt-auth-epic --yes
+  gt mq integration status gt-auth-epic --output-file status.json`,
 	Args: cobra.ExactArgs(1),
-	RunE: runMqIntegrationStatus,
+	RunE: withFormattedErrors(func() output.Format { return errorFormat(mqIntegrationStatusFormat) }, runMqIntegrationStatus),
+}
+
+var mqIntegrationListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List integration branches in the current rig",
+	Long: `List all integration branches in the current rig.
+
+Branches are found by matching local and remote branches against the rig's
+integration_branch_template (e.g., "integration/*"). Each branch is resolved
+back to its epic via the epic's integration_branch metadata, falling back to
+the {epic} placeholder in the template.
+
+Shows, per branch:
+  - Epic ID and branch name
+  - Number of commits ahead of main
+  - Epic children closed/total
+  - Whether the branch is ready to land (no pending MRs or open blockers)
+
+Examples:
+  gt mq integration list
+  gt mq integration list --ready-only
+  gt mq integration list --json`,
+	Args: cobra.NoArgs,
+	RunE: withFormattedErrors(func() output.Format { return jsonErrorFormat(mqIntegrationListJSON) }, runMqIntegrationList),
 }
 
 func init() {
@@ -305,26 +708,142 @@ func init() {
 	mqCmd.AddCommand(mqRejectCmd)
 	mqCmd.AddCommand(mqStatusCmd)
 
+	// Dashboard flags
+	mqDashboardFormat = output.NewFormatFlag(mqDashboardCmd).WithJSONAlias(mqDashboardCmd).WithTOONAlias(mqDashboardCmd)
+	mqDashboardCmd.Flags().BoolVar(&mqDashboardReadyOnly, "ready-only", false, "Only show epics ready to land")
+	mqDashboardCmd.Flags().IntVar(&mqDashboardConcurrency, "concurrency", runtime.NumCPU(), "How many rigs or epics to check at once")
+	mqDashboardCmd.Flags().BoolVar(&mqIntegrationNoFetch, "no-fetch", false, "Skip fetching from origin and use local refs only (offline use)")
+	mqDashboardCmd.Flags().DurationVar(&mqIntegrationGitTimeout, "git-timeout", 0, "Fail git fetch/pull/push after this long (default from "+git.NetworkTimeoutEnv+", else no limit)")
+	mqCmd.AddCommand(mqDashboardCmd)
+
 	// Integration branch subcommands
-	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateBranch, "branch", "", "Override branch name template (supports {epic}, {prefix}, {user})")
-	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateBaseBranch, "base-branch", "", "Create integration branch from this branch instead of main")
+	mqIntegrationCmd.PersistentFlags().BoolVar(&mqIntegrationNoFetch, "no-fetch", false, "Skip fetching from origin and use local refs only (offline use)")
+	mqIntegrationCmd.PersistentFlags().DurationVar(&mqIntegrationGitTimeout, "git-timeout", 0, "Fail git fetch/pull/push after this long (default from "+git.NetworkTimeoutEnv+", else no limit)")
+	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateBranch, "branch", "", "Override branch name template (supports {epic}, {prefix}, {user}, {date}, {year}, {month})")
+	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateBaseBranch, "base-branch", "", "Create integration branch from this branch, tag (refs/tags/...), or commit SHA instead of main")
+	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateTarget, "target", "", "Branch the epic lands into (default: the base branch, or main for a tag or SHA base)")
+	mqIntegrationCreateCmd.Flags().BoolVar(&mqIntegrationCreateAdopt, "adopt", false, "Record an existing branch as the epic's integration branch instead of creating it")
+	mqIntegrationCreateCmd.Flags().BoolVar(&mqIntegrationCreateAdopt, "from-existing", false, "Alias for --adopt")
+	mqIntegrationCreateCmd.Flags().BoolVar(&mqIntegrationCreateJSON, "json", false, "Output the epic, branch and base branch as JSON")
 	mqIntegrationCmd.AddCommand(mqIntegrationCreateCmd)
 
 	// Integration land flags
 	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandForce, "force", false, "Land even if some MRs still open")
 	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandSkipTests, "skip-tests", false, "Skip test run")
+	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandRunTests, "run-tests", false, "Run tests even if merge_queue.run_tests is false")
 	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandDryRun, "dry-run", false, "Preview only, make no changes")
+	mqIntegrationLandCmd.Flags().BoolVarP(&mqIntegrationLandYes, "yes", "y", false, "Skip the confirmation prompt (required when stdin is not a terminal)")
+	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandRepair, "repair", false, "Finish an interrupted land whose merge was already pushed")
+	mqIntegrationLandCmd.Flags().StringVar(&mqIntegrationLandUpTo, "up-to", "", "Land only up through this merged MR's merge commit (requires merge_queue.partial_land)")
+	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandChildren, "close-children", false, "After the epic closes, close any of its children still open")
+	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandStrict, "strict-base", false, "Refuse to merge if origin/<target> advanced during the land")
+	mqIntegrationLandCmd.Flags().BoolVar(&mqIntegrationLandSign, "sign", false, "GPG-sign the merge commit and tag (as merge_queue.sign_commits)")
 	mqIntegrationCmd.AddCommand(mqIntegrationLandCmd)
 
+	// Integration abort
+	mqIntegrationCmd.AddCommand(mqIntegrationAbortCmd)
+
+	// Integration gc flags
+	mqIntegrationGCCmd.Flags().BoolVar(&mqIntegrationGCDryRun, "dry-run", false, "List branches that would be deleted without deleting them")
+	mqIntegrationCmd.AddCommand(mqIntegrationGCCmd)
+
+	// Integration reassign-target flags
+	mqIntegrationReassignTargetCmd.Flags().StringVar(&mqIntegrationReassignFrom, "from", "", "Branch the MRs currently target (required)")
+	mqIntegrationReassignTargetCmd.Flags().StringVar(&mqIntegrationReassignTo, "to", "", "Branch the MRs should target (required)")
+	mqIntegrationReassignTargetCmd.Flags().BoolVar(&mqIntegrationReassignDryRun, "dry-run", false, "List MRs that would be retargeted without changing them")
+	mqIntegrationCmd.AddCommand(mqIntegrationReassignTargetCmd)
+
+	// Integration adopt-mrs flags
+	mqIntegrationAdoptMRsCmd.Flags().BoolVar(&mqIntegrationAdoptDryRun, "dry-run", false, "List MRs that would be adopted without changing them")
+	mqIntegrationAdoptMRsCmd.Flags().BoolVarP(&mqIntegrationAdoptYes, "yes", "y", false, "Skip the confirmation prompt (required when stdin is not a terminal)")
+	mqIntegrationCmd.AddCommand(mqIntegrationAdoptMRsCmd)
+
+	// Integration name flags
+	mqIntegrationNameCmd.Flags().StringVar(&mqIntegrationNameTemplate, "template", "", "Override the branch name template (supports {epic}, {prefix}, {user}, {date}, {year}, {month})")
+	mqIntegrationCmd.AddCommand(mqIntegrationNameCmd)
+
+	// Integration next flags
+	mqIntegrationNextFormat = output.NewFormatFlag(mqIntegrationNextCmd).WithJSONAlias(mqIntegrationNextCmd)
+	mqIntegrationNextCmd.Flags().StringVar(&mqIntegrationNextRig, "rig", "", "Only consider epics in this rig")
+	mqIntegrationNextCmd.Flags().BoolVarP(&mqIntegrationNextQuiet, "quiet", "q", false, "Just print the epic ID")
+	mqIntegrationCmd.AddCommand(mqIntegrationNextCmd)
+
 	// Integration status flags
-	mqIntegrationStatusCmd.Flags().BoolVar(&mqIntegrationStatusJSON, "json", false, "Output as JSON")
+	mqIntegrationStatusFormat = output.NewFormatFlag(mqIntegrationStatusCmd).WithJSONAlias(mqIntegrationStatusCmd)
+	output.AddFileFlag(mqIntegrationStatusCmd, &mqIntegrationStatusOutputFile)
+	output.AddSelectFlag(mqIntegrationStatusCmd, &mqIntegrationStatusSelect)
+	mqIntegrationStatusCmd.Flags().StringVar(&mqIntegrationStatusSince, "since", "", "Only list merged MRs closed within this window (e.g., 24h, 7d)")
+	mqIntegrationStatusCmd.Flags().StringVar(&mqIntegrationStatusWorker, "worker", "", "Only list MRs submitted by this worker (case-insensitive)")
+	mqIntegrationStatusCmd.Flags().BoolVarP(&mqIntegrationStatusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
+	mqIntegrationStatusCmd.Flags().VarP(&mqIntegrationStatusInterval, "interval", "n", "Refresh interval as a duration (500ms, 2s, 1m) or whole seconds")
+	mqIntegrationStatusCmd.Flags().BoolVar(&mqIntegrationStatusChildren, "children", false, "List the epic's children that are not yet closed")
+	mqIntegrationStatusCmd.Flags().BoolVarP(&mqIntegrationStatusQuiet, "quiet", "q", false, "Print nothing; exit 0 if ready to land, 1 otherwise")
 	mqIntegrationCmd.AddCommand(mqIntegrationStatusCmd)
 
+	// Integration list flags
+	mqIntegrationListCmd.Flags().BoolVar(&mqIntegrationListJSON, "json", false, "Output as JSON")
+	mqIntegrationListCmd.Flags().BoolVar(&mqIntegrationListReadyOnly, "ready-only", false, "Only show branches ready to land")
+	mqIntegrationCmd.AddCommand(mqIntegrationListCmd)
+
 	mqCmd.AddCommand(mqIntegrationCmd)
 
+	mqCmd.PersistentFlags().StringVar(&mqRigPath, "rig-path", "", "Operate on this rig directory instead of detecting the rig from the working directory")
 	rootCmd.AddCommand(mqCmd)
 }
 
+// resolveMQRig returns the town root and rig an mq command operates on:
+// the --rig-path rig if given, else the rig containing the working
+// directory.
+func resolveMQRig() (string, *rig.Rig, error) {
+	if mqRigPath != "" {
+		return rigFromPath(mqRigPath)
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return "", nil, fmt.Errorf("not in a Gas Town workspace (use --rig-path to name the rig): %w", err)
+	}
+	_, r, err := findCurrentRig(townRoot)
+	if err != nil {
+		return "", nil, err
+	}
+	return townRoot, r, nil
+}
+
+// rigFromPath loads the rig at rigPath without consulting the town's rig
+// registry. The directory must have a repo base (see getRigGit). The town
+// root is the enclosing workspace if there is one, else the rig's parent
+// directory, where a town would keep it.
+func rigFromPath(rigPath string) (string, *rig.Rig, error) {
+	absPath, err := filepath.Abs(rigPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving --rig-path: %w", err)
+	}
+	if info, err := os.Stat(absPath); err != nil {
+		return "", nil, fmt.Errorf("--rig-path %s: %w", rigPath, err)
+	} else if !info.IsDir() {
+		return "", nil, fmt.Errorf("--rig-path %s is not a directory", rigPath)
+	}
+	if _, err := getRigGit(absPath); err != nil {
+		return "", nil, fmt.Errorf("--rig-path %s is not a rig: %w", rigPath, err)
+	}
+
+	// The directory name may not be the rig name (e.g., a CI checkout), so
+	// prefer the name in the rig's config.json
+	r := &rig.Rig{Name: filepath.Base(absPath), Path: absPath}
+	if cfg, err := rig.LoadRigConfig(absPath); err == nil {
+		if cfg.Name != "" {
+			r.Name = cfg.Name
+		}
+		r.GitURL = cfg.GitURL
+		r.LocalRepo = cfg.LocalRepo
+	}
+	townRoot, err := workspace.Find(absPath)
+	if err != nil || townRoot == "" {
+		townRoot = filepath.Dir(absPath)
+	}
+	return townRoot, r, nil
+}
+
 // findCurrentRig determines the current rig from the working directory.
 // Returns the rig name and rig object, or an error if not in a rig.
 func findCurrentRig(townRoot string) (string, *rig.Rig, error) {
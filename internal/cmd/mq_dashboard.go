@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/output"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// MQDashboardOutput is the town-wide integration branch dashboard.
+type MQDashboardOutput struct {
+	Rigs       []MQDashboardRig `json:"rigs"`
+	EpicCount  int              `json:"epic_count"`
+	ReadyCount int              `json:"ready_count"`
+	Next       *LandCandidate   `json:"next,omitempty"` // Ready epic to land first, if any
+}
+
+// MQDashboardRig groups one rig's integration epics.
+type MQDashboardRig struct {
+	Rig    string                    `json:"rig"`
+	Epics  []IntegrationStatusOutput `json:"epics"`
+	Errors []string                  `json:"errors,omitempty"` // Epics or steps that could not be read
+}
+
+// runMqDashboard shows integration readiness for every rig in the town.
+func runMqDashboard(cmd *cobra.Command, args []string) error {
+	applyGitTimeout()
+
+	format, err := mqDashboardFormat.Resolve()
+	if err != nil {
+		return err
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigs, err := discoverTownRigs(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if mqDashboardConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1, got %d", mqDashboardConcurrency)
+	}
+
+	dashboard := aggregateDashboard(collectTownIntegrationStatus(rigs, mqDashboardConcurrency), mqDashboardReadyOnly)
+
+	if format != output.FormatText {
+		return output.PrintFormatted(dashboard, format)
+	}
+	printDashboard(dashboard)
+	return nil
+}
+
+// discoverTownRigs returns every rig in the town.
+func discoverTownRigs(townRoot string) ([]*rig.Rig, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	rigs, err := mgr.DiscoverRigs()
+	if err != nil {
+		return nil, fmt.Errorf("discovering rigs: %w", err)
+	}
+	return rigs, nil
+}
+
+// dashboardRigSource is a rig prepared for the dashboard: its epics with
+// integration branches and how to compute each one's status.
+type dashboardRigSource struct {
+	Rig    string
+	Epics  []string
+	Errors []string // Failures while preparing the rig
+	Status func(epicID string) (*IntegrationStatusOutput, error)
+}
+
+// collectTownIntegrationStatus computes the status of every epic with an
+// integration branch in rigs, up to concurrency rigs or epics at a time.
+// Failures are recorded rather than aborting, so one broken rig or epic
+// doesn't hide the others.
+func collectTownIntegrationStatus(rigs []*rig.Rig, concurrency int) []MQDashboardRig {
+	sources := make([]dashboardRigSource, len(rigs))
+	runBounded(len(rigs), concurrency, func(i int) {
+		sources[i] = prepareDashboardRig(rigs[i])
+	})
+	return collectDashboardStatus(sources, concurrency)
+}
+
+// prepareDashboardRig fetches r's integration refs and lists its epics with
+// integration branches.
+func prepareDashboardRig(r *rig.Rig) dashboardRigSource {
+	source := dashboardRigSource{Rig: r.Name}
+
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		source.Errors = append(source.Errors, fmt.Sprintf("initializing git: %v", err))
+		return source
+	}
+	_ = fetchIntegrationRefs(g, mqIntegrationNoFetch) // Non-fatal, continue with local data
+
+	bd := beads.New(r.Path)
+	found, err := findIntegrationBranches(bd, g, r.Path)
+	if err != nil {
+		source.Errors = append(source.Errors, err.Error())
+		return source
+	}
+	for _, ib := range found.Branches {
+		source.Epics = append(source.Epics, ib.Epic)
+	}
+	// Read every epic in one bd call up front rather than one per status
+	epics := beads.NewIssueCache(bd)
+	_ = epics.Prefetch(source.Epics...) // Non-fatal, Show fetches lazily
+	source.Status = func(epicID string) (*IntegrationStatusOutput, error) {
+		return computeIntegrationStatus(bd, epics, g, r.Path, epicID, 0)
+	}
+	return source
+}
+
+// collectDashboardStatus computes every epic's status across sources with
+// at most concurrency running at once. Results keep the order of sources
+// and their epics whatever order the workers finish in; an epic that fails
+// is reported in its rig's Errors.
+func collectDashboardStatus(sources []dashboardRigSource, concurrency int) []MQDashboardRig {
+	type job struct {
+		rig  int
+		epic string
+	}
+	var jobs []job
+	for i, s := range sources {
+		for _, epic := range s.Epics {
+			jobs = append(jobs, job{rig: i, epic: epic})
+		}
+	}
+
+	statuses := make([]*IntegrationStatusOutput, len(jobs))
+	errs := make([]error, len(jobs))
+	runBounded(len(jobs), concurrency, func(i int) {
+		statuses[i], errs[i] = sources[jobs[i].rig].Status(jobs[i].epic)
+	})
+
+	results := make([]MQDashboardRig, len(sources))
+	for i, s := range sources {
+		results[i] = MQDashboardRig{Rig: s.Rig, Errors: append([]string(nil), s.Errors...)}
+	}
+	for i, j := range jobs {
+		r := &results[j.rig]
+		if errs[i] != nil {
+			r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", j.epic, errs[i]))
+			continue
+		}
+		r.Epics = append(r.Epics, *statuses[i])
+	}
+	return results
+}
+
+// runBounded calls fn(i) for every i in [0, n), at most concurrency at a
+// time, and returns when all calls have finished.
+func runBounded(n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// aggregateDashboard sorts rigs and epics, applies --ready-only, and totals
+// the result. Rigs left with no epics and no errors are dropped.
+func aggregateDashboard(rigs []MQDashboardRig, readyOnly bool) MQDashboardOutput {
+	dashboard := MQDashboardOutput{Rigs: []MQDashboardRig{}}
+	for _, r := range rigs {
+		var epics []IntegrationStatusOutput
+		for _, e := range r.Epics {
+			if readyOnly && !e.ReadyToLand {
+				continue
+			}
+			epics = append(epics, e)
+		}
+		if len(epics) == 0 && len(r.Errors) == 0 {
+			continue
+		}
+		// Highest priority first, so the epic to land next leads each rig
+		sort.Slice(epics, func(i, j int) bool {
+			if epics[i].Priority != epics[j].Priority {
+				return epics[i].Priority < epics[j].Priority
+			}
+			return epics[i].Epic < epics[j].Epic
+		})
+		if epics == nil {
+			epics = []IntegrationStatusOutput{}
+		}
+
+		dashboard.EpicCount += len(epics)
+		for _, e := range epics {
+			if e.ReadyToLand {
+				dashboard.ReadyCount++
+			}
+		}
+		r.Epics = epics
+		dashboard.Rigs = append(dashboard.Rigs, r)
+	}
+	sort.Slice(dashboard.Rigs, func(i, j int) bool { return dashboard.Rigs[i].Rig < dashboard.Rigs[j].Rig })
+	dashboard.Next = selectNextLand(dashboard.Rigs)
+	return dashboard
+}
+
+// printDashboard renders the dashboard as one table per rig.
+func printDashboard(d MQDashboardOutput) {
+	fmt.Printf("%s Integration dashboard: %d epic(s), %d ready to land\n",
+		style.Bold.Render("📋"), d.EpicCount, d.ReadyCount)
+	if len(d.Rigs) == 0 {
+		fmt.Printf("\n  %s\n", style.Dim.Render("(no integration branches)"))
+		return
+	}
+	if d.Next != nil {
+		fmt.Printf("Next to land: %s (%s, P%d)\n", style.Success.Render(d.Next.Epic), d.Next.Rig, d.Next.Priority)
+	}
+
+	for _, r := range d.Rigs {
+		fmt.Printf("\n%s\n", style.Bold.Render(r.Rig))
+		if len(r.Epics) > 0 {
+			table := style.NewTable(
+				style.Column{Name: "EPIC", Width: 14},
+				style.Column{Name: "PRI", Width: 4},
+				style.Column{Name: "BRANCH", Width: 32},
+				style.Column{Name: "AHEAD", Width: 6, Align: style.AlignRight},
+				style.Column{Name: "CHILDREN", Width: 9, Align: style.AlignRight},
+				style.Column{Name: "PENDING", Width: 8, Align: style.AlignRight},
+				style.Column{Name: "READY", Width: 6},
+			)
+			for _, e := range r.Epics {
+				epic := e.Epic
+				ready := style.Dim.Render("no")
+				if e.ReadyToLand {
+					epic = style.Success.Render(e.Epic)
+					ready = style.Success.Render("yes")
+				}
+				table.AddRow(
+					epic,
+					fmt.Sprintf("P%d", e.Priority),
+					e.Branch,
+					fmt.Sprintf("%d", e.AheadOfMain),
+					fmt.Sprintf("%d/%d", e.ChildrenClosed, e.ChildrenTotal),
+					fmt.Sprintf("%d", e.PendingTotal),
+					ready,
+				)
+			}
+			fmt.Print(table.Render())
+		}
+		for _, msg := range r.Errors {
+			fmt.Printf("  %s\n", style.Warning.Render("⚠ "+msg))
+		}
+	}
+}
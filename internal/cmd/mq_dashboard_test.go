@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+func TestAggregateDashboard(t *testing.T) {
+	rigs := []MQDashboardRig{
+		{Rig: "wyvern", Epics: []IntegrationStatusOutput{
+			{Epic: "wy-2", ReadyToLand: false},
+			{Epic: "wy-1", ReadyToLand: true},
+		}},
+		{Rig: "beads"}, // no integration branches
+		{Rig: "gastown", Epics: []IntegrationStatusOutput{
+			{Epic: "gt-auth", ReadyToLand: true},
+		}},
+		{Rig: "broken", Errors: []string{"initializing git: no repo base found"}},
+	}
+
+	t.Run("all epics", func(t *testing.T) {
+		d := aggregateDashboard(rigs, false)
+		if d.EpicCount != 3 || d.ReadyCount != 2 {
+			t.Errorf("counts = %d epics / %d ready, want 3 / 2", d.EpicCount, d.ReadyCount)
+		}
+		var names []string
+		for _, r := range d.Rigs {
+			names = append(names, r.Rig)
+		}
+		want := []string{"broken", "gastown", "wyvern"}
+		if len(names) != len(want) {
+			t.Fatalf("rigs = %v, want %v", names, want)
+		}
+		for i := range want {
+			if names[i] != want[i] {
+				t.Errorf("rigs = %v, want %v (empty rigs dropped, sorted)", names, want)
+				break
+			}
+		}
+		wy := d.Rigs[2]
+		if wy.Epics[0].Epic != "wy-1" || wy.Epics[1].Epic != "wy-2" {
+			t.Errorf("epics not sorted: %v, %v", wy.Epics[0].Epic, wy.Epics[1].Epic)
+		}
+	})
+
+	t.Run("priority orders epics", func(t *testing.T) {
+		d := aggregateDashboard([]MQDashboardRig{{Rig: "gastown", Epics: []IntegrationStatusOutput{
+			{Epic: "gt-a", Priority: 2},
+			{Epic: "gt-b", Priority: 0},
+			{Epic: "gt-c", Priority: 2},
+		}}}, false)
+		var got []string
+		for _, e := range d.Rigs[0].Epics {
+			got = append(got, e.Epic)
+		}
+		if strings.Join(got, ",") != "gt-b,gt-a,gt-c" {
+			t.Errorf("epics = %v, want highest priority first, then by name", got)
+		}
+	})
+
+	t.Run("toon", func(t *testing.T) {
+		data, err := output.Marshal(aggregateDashboard(rigs, false), output.FormatTOON)
+		if err != nil {
+			t.Fatalf("Marshal(toon) error = %v", err)
+		}
+		for _, want := range []string{"rig: gastown", "epics[2]{epic,", "ready_to_land", "gt-auth,", "wy-1,", "epic_count: 3", "ready_count: 2"} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("TOON dashboard missing %q:\n%s", want, data)
+			}
+		}
+	})
+
+	t.Run("ready only", func(t *testing.T) {
+		d := aggregateDashboard(rigs, true)
+		if d.EpicCount != 2 || d.ReadyCount != 2 {
+			t.Errorf("counts = %d epics / %d ready, want 2 / 2", d.EpicCount, d.ReadyCount)
+		}
+		for _, r := range d.Rigs {
+			for _, e := range r.Epics {
+				if !e.ReadyToLand {
+					t.Errorf("--ready-only kept %s", e.Epic)
+				}
+			}
+		}
+		// The rig with errors is kept so failures stay visible
+		if d.Rigs[0].Rig != "broken" {
+			t.Errorf("first rig = %s, want broken", d.Rigs[0].Rig)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		d := aggregateDashboard(nil, false)
+		if d.Rigs == nil || len(d.Rigs) != 0 {
+			t.Errorf("Rigs = %#v, want empty non-nil slice for JSON", d.Rigs)
+		}
+	})
+}
+
+func TestSelectNextLand(t *testing.T) {
+	rigs := []MQDashboardRig{
+		{Rig: "gastown", Epics: []IntegrationStatusOutput{
+			{Epic: "gt-urgent-unready", Priority: 0, ReadyToLand: false},
+			{Epic: "gt-newer", Priority: 1, ReadyToLand: true, EpicCreated: "2026-03-02T10:00:00Z"},
+			{Epic: "gt-low", Priority: 3, ReadyToLand: true, EpicCreated: "2026-01-01T00:00:00Z"},
+		}},
+		{Rig: "wyvern", Epics: []IntegrationStatusOutput{
+			{Epic: "wy-older", Priority: 1, ReadyToLand: true, EpicCreated: "2026-03-01T10:00:00Z"},
+			{Epic: "wy-undated", Priority: 1, ReadyToLand: true},
+		}},
+	}
+
+	next := selectNextLand(rigs)
+	if next == nil || next.Epic != "wy-older" || next.Rig != "wyvern" {
+		t.Fatalf("selectNextLand() = %+v, want wy-older in wyvern (P1 tie broken by age)", next)
+	}
+
+	if got := selectNextLand(nil); got != nil {
+		t.Errorf("selectNextLand(nil) = %+v, want nil", got)
+	}
+}
+
+func TestLandCandidateLess(t *testing.T) {
+	older := LandCandidate{Epic: "gt-b", Priority: 2, EpicCreated: "2026-01-01T00:00:00Z"}
+	newer := LandCandidate{Epic: "gt-a", Priority: 2, EpicCreated: "2026-02-01T00:00:00Z"}
+	undated := LandCandidate{Epic: "gt-0", Priority: 2}
+	urgent := LandCandidate{Epic: "gt-z", Priority: 0, EpicCreated: "2026-06-01T00:00:00Z"}
+
+	tests := []struct {
+		name string
+		a, b LandCandidate
+		want bool
+	}{
+		{"priority beats age", urgent, older, true},
+		{"older first on tie", older, newer, true},
+		{"newer after older", newer, older, false},
+		{"dated before undated", newer, undated, true},
+		{"undated after dated", undated, newer, false},
+		{"same time falls back to ID", LandCandidate{Epic: "gt-a", EpicCreated: older.EpicCreated}, LandCandidate{Epic: "gt-b", EpicCreated: older.EpicCreated}, true},
+	}
+	for _, tt := range tests {
+		if got := landCandidateLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: landCandidateLess() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeDashboardSources builds rigs whose epic statuses are computed with a
+// small delay that varies by epic, so workers finish out of order, and
+// records the most statuses computed at once.
+func fakeDashboardSources(maxRunning *int32) []dashboardRigSource {
+	var running int32
+	status := func(failing string) func(string) (*IntegrationStatusOutput, error) {
+		return func(epicID string) (*IntegrationStatusOutput, error) {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(len(epicID)%3) * time.Millisecond)
+			if epicID == failing {
+				return nil, errors.New("bd show failed")
+			}
+			return &IntegrationStatusOutput{Epic: epicID, Branch: "integration/" + epicID}, nil
+		}
+	}
+	return []dashboardRigSource{
+		{Rig: "gastown", Epics: []string{"gt-a", "gt-bb", "gt-ccc", "gt-dddd"}, Status: status("gt-ccc")},
+		{Rig: "broken", Errors: []string{"initializing git: no repo base found"}},
+		{Rig: "beads", Epics: []string{"bd-x", "bd-yy"}, Status: status("")},
+		{Rig: "wyvern", Epics: []string{"wy-1", "wy-22", "wy-333"}, Status: status("")},
+	}
+}
+
+func TestCollectDashboardStatus(t *testing.T) {
+	want := []MQDashboardRig{
+		{Rig: "gastown", Epics: []IntegrationStatusOutput{
+			{Epic: "gt-a", Branch: "integration/gt-a"},
+			{Epic: "gt-bb", Branch: "integration/gt-bb"},
+			{Epic: "gt-dddd", Branch: "integration/gt-dddd"},
+		}, Errors: []string{"gt-ccc: bd show failed"}},
+		{Rig: "broken", Errors: []string{"initializing git: no repo base found"}},
+		{Rig: "beads", Epics: []IntegrationStatusOutput{
+			{Epic: "bd-x", Branch: "integration/bd-x"},
+			{Epic: "bd-yy", Branch: "integration/bd-yy"},
+		}},
+		{Rig: "wyvern", Epics: []IntegrationStatusOutput{
+			{Epic: "wy-1", Branch: "integration/wy-1"},
+			{Epic: "wy-22", Branch: "integration/wy-22"},
+			{Epic: "wy-333", Branch: "integration/wy-333"},
+		}},
+	}
+
+	for _, concurrency := range []int{1, 2, 4, 32} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			var maxRunning int32
+			got := collectDashboardStatus(fakeDashboardSources(&maxRunning), concurrency)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("collectDashboardStatus() =\n%+v\nwant\n%+v", got, want)
+			}
+			if maxRunning > int32(concurrency) {
+				t.Errorf("%d statuses computed at once, want at most %d", maxRunning, concurrency)
+			}
+		})
+	}
+}
+
+func TestRunBounded(t *testing.T) {
+	for _, tt := range []struct{ n, concurrency int }{{0, 4}, {5, 0}, {5, 1}, {3, 10}, {100, 8}} {
+		var calls int32
+		seen := make([]int32, tt.n)
+		runBounded(tt.n, tt.concurrency, func(i int) {
+			atomic.AddInt32(&calls, 1)
+			atomic.AddInt32(&seen[i], 1)
+		})
+		if int(calls) != tt.n {
+			t.Errorf("runBounded(%d, %d) made %d calls", tt.n, tt.concurrency, calls)
+		}
+		for i, c := range seen {
+			if c != 1 {
+				t.Errorf("runBounded(%d, %d) called fn(%d) %d times", tt.n, tt.concurrency, i, c)
+			}
+		}
+	}
+}
@@ -1,20 +1,28 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/style"
-	"github.com/steveyegge/gastown/internal/workspace"
+	"github.com/steveyegge/gastown/internal/util"
+	"golang.org/x/term"
 )
 
 // defaultIntegrationBranchTemplate is kept for local backward compat references.
@@ -67,6 +75,52 @@ func validateBranchName(branchName string) error {
 	return nil
 }
 
+// commitSHARegex matches abbreviated (7+ chars) or full (40 chars) commit SHAs.
+var commitSHARegex = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+
+// isBranchBase reports whether a --base-branch value names a branch rather
+// than a tag (refs/tags/...) or a commit SHA.
+func isBranchBase(baseBranch string) bool {
+	return !strings.HasPrefix(baseBranch, "refs/tags/") && !commitSHARegex.MatchString(baseBranch)
+}
+
+// resolveIntegrationBaseRef maps a --base-branch value to the ref passed to
+// CreateBranchFrom and the name shown for it.
+// Tags (refs/tags/...) and commit SHAs are used verbatim; anything else is
+// treated as a branch on origin. Empty means origin/main.
+func resolveIntegrationBaseRef(baseBranch string) (ref, display string) {
+	if baseBranch == "" {
+		return "origin/main", "main"
+	}
+	if !isBranchBase(baseBranch) {
+		return baseBranch, baseBranch
+	}
+	name := strings.TrimPrefix(baseBranch, "origin/")
+	return "origin/" + name, name
+}
+
+// resolveIntegrationLandTarget returns the branch an integration branch
+// created from baseBranch lands back into, which is recorded as the epic's
+// base_branch. --target wins; otherwise a branch base lands into itself and
+// a tag or SHA base lands into main, since land can't push to either.
+func resolveIntegrationLandTarget(baseBranch, target string) (string, error) {
+	if target != "" {
+		target = strings.TrimPrefix(target, "origin/")
+		if !isBranchBase(target) {
+			return "", fmt.Errorf("--target %q must be a branch, not a tag or commit", target)
+		}
+		if err := validateBranchName(target); err != nil {
+			return "", fmt.Errorf("invalid --target: %w", err)
+		}
+		return target, nil
+	}
+	if baseBranch == "" || !isBranchBase(baseBranch) {
+		return "main", nil
+	}
+	_, name := resolveIntegrationBaseRef(baseBranch)
+	return name, nil
+}
+
 // getIntegrationBranchField wraps beads.GetIntegrationBranchField for local callers.
 func getIntegrationBranchField(description string) string {
 	return beads.GetIntegrationBranchField(description)
@@ -91,7 +145,7 @@ func getRigGit(rigPath string) (*git.Git, error) {
 // The caller MUST call the returned cleanup function when done (typically via defer).
 // The worktree is checked out to startBranch (e.g., "main").
 func createLandWorktree(rigPath, startBranch string) (*git.Git, func(), error) {
-	landPath := filepath.Join(rigPath, ".land-worktree")
+	landPath := landWorktreePath(rigPath)
 	noop := func() {}
 
 	// Get bare repo for worktree creation
@@ -121,6 +175,82 @@ func createLandWorktree(rigPath, startBranch string) (*git.Git, func(), error) {
 	return git.NewGit(landPath), cleanup, nil
 }
 
+// landWorktreePath returns where land operations check out their temporary worktree.
+func landWorktreePath(rigPath string) string {
+	return filepath.Join(rigPath, ".land-worktree")
+}
+
+// abortLand cleans up a land interrupted between merge and cleanup: it aborts
+// any merge in progress in the land worktree, then removes the worktree.
+// Returns a description of each step taken; nil means nothing was in progress.
+func abortLand(rigPath string) ([]string, error) {
+	landPath := landWorktreePath(rigPath)
+	if _, err := os.Stat(landPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var cleaned []string
+	if landMergeInProgress(util.ExecRunner{}, landPath) {
+		if err := git.NewGit(landPath).AbortMerge(); err != nil {
+			return cleaned, fmt.Errorf("aborting merge in %s: %w", landPath, err)
+		}
+		cleaned = append(cleaned, "Aborted merge in progress")
+	}
+
+	// Unregister from the bare repo first so git doesn't keep a stale entry
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	if _, err := os.Stat(bareRepoPath); err == nil {
+		bareGit := git.NewGitWithDir(bareRepoPath, "")
+		_ = bareGit.WorktreeRemove(landPath, true)
+		_ = bareGit.WorktreePrune()
+	}
+	if err := os.RemoveAll(landPath); err != nil {
+		return cleaned, fmt.Errorf("removing land worktree: %w", err)
+	}
+	cleaned = append(cleaned, fmt.Sprintf("Removed land worktree %s", landPath))
+
+	return cleaned, nil
+}
+
+// landMergeInProgress reports whether the worktree at dir has an unfinished merge.
+func landMergeInProgress(r util.Runner, dir string) bool {
+	_, err := r.Run(dir, "git", "rev-parse", "-q", "--verify", "MERGE_HEAD")
+	return err == nil
+}
+
+// refFetcher is the subset of git.Git used to refresh remote refs.
+type refFetcher interface {
+	FetchPrune(remote string) error
+}
+
+// fetchIntegrationRefs fetches origin unless noFetch is set, in which case it
+// warns on stderr (keeping --json stdout clean) that remote state may be stale.
+// The fetch prunes tracking refs for branches deleted on origin, so an
+// integration branch removed elsewhere (by land or gc in another clone) no
+// longer shows up as existing on origin.
+func fetchIntegrationRefs(g refFetcher, noFetch bool) error {
+	if noFetch {
+		fmt.Fprintf(os.Stderr, "%s\n", style.Dim.Render("(--no-fetch: using local refs; remote state may be stale)"))
+		return nil
+	}
+	return g.FetchPrune("origin")
+}
+
+// applyGitTimeout bounds git network operations by --git-timeout, falling
+// back to GT_GIT_TIMEOUT when the flag isn't given.
+func applyGitTimeout() {
+	git.SetNetworkTimeout(mqIntegrationGitTimeout)
+}
+
+// integrationRemoteBranchExists checks whether branch exists on origin.
+// With noFetch it consults the local remote-tracking ref instead of the network.
+func integrationRemoteBranchExists(g *git.Git, branch string, noFetch bool) (bool, error) {
+	if noFetch {
+		return g.RemoteTrackingBranchExists("origin", branch)
+	}
+	return g.RemoteBranchExists("origin", branch)
+}
+
 // getIntegrationBranchTemplate returns the integration branch template to use.
 // Priority: CLI flag > rig config > default
 func getIntegrationBranchTemplate(rigPath, cliOverride string) string {
@@ -148,33 +278,64 @@ type IntegrationStatusOutput struct {
 	Branch          string                       `json:"branch"`
 	Created         string                       `json:"created,omitempty"`
 	AheadOfMain     int                          `json:"ahead_of_main"`
+	LastCommit      *git.Commit                  `json:"last_commit,omitempty"` // Tip of the integration branch
 	MergedMRs       []IntegrationStatusMRSummary `json:"merged_mrs"`
+	MergedTotal     int                          `json:"merged_total"`
+	MergedSince     string                       `json:"merged_since,omitempty"` // --since window applied to MergedMRs
 	PendingMRs      []IntegrationStatusMRSummary `json:"pending_mrs"`
+	PendingTotal    int                          `json:"pending_total"`
+	Worker          string                       `json:"worker,omitempty"` // --worker filter applied to MergedMRs and PendingMRs
 	ReadyToLand     bool                         `json:"ready_to_land"`
 	AutoLandEnabled bool                         `json:"auto_land_enabled"`
 	ChildrenTotal   int                          `json:"children_total"`
 	ChildrenClosed  int                          `json:"children_closed"`
+	OpenChildren    []IntegrationStatusMRSummary `json:"open_children,omitempty"` // --children: children not yet closed
+	BlockedBy       []string                     `json:"blocked_by,omitempty"`    // Open issues the epic depends on
+	Priority        int                          `json:"priority"`                // Epic priority (0 = highest), orders the land queue
+	EpicCreated     string                       `json:"epic_created,omitempty"`  // Epic creation time, breaks priority ties
 }
 
 // IntegrationStatusMRSummary represents a merge request in the integration status output.
 type IntegrationStatusMRSummary struct {
-	ID     string `json:"id"`
-	Title  string `json:"title"`
-	Status string `json:"status,omitempty"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Status    string `json:"status,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Priority  *int   `json:"priority,omitempty"` // Set for open children
 }
 
-// runMqIntegrationCreate creates an integration branch for an epic.
-func runMqIntegrationCreate(cmd *cobra.Command, args []string) error {
-	epicID := args[0]
+// resolveIntegrationBranchName expands the integration branch template for
+// epicID (CLI override > rig config > default) and validates the result.
+func resolveIntegrationBranchName(rigPath, templateOverride, epicID string) (string, error) {
+	template := getIntegrationBranchTemplate(rigPath, templateOverride)
+	branchName := buildIntegrationBranchName(template, epicID)
+	if err := validateBranchName(branchName); err != nil {
+		return "", fmt.Errorf("invalid branch name: %w", err)
+	}
+	return branchName, nil
+}
+
+// runMqIntegrationName prints the integration branch name create would use.
+func runMqIntegrationName(cmd *cobra.Command, args []string) error {
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
 
-	// Find workspace
-	townRoot, err := workspace.FindFromCwdOrError()
+	branchName, err := resolveIntegrationBranchName(r.Path, mqIntegrationNameTemplate, args[0])
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
+	fmt.Println(branchName)
+	return nil
+}
+
+// runMqIntegrationCreate creates an integration branch for an epic.
+func runMqIntegrationCreate(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+	applyGitTimeout()
 
-	// Find current rig
-	_, r, err := findCurrentRig(townRoot)
+	_, r, err := resolveMQRig()
 	if err != nil {
 		return err
 	}
@@ -197,12 +358,9 @@ func runMqIntegrationCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build integration branch name from template
-	template := getIntegrationBranchTemplate(r.Path, mqIntegrationCreateBranch)
-	branchName := buildIntegrationBranchName(template, epicID)
-
-	// Validate the branch name
-	if err := validateBranchName(branchName); err != nil {
-		return fmt.Errorf("invalid branch name: %w", err)
+	branchName, err := resolveIntegrationBranchName(r.Path, mqIntegrationCreateBranch, epicID)
+	if err != nil {
+		return err
 	}
 
 	// Initialize git for the rig
@@ -211,74 +369,213 @@ func runMqIntegrationCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("initializing git: %w", err)
 	}
 
+	progress := io.Writer(os.Stdout)
+	if mqIntegrationCreateJSON {
+		progress = io.Discard
+	}
+
+	var result *IntegrationCreateOutput
+	if mqIntegrationCreateAdopt {
+		result, err = adoptIntegrationBranchForEpic(bd, integrationBranchChecker{g: g, noFetch: mqIntegrationNoFetch}, epic, branchName, progress)
+	} else {
+		result, err = createIntegrationBranch(bd, g, epic, branchName, mqIntegrationCreateBaseBranch, mqIntegrationCreateTarget, mqIntegrationNoFetch, progress)
+	}
+	if err != nil {
+		return err
+	}
+	if mqIntegrationCreateJSON {
+		return output.PrintFormatted(result, output.FormatJSON)
+	}
+	return nil
+}
+
+// IntegrationCreateOutput is the result of gt mq integration create --json.
+type IntegrationCreateOutput struct {
+	Epic       string `json:"epic"`
+	Branch     string `json:"branch"`
+	BaseBranch string `json:"base_branch"`        // Branch the epic lands into
+	BaseRef    string `json:"base_ref,omitempty"` // Tag or SHA it was created from, if not a branch
+	Created    bool   `json:"created"`            // false when an existing branch was adopted
+}
+
+// createIntegrationBranch creates branchName from baseRef (origin/main when
+// empty), pushes it, and records it in the epic's metadata along with the
+// branch it lands into (target, else derived from baseRef). Progress and the
+// summary go to progress.
+func createIntegrationBranch(bd mrUpdater, g *git.Git, epic *beads.Issue, branchName, baseRef, target string, noFetch bool, progress io.Writer) (*IntegrationCreateOutput, error) {
+	epicID := epic.ID
+
+	landTarget, err := resolveIntegrationLandTarget(baseRef, target)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if integration branch already exists locally
 	exists, err := g.BranchExists(branchName)
 	if err != nil {
-		return fmt.Errorf("checking branch existence: %w", err)
+		return nil, fmt.Errorf("checking branch existence: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("integration branch '%s' already exists locally", branchName)
+		return nil, fmt.Errorf("integration branch '%s' already exists locally", branchName)
 	}
 
 	// Check if branch exists on remote
-	remoteExists, err := g.RemoteBranchExists("origin", branchName)
+	remoteExists, err := integrationRemoteBranchExists(g, branchName, noFetch)
 	if err != nil {
 		// Log warning but continue - remote check isn't critical
-		fmt.Printf("  %s\n", style.Dim.Render("(could not check remote, continuing)"))
+		fmt.Fprintf(progress, "  %s\n", style.Dim.Render("(could not check remote, continuing)"))
 	}
 	if remoteExists {
-		return fmt.Errorf("integration branch '%s' already exists on origin", branchName)
+		return nil, fmt.Errorf("integration branch '%s' already exists on origin", branchName)
 	}
 
 	// Ensure we have latest refs
-	fmt.Printf("Fetching latest from origin...\n")
-	if err := g.Fetch("origin"); err != nil {
-		return fmt.Errorf("fetching from origin: %w", err)
+	if !noFetch {
+		fmt.Fprintf(progress, "Fetching latest from origin...\n")
+	}
+	if err := fetchIntegrationRefs(g, noFetch); err != nil {
+		return nil, fmt.Errorf("fetching from origin: %w", err)
 	}
 
-	// 2. Create branch from base (default: origin/main)
-	baseBranch := "origin/main"
-	baseBranchDisplay := "main"
-	if mqIntegrationCreateBaseBranch != "" {
-		baseBranch = "origin/" + strings.TrimPrefix(mqIntegrationCreateBaseBranch, "origin/")
-		baseBranchDisplay = strings.TrimPrefix(baseBranch, "origin/")
+	// 2. Create branch from base (default: origin/main).
+	// Tags and commit SHAs are used verbatim instead of being prefixed with origin/.
+	baseBranch, baseBranchDisplay := resolveIntegrationBaseRef(baseRef)
+	if _, err := g.Rev(baseBranch + "^{commit}"); err != nil {
+		return nil, fmt.Errorf("base ref '%s' not found: %w", baseBranch, err)
 	}
-	fmt.Printf("Creating branch '%s' from %s...\n", branchName, baseBranchDisplay)
+	fmt.Fprintf(progress, "Creating branch '%s' from %s...\n", branchName, baseBranchDisplay)
 	if err := g.CreateBranchFrom(branchName, baseBranch); err != nil {
-		return fmt.Errorf("creating branch: %w", err)
+		return nil, fmt.Errorf("creating branch: %w", err)
 	}
 
 	// 3. Push to origin
-	fmt.Printf("Pushing to origin...\n")
+	fmt.Fprintf(progress, "Pushing to origin...\n")
 	if err := g.Push("origin", branchName, false); err != nil {
 		// Clean up local branch on push failure (best-effort cleanup)
 		_ = g.DeleteBranch(branchName, true)
-		return fmt.Errorf("pushing to origin: %w", err)
+		return nil, fmt.Errorf("pushing to origin: %w", err)
 	}
 
 	// 4. Store integration branch info in epic metadata
 	// Update the epic's description to include the integration branch info
 	newDesc := addIntegrationBranchField(epic.Description, branchName)
-	// Also store base_branch if non-main was used (for land to know where to merge back)
-	if mqIntegrationCreateBaseBranch != "" {
-		newDesc = beads.AddBaseBranchField(newDesc, baseBranchDisplay)
+	// Also store base_branch if non-main was used (for land to know where to merge back).
+	// It is always a branch: a tag or SHA base records the target instead.
+	if baseRef != "" || target != "" {
+		newDesc = beads.AddBaseBranchField(newDesc, landTarget)
 	}
 	if newDesc != epic.Description {
 		if err := bd.Update(epicID, beads.UpdateOptions{Description: &newDesc}); err != nil {
 			// Non-fatal - branch was created, just metadata update failed
-			fmt.Printf("  %s\n", style.Dim.Render("(warning: could not update epic metadata)"))
+			fmt.Fprintf(progress, "  %s\n", style.Dim.Render("(warning: could not update epic metadata)"))
 		}
 	}
 
 	// Success output
-	fmt.Printf("\n%s Created integration branch\n", style.Bold.Render("✓"))
-	fmt.Printf("  Epic:   %s\n", epicID)
-	fmt.Printf("  Branch: %s\n", branchName)
-	fmt.Printf("  From:   %s\n", baseBranchDisplay)
-	fmt.Printf("\n  Future MRs for this epic's children can target:\n")
-	fmt.Printf("    gt mq submit --epic %s\n", epicID)
+	fmt.Fprintf(progress, "\n%s Created integration branch\n", style.Bold.Render("✓"))
+	fmt.Fprintf(progress, "  Epic:   %s\n", epicID)
+	fmt.Fprintf(progress, "  Branch: %s\n", branchName)
+	fmt.Fprintf(progress, "  From:   %s\n", baseBranchDisplay)
+	if landTarget != baseBranchDisplay {
+		fmt.Fprintf(progress, "  Lands:  %s\n", landTarget)
+	}
+	fmt.Fprintf(progress, "\n  Future MRs for this epic's children can target:\n")
+	fmt.Fprintf(progress, "    gt mq submit --epic %s\n", epicID)
 
-	return nil
+	result := &IntegrationCreateOutput{Epic: epicID, Branch: branchName, BaseBranch: landTarget, Created: true}
+	if !isBranchBase(baseBranchDisplay) {
+		result.BaseRef = baseBranchDisplay
+	}
+	return result, nil
+}
+
+// integrationBranchChecker checks integration branches with the same remote
+// semantics as the rest of the integration commands: with --no-fetch, only
+// remote-tracking refs are consulted.
+type integrationBranchChecker struct {
+	g       *git.Git
+	noFetch bool
+}
+
+func (c integrationBranchChecker) BranchExists(name string) (bool, error) {
+	return c.g.BranchExists(name)
+}
+
+func (c integrationBranchChecker) RemoteBranchExists(_, name string) (bool, error) {
+	return integrationRemoteBranchExists(c.g, name, c.noFetch)
+}
+
+// adoptIntegrationBranchForEpic records an existing branch in the epic's
+// metadata (gt mq integration create --adopt).
+func adoptIntegrationBranchForEpic(bd *beads.Beads, checker beads.BranchChecker, epic *beads.Issue, branchName string, progress io.Writer) (*IntegrationCreateOutput, error) {
+	baseBranchDisplay := ""
+	if mqIntegrationCreateBaseBranch != "" || mqIntegrationCreateTarget != "" {
+		target, err := resolveIntegrationLandTarget(mqIntegrationCreateBaseBranch, mqIntegrationCreateTarget)
+		if err != nil {
+			return nil, err
+		}
+		baseBranchDisplay = target
+	}
+
+	newDesc, location, err := adoptIntegrationBranch(checker, epic.Description, branchName, baseBranchDisplay)
+	if err != nil {
+		return nil, err
+	}
+	if newDesc != epic.Description {
+		if err := bd.Update(epic.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
+			return nil, fmt.Errorf("updating epic metadata: %w", err)
+		}
+	}
+
+	fmt.Fprintf(progress, "%s Adopted existing integration branch\n", style.Bold.Render("✓"))
+	fmt.Fprintf(progress, "  Epic:   %s\n", epic.ID)
+	fmt.Fprintf(progress, "  Branch: %s (%s)\n", branchName, location)
+	if baseBranchDisplay != "" {
+		fmt.Fprintf(progress, "  Base:   %s\n", baseBranchDisplay)
+	}
+	fmt.Fprintf(progress, "\n  Future MRs for this epic's children can target:\n")
+	fmt.Fprintf(progress, "    gt mq submit --epic %s\n", epic.ID)
+
+	base := beads.GetBaseBranchField(newDesc)
+	if base == "" {
+		base = "main"
+	}
+	return &IntegrationCreateOutput{Epic: epic.ID, Branch: branchName, BaseBranch: base}, nil
+}
+
+// adoptIntegrationBranch verifies branchName exists locally or on origin and
+// returns description with it recorded as the integration branch, plus
+// base_branch when baseBranch is non-empty. location says where the branch
+// was found.
+func adoptIntegrationBranch(checker beads.BranchChecker, description, branchName, baseBranch string) (newDesc, location string, err error) {
+	if err := validateBranchName(branchName); err != nil {
+		return "", "", fmt.Errorf("invalid branch name: %w", err)
+	}
+
+	local, err := checker.BranchExists(branchName)
+	if err != nil {
+		return "", "", fmt.Errorf("checking branch existence: %w", err)
+	}
+	remote, remoteErr := checker.RemoteBranchExists("origin", branchName)
+
+	switch {
+	case local && remote:
+		location = "local and origin"
+	case local:
+		location = "local only"
+	case remote:
+		location = "origin only"
+	case remoteErr != nil:
+		return "", "", fmt.Errorf("branch '%s' not found locally and origin could not be checked: %w", branchName, remoteErr)
+	default:
+		return "", "", fmt.Errorf("cannot adopt '%s': branch does not exist locally or on origin", branchName)
+	}
+
+	newDesc = addIntegrationBranchField(description, branchName)
+	if baseBranch != "" {
+		newDesc = beads.AddBaseBranchField(newDesc, baseBranch)
+	}
+	return newDesc, location, nil
 }
 
 // addIntegrationBranchField wraps beads.AddIntegrationBranchField for local callers.
@@ -287,19 +584,35 @@ func addIntegrationBranchField(description, branchName string) string {
 }
 
 // runMqIntegrationLand merges an integration branch to main.
-func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
+func runMqIntegrationLand(cmd *cobra.Command, args []string) (err error) {
 	epicID := args[0]
+	applyGitTimeout()
 
-	// Find workspace
-	townRoot, err := workspace.FindFromCwdOrError()
+	townRoot, r, err := resolveMQRig()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return err
 	}
 
-	// Find current rig
-	_, r, err := findCurrentRig(townRoot)
-	if err != nil {
-		return err
+	if mqIntegrationLandStrict && mqIntegrationNoFetch {
+		return fmt.Errorf("--strict-base needs to re-fetch the target; it can't be used with --no-fetch")
+	}
+	if mqIntegrationLandSkipTests && mqIntegrationLandRunTests {
+		return fmt.Errorf("--skip-tests and --run-tests can't be used together")
+	}
+	skipTests := landSkipsTests(getRunTestsSetting(r.Path), mqIntegrationLandSkipTests, mqIntegrationLandRunTests)
+	signing := getLandSigning(r.Path, mqIntegrationLandSign)
+
+	// Partial land is opt-in per rig and can't resume an interrupted land
+	if mqIntegrationLandUpTo != "" {
+		if mqIntegrationLandRepair {
+			return fmt.Errorf("--up-to can't be combined with --repair")
+		}
+		if mqIntegrationLandChildren {
+			return fmt.Errorf("--up-to can't be combined with --close-children; a partial land leaves the epic open")
+		}
+		if !getPartialLandEnabled(r.Path) {
+			return fmt.Errorf("partial land is disabled for rig '%s'; set merge_queue.partial_land to true in its settings to use --up-to", r.Name)
+		}
 	}
 
 	// Initialize beads and git for the rig
@@ -335,16 +648,25 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 	if branchName == "" {
 		branchName = buildIntegrationBranchName(defaultIntegrationBranchTemplate, epicID)
 	}
+	if err := validateBranchName(branchName); err != nil {
+		return fmt.Errorf("epic %s has an invalid integration branch: %w", epicID, err)
+	}
+
+	// Land into the parent epic's integration branch when nested, else base_branch
+	targetBranch, err := resolveLandTarget(bd, g, epic)
+	if err != nil {
+		return err
+	}
 
-	// Read base_branch from epic metadata (where to merge back)
-	// Default to "main" if not stored (backward compat with pre-base-branch epics)
-	targetBranch := beads.GetBaseBranchField(epic.Description)
-	if targetBranch == "" {
-		targetBranch = "main"
+	// Resolve the land tag up front so a bad tag_on_land template fails before merging
+	tagName, err := resolveLandTagName(getTagOnLandTemplate(r.Path), epicID)
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("Landing integration branch for epic: %s\n", epicID)
-	fmt.Printf("  Title: %s\n\n", epic.Title)
+	fmt.Printf("  Title: %s\n", epic.Title)
+	fmt.Printf("  Target: %s\n\n", targetBranch)
 
 	// 2. Verify integration branch exists
 	fmt.Printf("Checking integration branch...\n")
@@ -355,7 +677,7 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 
 	// Also check remote if local doesn't exist
 	if !exists {
-		remoteExists, err := g.RemoteBranchExists("origin", branchName)
+		remoteExists, err := integrationRemoteBranchExists(g, branchName, mqIntegrationNoFetch)
 		if err != nil {
 			return fmt.Errorf("checking remote branch: %w", err)
 		}
@@ -363,13 +685,23 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("integration branch '%s' does not exist (locally or on origin)", branchName)
 		}
 		// Fetch and create local tracking branch
-		fmt.Printf("Fetching integration branch from origin...\n")
-		if err := g.FetchBranch("origin", branchName); err != nil {
-			return fmt.Errorf("fetching branch: %w", err)
+		if !mqIntegrationNoFetch {
+			fmt.Printf("Fetching integration branch from origin...\n")
+			if err := g.FetchBranch("origin", branchName); err != nil {
+				return fmt.Errorf("fetching branch: %w", err)
+			}
 		}
 	}
 	fmt.Printf("  %s Branch exists\n", style.Bold.Render("✓"))
 
+	if mqIntegrationLandRepair {
+		printRecentStatusHistory(bd, epicID, landHistoryLimit)
+		return repairLand(g, bd, branchName, targetBranch, tagName, epic, signing)
+	}
+	if mqIntegrationLandUpTo != "" {
+		return runPartialLand(bd, g, r, townRoot, epic, branchName, targetBranch, skipTests, signing)
+	}
+
 	// 3. Verify all MRs targeting this integration branch are merged
 	fmt.Printf("Checking open merge requests...\n")
 	openMRs, err := findOpenMRsForIntegration(bd, branchName)
@@ -392,24 +724,79 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s No open MRs targeting integration branch\n", style.Bold.Render("✓"))
 	}
 
+	// Open blocking dependencies are a warning, not a refusal: the epic's
+	// own work is merged, and the blocker may be tracked elsewhere
+	fmt.Printf("Checking dependencies...\n")
+	if blockers, err := openEpicBlockers(bd, epicID); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not check dependencies: %v)", err)))
+	} else if len(blockers) > 0 {
+		fmt.Printf("  %s Epic is waiting on open dependencies: %s\n", style.Warning.Render("⚠"), strings.Join(blockers, ", "))
+	} else {
+		fmt.Printf("  %s No open dependencies\n", style.Bold.Render("✓"))
+	}
+
+	// Surface unread mail about the epic (e.g., a reviewer blocking it)
+	if getCheckMailBeforeLand(r.Path) {
+		fmt.Printf("Checking mail about %s...\n", epicID)
+		if err := checkLandMail(epicID, mqIntegrationLandForce); err != nil {
+			return err
+		}
+	}
+
+	// Refuse a disallowed test command before touching any branches
+	testCmd := expandTestCommand(getTestCommand(r.Path), townRoot, r.Name, epicID, branchName)
+	if !skipTests {
+		if err := checkTestCommandAllowed(testCmd, getAllowedTestCommands(townRoot)); err != nil {
+			return err
+		}
+	}
+
+	steps := landPlanSteps(branchName, targetBranch, tagName, skipTests)
+	if mqIntegrationLandChildren {
+		steps = append(steps, "Close the epic's open children")
+	}
+
 	// Dry run stops here
 	if mqIntegrationLandDryRun {
 		fmt.Printf("\n%s Dry run complete. Would perform:\n", style.Bold.Render("🔍"))
-		fmt.Printf("  1. Merge %s to %s (--no-ff)\n", branchName, targetBranch)
-		if !mqIntegrationLandSkipTests {
-			fmt.Printf("  2. Run tests on %s\n", targetBranch)
-		}
-		fmt.Printf("  3. Push %s to origin\n", targetBranch)
-		fmt.Printf("  4. Delete integration branch (local and remote)\n")
-		fmt.Printf("  5. Update epic status to closed\n")
+		printLandPlan(steps)
 		return nil
 	}
 
+	// Confirm before anything is pushed or deleted
+	if !mqIntegrationLandYes {
+		fmt.Printf("\n%s About to land %s (%d open MRs):\n", style.Bold.Render("⚠"), epicID, len(openMRs))
+		printLandPlan(steps)
+		fmt.Println()
+	}
+	if err := confirmLand(mqIntegrationLandYes, term.IsTerminal(int(os.Stdin.Fd())), promptYesNo); err != nil {
+		return err
+	}
+
+	// Step transitions go to the GT_EVENTS_FILE sink, if configured
+	emitLandEvent(events.TypeLandStarted, epicID, branchName, targetBranch, "")
+	defer func() {
+		if err != nil {
+			emitLandEvent(events.TypeLandFailed, epicID, branchName, targetBranch, err.Error())
+		}
+	}()
+
 	// Fetch latest before creating worktree (ensures refs are up to date)
-	fmt.Printf("Fetching latest from origin...\n")
-	if err := g.Fetch("origin"); err != nil {
+	if !mqIntegrationNoFetch {
+		fmt.Printf("Fetching latest from origin...\n")
+	}
+	if err := fetchIntegrationRefs(g, mqIntegrationNoFetch); err != nil {
 		return fmt.Errorf("fetching from origin: %w", err)
 	}
+	if mqIntegrationNoFetch {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(warning: local %s may be behind origin; push will fail if so)", targetBranch)))
+	}
+
+	// A land that pushed but crashed before cleanup would hit the
+	// empty-merge guard below; point at --repair instead.
+	if merged, err := landMerged(g, branchName, targetBranch); err == nil && merged {
+		return fmt.Errorf("'%s' is already merged into '%s' (interrupted land?); re-run with --repair to finish cleanup", branchName, targetBranch)
+	}
 
 	// Create a temporary worktree for the merge operation.
 	// This avoids disrupting running agents (refinery, mayor) whose worktrees
@@ -421,207 +808,1188 @@ func runMqIntegrationLand(cmd *cobra.Command, args []string) error {
 	}
 	defer cleanup()
 
+	// Guard against merging onto the wrong branch if worktree setup changes
+	if current, err := landGit.CurrentBranch(); err != nil {
+		return fmt.Errorf("checking land worktree branch: %w", err)
+	} else if current != targetBranch {
+		return fmt.Errorf("land worktree is on '%s', expected '%s'; refusing to merge", current, targetBranch)
+	}
+
 	// Pull latest target branch into the worktree
-	if err := landGit.Pull("origin", targetBranch); err != nil {
+	if mqIntegrationNoFetch {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(pull from origin/%s skipped: --no-fetch)", targetBranch)))
+	} else if err := landGit.Pull("origin", targetBranch); err != nil {
 		// Non-fatal if pull fails (e.g., first time)
 		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(pull from origin/%s skipped)", targetBranch)))
 	}
-
-	// 4. Merge integration branch into target
-	fmt.Printf("Merging %s to %s...\n", branchName, targetBranch)
-	mergeMsg := fmt.Sprintf("Merge %s: %s\n\nEpic: %s", branchName, epic.Title, epicID)
-	if err := landGit.MergeNoFF("origin/"+branchName, mergeMsg); err != nil {
-		// Abort merge on failure (cleanup handles worktree removal)
-		_ = landGit.AbortMerge()
-		return fmt.Errorf("merge failed: %w", err)
-	}
-	fmt.Printf("  %s Merged successfully\n", style.Bold.Render("✓"))
-
-	// 5. Run tests (if configured and not skipped)
-	if !mqIntegrationLandSkipTests {
-		testCmd := getTestCommand(r.Path)
-		if testCmd != "" {
-			fmt.Printf("Running tests: %s\n", testCmd)
-			if err := runTestCommand(landGit.WorkDir(), testCmd); err != nil {
-				// Tests failed - no need to reset, worktree is temporary
-				fmt.Printf("  %s Tests failed\n", style.Bold.Render("✗"))
-				return fmt.Errorf("tests failed: %w", err)
-			}
-			fmt.Printf("  %s Tests passed\n", style.Bold.Render("✓"))
-		} else {
-			fmt.Printf("  %s\n", style.Dim.Render("(no test command configured)"))
+	if mqIntegrationLandStrict {
+		if err := checkLandBase(landGit, targetBranch); err != nil {
+			return err
 		}
-	} else {
-		fmt.Printf("  %s\n", style.Dim.Render("(tests skipped)"))
 	}
 
-	// Verify the merge actually brought changes (guard against empty merges).
-	// An empty merge means conflict resolution discarded all integration branch work,
-	// which would silently lose data if we proceed to delete the branch.
-	verifyCmd := exec.Command("git", "diff", "--stat", "HEAD~1..HEAD")
-	verifyCmd.Dir = landGit.WorkDir()
-	diffOutput, verifyErr := verifyCmd.Output()
-	if verifyErr == nil && len(strings.TrimSpace(string(diffOutput))) == 0 {
-		return fmt.Errorf("merge produced no file changes — integration branch work may have been discarded during conflict resolution\n"+
-			"  Integration branch '%s' has NOT been deleted.\n"+
-			"  Inspect manually: git diff %s...origin/%s", branchName, targetBranch, branchName)
+	// Remember what is being landed, to confirm the push carried it
+	tip, err := landGit.Rev("origin/" + branchName)
+	if err != nil {
+		return fmt.Errorf("resolving %s tip: %w", branchName, err)
+	}
+
+	// 4-5. Merge integration branch into target, then run tests (if
+	// configured and not skipped) and the empty-merge guard. A rejected
+	// push repeats this on the new target.
+	var changes []git.FileChange
+	build := func() error {
+		fmt.Printf("Merging %s to %s...\n", branchName, targetBranch)
+		mergeMsg := fmt.Sprintf("Merge %s: %s\n\nEpic: %s", branchName, epic.Title, epicID)
+		if err := landMerge(landGit, "origin/"+branchName, mergeMsg, signing); err != nil {
+			// Abort merge on failure (cleanup handles worktree removal)
+			_ = landGit.AbortMerge()
+			return fmt.Errorf("merge failed: %w", err)
+		}
+		fmt.Printf("  %s Merged successfully\n", style.Bold.Render("✓"))
+		emitLandEvent(events.TypeLandMerged, epicID, branchName, targetBranch, "")
+
+		if ran, err := runLandTests(runTestCommand, landGit.WorkDir(), testCmd, getTestTimeout(r.Path), skipTests); err != nil {
+			return err
+		} else if ran {
+			emitLandEvent(events.TypeLandTestsPassed, epicID, branchName, targetBranch, "")
+		}
+
+		var err error
+		changes, err = verifyLandMergeChanges(landGit, branchName, targetBranch)
+		return err
+	}
+	if err := build(); err != nil {
+		return err
 	}
 
 	// 6. Push to origin
 	fmt.Printf("Pushing %s to origin...\n", targetBranch)
-	if err := landGit.Push("origin", targetBranch, false); err != nil {
+	rebuild := rebuildLand(landGit, util.ExecRunner{}, targetBranch, mqIntegrationLandStrict, build)
+	if err := pushLandWithRetry(landGit, targetBranch, getPushRetries(r.Path), time.Sleep, rebuild); err != nil {
 		return fmt.Errorf("push failed: %w", err)
 	}
 	fmt.Printf("  %s Pushed to origin\n", style.Bold.Render("✓"))
-
-	// 7. Delete integration branch (use bare repo git — ref-only operations)
-	fmt.Printf("Deleting integration branch...\n")
-	// Delete remote first
-	if err := g.DeleteRemoteBranch("origin", branchName); err != nil {
-		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not delete remote branch: %v)", err)))
-	} else {
-		fmt.Printf("  %s Deleted from origin\n", style.Bold.Render("✓"))
+	emitLandEvent(events.TypeLandPushed, epicID, branchName, targetBranch, "")
+	if err := verifyLandContains(landGit, targetBranch, branchName, tip); err != nil {
+		return err
 	}
-	// Delete local
-	if err := g.DeleteBranch(branchName, true); err != nil {
-		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not delete local branch: %v)", err)))
-	} else {
-		fmt.Printf("  %s Deleted locally\n", style.Bold.Render("✓"))
+
+	// Tag the landed merge commit. The target is already pushed, so a tag
+	// failure is reported but doesn't fail the land.
+	if tagName != "" {
+		fmt.Printf("Tagging merge commit as %s...\n", tagName)
+		if err := tagLandedEpic(landGit, tagName, "HEAD", epicID, epic.Title, signing); err != nil {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not tag: %v)", err)))
+		} else {
+			fmt.Printf("  %s Tagged and pushed\n", style.Bold.Render("✓"))
+		}
 	}
 
-	// 8. Update epic status
-	fmt.Printf("Updating epic status...\n")
-	if err := bd.Close(epicID); err != nil {
-		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not close epic: %v)", err)))
-	} else {
-		fmt.Printf("  %s Epic closed\n", style.Bold.Render("✓"))
+	// 7-8. Delete integration branch and close the epic
+	finishLand(g, bd, branchName, targetBranch, epicID)
+	if mqIntegrationLandChildren {
+		closeLandedChildren(bd, epicID)
 	}
+	emitLandEvent(events.TypeLandCompleted, epicID, branchName, targetBranch, "")
 
 	// Success output
 	fmt.Printf("\n%s Successfully landed integration branch\n", style.Bold.Render("✓"))
-	fmt.Printf("  Epic:   %s\n", epicID)
-	fmt.Printf("  Branch: %s → %s\n", branchName, targetBranch)
-
-	return nil
-}
-
-// findOpenMRsForIntegration finds all open merge requests targeting an integration branch.
-func findOpenMRsForIntegration(bd *beads.Beads, targetBranch string) ([]*beads.Issue, error) {
-	// List all open merge requests
-	opts := beads.ListOptions{
-		Type:   "merge-request",
-		Status: "open",
-	}
-	allMRs, err := bd.List(opts)
-	if err != nil {
-		return nil, err
+	fmt.Printf("  Epic:    %s\n", epicID)
+	fmt.Printf("  Branch:  %s → %s\n", branchName, targetBranch)
+	if changes != nil {
+		fmt.Printf("  Changes: %s\n", formatDiffSummary(changes))
 	}
 
-	return filterMRsByTarget(allMRs, targetBranch), nil
+	return nil
 }
 
-// filterMRsByTarget filters merge requests to those targeting a specific branch.
-func filterMRsByTarget(mrs []*beads.Issue, targetBranch string) []*beads.Issue {
-	var result []*beads.Issue
-	for _, mr := range mrs {
-		fields := beads.ParseMRFields(mr)
-		if fields != nil && fields.Target == targetBranch {
-			result = append(result, mr)
-		}
-	}
-	return result
+// landContainsChecker is the git access verifyLandContains needs; *git.Git
+// implements it.
+type landContainsChecker interface {
+	FetchBranch(remote, branch string) error
+	BranchContains(branch, commit string) (bool, error)
 }
 
-// getTestCommand returns the test command from rig settings.
-func getTestCommand(rigPath string) string {
-	settingsPath := filepath.Join(rigPath, "settings", "config.json")
-	settings, err := config.LoadRigSettings(settingsPath)
+// verifyLandContains confirms that origin's targetBranch contains tip, the
+// integration branch commit that was merged. The target is re-fetched first
+// so the check reads what the remote has, not the land worktree's local
+// branch, which always contains tip after the merge. If origin doesn't
+// have it, the push didn't land the epic's work, and the land stops before
+// the integration branch is deleted.
+func verifyLandContains(c landContainsChecker, targetBranch, branchName, tip string) error {
+	if err := c.FetchBranch("origin", targetBranch); err != nil {
+		return fmt.Errorf("re-fetching origin/%s to verify the push: %w", targetBranch, err)
+	}
+	remoteTarget := "origin/" + targetBranch
+	contains, err := c.BranchContains(remoteTarget, tip)
 	if err != nil {
-		return ""
+		return fmt.Errorf("verifying %s contains %s: %w", remoteTarget, branchName, err)
 	}
-	if settings.MergeQueue != nil && settings.MergeQueue.TestCommand != "" {
-		return settings.MergeQueue.TestCommand
+	if !contains {
+		return fmt.Errorf("%s does not contain %s (%s) after push; the work did not land, so %s was kept",
+			remoteTarget, branchName, shortSHA(tip), branchName)
 	}
-	return ""
+	fmt.Printf("  %s %s contains %s\n", style.Bold.Render("✓"), remoteTarget, shortSHA(tip))
+	return nil
 }
 
-// runTestCommand executes a test command in the given directory.
-func runTestCommand(workDir, testCmd string) error {
-	parts := strings.Fields(testCmd)
-	if len(parts) == 0 {
-		return nil
+// getPushRetries returns merge_queue.push_retries for the rig, defaulting
+// to config.DefaultPushRetries.
+func getPushRetries(rigPath string) int {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil || settings.MergeQueue == nil {
+		return config.DefaultPushRetries
 	}
+	return settings.MergeQueue.PushRetryCount()
+}
 
-	cmd := exec.Command(parts[0], parts[1:]...)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
+// landSigning says whether an integration land GPG-signs its merge commit
+// and tag, and with which key ("" for git's default).
+type landSigning struct {
+	Sign bool
+	Key  string
 }
 
-// resetHard performs a git reset --hard to the given ref.
-func resetHard(g *git.Git, ref string) error {
-	// We need to use the git package, but it doesn't have a Reset method
-	// For now, use the internal run method via Checkout workaround
-	// This is a bit of a hack but works for now
-	cmd := exec.Command("git", "reset", "--hard", ref)
-	cmd.Dir = g.WorkDir()
-	return cmd.Run()
+// getLandSigning returns merge_queue.sign_commits and signing_key for the
+// rig. signFlag (--sign) turns signing on even when the setting is off.
+func getLandSigning(rigPath string, signFlag bool) landSigning {
+	signing := landSigning{Sign: signFlag}
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil || settings.MergeQueue == nil {
+		return signing
+	}
+	signing.Sign = signing.Sign || settings.MergeQueue.SignCommits
+	signing.Key = settings.MergeQueue.SigningKey
+	return signing
 }
 
-// runMqIntegrationStatus shows the status of an integration branch for an epic.
-func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
-	epicID := args[0]
+// landMerger performs the land merge; *git.Git implements it.
+type landMerger interface {
+	MergeNoFF(branch, message string) error
+	MergeNoFFSigned(branch, message, key string) error
+}
 
-	// Find workspace
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+// landMerge merges ref into the land worktree with --no-ff, signing the
+// merge commit when signing says so.
+func landMerge(m landMerger, ref, message string, signing landSigning) error {
+	if !signing.Sign {
+		return m.MergeNoFF(ref, message)
 	}
+	return signingFailure(m.MergeNoFFSigned(ref, message, signing.Key), "merge commit", signing.Key)
+}
 
-	// Find current rig
-	_, r, err := findCurrentRig(townRoot)
-	if err != nil {
+// signingFailure replaces a git error caused by gpg failing to sign what
+// with one that says so; git's own message only mentions the gpg exit.
+// Other errors are returned unchanged.
+func signingFailure(err error, what, key string) error {
+	var gitErr *git.GitError
+	if err == nil || !errors.As(err, &gitErr) {
 		return err
 	}
+	stderr := strings.ToLower(gitErr.Stderr)
+	if !strings.Contains(stderr, "gpg") && !strings.Contains(stderr, "failed to sign") && !strings.Contains(stderr, "secret key") {
+		return err
+	}
+	keyDesc := "the default signing key (user.signingkey)"
+	if key != "" {
+		keyDesc = "key " + key
+	}
+	return fmt.Errorf("could not GPG-sign the %s with %s; check that the key is in your keyring and gpg-agent is running, or unset merge_queue.sign_commits\n  %w", what, keyDesc, err)
+}
 
-	// Initialize beads for the rig
-	bd := beads.New(r.Path)
+// pushRetryBaseDelay is the wait before retrying a push that failed on the
+// network. It doubles with each retry.
+const pushRetryBaseDelay = 2 * time.Second
 
-	// Fetch epic to get stored branch name
-	epic, err := bd.Show(epicID)
-	if err != nil {
-		if err == beads.ErrNotFound {
-			return fmt.Errorf("epic '%s' not found", epicID)
-		}
-		return fmt.Errorf("fetching epic: %w", err)
+// landPusher pushes the land worktree; *git.Git implements it.
+type landPusher interface {
+	Push(remote, branch string, force bool) error
+}
+
+// pushFailure classifies a failed push.
+type pushFailure int
+
+const (
+	pushFatal     pushFailure = iota // Retrying won't help (auth, hooks, protection)
+	pushRejected                     // Non-fast-forward: the target moved on origin
+	pushTransient                    // Network trouble
+)
+
+// Substrings of git's stderr (lowercased) that identify retryable failures.
+var (
+	pushRejectedMarkers  = []string{"non-fast-forward", "[rejected]", "fetch first", "updates were rejected"}
+	pushTransientMarkers = []string{
+		"could not resolve host", "connection reset", "connection timed out", "connection refused",
+		"the remote end hung up", "early eof", "operation timed out", "could not read from remote repository",
+	}
+)
+
+// classifyPushError decides from git's stderr whether a push is worth retrying.
+func classifyPushError(err error) pushFailure {
+	if errors.Is(err, git.ErrNetworkTimeout) {
+		return pushTransient
+	}
+	msg := err.Error()
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) {
+		msg = gitErr.Stderr
+	}
+	msg = strings.ToLower(msg)
+	for _, m := range pushRejectedMarkers {
+		if strings.Contains(msg, m) {
+			return pushRejected
+		}
+	}
+	for _, m := range pushTransientMarkers {
+		if strings.Contains(msg, m) {
+			return pushTransient
+		}
+	}
+	return pushFatal
+}
+
+// pushLandWithRetry pushes targetBranch to origin, retrying up to retries
+// times. A non-fast-forward rejection calls rebuild first, which must
+// redo the merge on the new target and re-run the tests and checks, so a
+// merge that was never tested is not pushed; a nil rebuild fails instead.
+// A network error backs off. Anything else fails at once.
+func pushLandWithRetry(p landPusher, targetBranch string, retries int, sleep func(time.Duration), rebuild func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := p.Push("origin", targetBranch, false)
+		if err == nil {
+			return nil
+		}
+		kind := classifyPushError(err)
+		if kind == pushFatal || attempt >= retries {
+			return err
+		}
+		switch kind {
+		case pushRejected:
+			if rebuild == nil {
+				return fmt.Errorf("origin/%s moved and the land can't be re-verified on it: %w", targetBranch, err)
+			}
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(origin/%s moved; re-merging and re-testing before retry %d/%d)", targetBranch, attempt+1, retries)))
+			if err := rebuild(); err != nil {
+				return fmt.Errorf("rebuilding land on the new %s after rejected push: %w", targetBranch, err)
+			}
+		case pushTransient:
+			delay := pushRetryBaseDelay << attempt
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(push failed: %v; retry %d/%d in %s)", err, attempt+1, retries, delay)))
+			sleep(delay)
+		}
+	}
+}
+
+// landRebaser re-fetches the land target and locates the land worktree;
+// *git.Git implements it.
+type landRebaser interface {
+	landBaseRefresher
+	WorkDir() string
+}
+
+// rebuildLand returns pushLandWithRetry's rebuild step. It resets the land
+// worktree to the re-fetched origin/<target> and runs build again (merge,
+// tests, empty-merge guard), so the retried push carries a merge that was
+// verified on the target it lands on. With --strict-base the target moving
+// is an error, so it reports that through checkLandBase instead.
+func rebuildLand(g landRebaser, r util.Runner, targetBranch string, strict bool, build func() error) func() error {
+	return func() error {
+		if strict {
+			if err := checkLandBase(g, targetBranch); err != nil {
+				return err
+			}
+			return fmt.Errorf("--strict-base: push to %s was rejected; re-run", targetBranch)
+		}
+		if err := g.FetchBranch("origin", targetBranch); err != nil {
+			return fmt.Errorf("re-fetching origin/%s: %w", targetBranch, err)
+		}
+		if err := resetHard(r, g.WorkDir(), "origin/"+targetBranch); err != nil {
+			return fmt.Errorf("resetting land worktree to origin/%s: %w", targetBranch, err)
+		}
+		return build()
+	}
+}
+
+// testCommandRunner runs a shell test command; runTestCommand is the real one.
+type testCommandRunner func(workDir, testCmd string, timeout time.Duration) error
+
+// runLandTests runs the land test command in the land worktree, reporting
+// progress. ran is true only when tests ran and passed.
+func runLandTests(run testCommandRunner, workDir, testCmd string, timeout time.Duration, skip bool) (ran bool, err error) {
+	if skip {
+		fmt.Printf("  %s\n", style.Dim.Render("(tests skipped)"))
+		return false, nil
+	}
+	if testCmd == "" {
+		fmt.Printf("  %s\n", style.Dim.Render("(no test command configured)"))
+		return false, nil
+	}
+	fmt.Printf("Running tests: %s\n", testCmd)
+	if err := run(workDir, testCmd, timeout); err != nil {
+		// Tests failed - no need to reset, worktree is temporary
+		if errors.Is(err, errTestTimeout) {
+			fmt.Printf("  %s Tests timed out\n", style.Bold.Render("✗"))
+			return false, err
+		}
+		fmt.Printf("  %s Tests failed\n", style.Bold.Render("✗"))
+		return false, fmt.Errorf("tests failed: %w", err)
+	}
+	fmt.Printf("  %s Tests passed\n", style.Bold.Render("✓"))
+	return true, nil
+}
+
+// landBaseRefresher re-reads the target branch from origin; *git.Git
+// implements it.
+type landBaseRefresher interface {
+	FetchBranch(remote, branch string) error
+	IsAncestor(ancestor, descendant string) (bool, error)
+}
+
+// checkLandBase fetches origin/<target> again and fails if it has commits
+// the land worktree's HEAD doesn't contain: someone pushed to the target
+// after the land started, or the pull into the worktree didn't happen.
+func checkLandBase(g landBaseRefresher, targetBranch string) error {
+	if err := g.FetchBranch("origin", targetBranch); err != nil {
+		return fmt.Errorf("re-fetching origin/%s: %w", targetBranch, err)
+	}
+	upToDate, err := g.IsAncestor("origin/"+targetBranch, "HEAD")
+	if err != nil {
+		return fmt.Errorf("comparing %s with origin/%s: %w", targetBranch, targetBranch, err)
+	}
+	if !upToDate {
+		return fmt.Errorf("target branch %s advanced on origin since the land started; re-run", targetBranch)
+	}
+	return nil
+}
+
+// landDiffStater reads the files a land merge changed; *git.Git implements it.
+type landDiffStater interface {
+	DiffStat(fromRef, toRef string) ([]git.FileChange, error)
+}
+
+// verifyLandMergeChanges guards against empty merges: a land merge that
+// changed no files means conflict resolution discarded the integration
+// branch work, which would silently lose data if the land went on. It
+// returns the merge's changes for the land summary, or nil when the diff
+// couldn't be read.
+func verifyLandMergeChanges(d landDiffStater, branchName, targetBranch string) ([]git.FileChange, error) {
+	changes, err := d.DiffStat("HEAD~1", "HEAD")
+	if err != nil {
+		// A diff that can't be read isn't proof of an empty merge
+		return nil, nil
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("merge produced no file changes — integration branch work may have been discarded during conflict resolution\n"+
+			"  Integration branch '%s' has NOT been deleted.\n"+
+			"  Inspect manually: git diff %s...origin/%s", branchName, targetBranch, branchName)
+	}
+	return changes, nil
+}
+
+// formatDiffSummary summarizes changes as "+120 -34 across 8 files".
+func formatDiffSummary(changes []git.FileChange) string {
+	var added, deleted int
+	for _, c := range changes {
+		added += c.Added
+		deleted += c.Deleted
+	}
+	files := "files"
+	if len(changes) == 1 {
+		files = "file"
+	}
+	return fmt.Sprintf("+%d -%d across %d %s", added, deleted, len(changes), files)
+}
+
+// emitLandEvent records a land step in the GT_EVENTS_FILE sink. It does
+// nothing when no sink is configured; write errors never fail the land.
+func emitLandEvent(eventType, epicID, branch, target, reason string) {
+	if os.Getenv(events.SinkEnv) == "" {
+		return
+	}
+	_ = events.Emit(eventType, detectActor(), events.LandPayload(epicID, branch, target, reason))
+}
+
+// resolveLandTarget returns the branch an epic's integration branch lands
+// into. When an ancestor epic has its own integration branch the child lands
+// there (integration-of-integrations), so landing cascades upward one level at
+// a time. Otherwise it is the epic's base_branch, defaulting to "main" for
+// epics created before base_branch was recorded. A base_branch holding a tag
+// or SHA (recorded by older versions of create) is an error, since land can
+// only push to a branch.
+func resolveLandTarget(bd beads.IssueShower, checker beads.BranchChecker, epic *beads.Issue) (string, error) {
+	if epic.Parent != "" {
+		parentBranch, err := beads.DetectIntegrationBranch(bd, checker, epic.Parent)
+		if err != nil {
+			return "", fmt.Errorf("detecting parent integration branch: %w", err)
+		}
+		if parentBranch != "" {
+			return parentBranch, nil
+		}
+	}
+
+	if baseBranch := beads.GetBaseBranchField(epic.Description); baseBranch != "" {
+		if !isBranchBase(baseBranch) {
+			return "", fmt.Errorf("epic %s records base_branch %q, which is a tag or commit, not a branch; set base_branch in its description to the branch to land into", epic.ID, baseBranch)
+		}
+		return baseBranch, nil
+	}
+	return "main", nil
+}
+
+// runMqIntegrationAbort cleans up after an interrupted land.
+func runMqIntegrationAbort(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Aborting land for epic: %s\n", epicID)
+	printRecentStatusHistory(beads.New(r.Path), epicID, landHistoryLimit)
+	cleaned, err := abortLand(r.Path)
+	for _, step := range cleaned {
+		fmt.Printf("  %s %s\n", style.Bold.Render("✓"), step)
+	}
+	if err != nil {
+		return err
+	}
+	if len(cleaned) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(nothing to abort: no land in progress)"))
+		return nil
+	}
+
+	fmt.Printf("\n%s Land aborted; integration branch left intact\n", style.Bold.Render("✓"))
+	return nil
+}
+
+// landHistoryLimit is how many status changes repair and abort show.
+const landHistoryLimit = 5
+
+// historyReader reads an issue's recorded changes. *beads.Beads satisfies
+// this interface.
+type historyReader interface {
+	History(id string) ([]beads.StateChange, error)
+}
+
+// printRecentStatusHistory prints the last few status changes of an epic, so
+// an operator repairing or aborting a land can see how it got there (e.g.,
+// closed by a forced land, then reopened). Best effort: a backend without
+// history or a lookup failure is noted and skipped.
+func printRecentStatusHistory(h historyReader, id string, limit int) {
+	changes, err := h.History(id)
+	if err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(no history for %s: %v)", id, err)))
+		return
+	}
+	var status []beads.StateChange
+	for _, c := range changes {
+		if c.Field == "status" || c.Field == "created" {
+			status = append(status, c)
+		}
+	}
+	if len(status) > limit {
+		status = status[len(status)-limit:]
+	}
+	if len(status) == 0 {
+		return
+	}
+	fmt.Printf("Recent status changes for %s:\n", id)
+	for _, c := range status {
+		fmt.Printf("  %s  %s  %s\n", style.Dim.Render(c.Timestamp), formatStateChange(c), style.Dim.Render(c.Actor))
+	}
+}
+
+// landBranchDeleter deletes branches locally and on a remote.
+// *git.Git satisfies this interface.
+type landBranchDeleter interface {
+	DeleteRemoteBranch(remote, branch string) error
+	DeleteBranch(name string, force bool) error
+}
+
+// epicCloser closes issues with a resolution note. *beads.Beads satisfies
+// this interface.
+type epicCloser interface {
+	CloseWithReason(reason string, ids ...string) error
+}
+
+// childCloser lists an epic's children and closes them one by one.
+// *beads.Beads satisfies this interface.
+type childCloser interface {
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+	BulkCloseWithReason(reason string, ids []string) (closed int, errs []error)
+}
+
+// landCloser closes a landed epic and, with --close-children, its children.
+type landCloser interface {
+	epicCloser
+	childCloser
+}
+
+// closeLandedChildren closes the children of a landed epic that are still
+// open (--close-children). Like the rest of the cleanup, failures are
+// reported per child but don't fail the land.
+func closeLandedChildren(bd childCloser, epicID string) {
+	fmt.Printf("Closing open children of %s...\n", epicID)
+	children, err := bd.List(beads.ListOptions{
+		Parent:   epicID,
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not list children: %v)", err)))
+		return
+	}
+	var open []string
+	for _, child := range children {
+		if child.Status != "closed" {
+			open = append(open, child.ID)
+		}
+	}
+	if len(open) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no open children)"))
+		return
+	}
+
+	closed, errs := bd.BulkCloseWithReason(fmt.Sprintf("Epic %s landed", epicID), open)
+	for _, err := range errs {
+		fmt.Printf("  %s %v\n", style.Error.Render("✗"), err)
+	}
+	fmt.Printf("  %s Closed %d of %d open children\n", style.Bold.Render("✓"), closed, len(open))
+}
+
+// landCloseReason is the resolution recorded on an epic closed by land, so
+// the epic's history says where its work went.
+func landCloseReason(branchName, targetBranch string) string {
+	return fmt.Sprintf("Landed %s to %s", branchName, targetBranch)
+}
+
+// landMerged reports whether branch is already merged into target on origin,
+// the state a land leaves behind if it pushed but stopped before cleanup.
+func landMerged(g *git.Git, branch, target string) (bool, error) {
+	return g.IsAncestor("origin/"+branch, "origin/"+target)
+}
+
+// finishLand runs the steps after a land's push: delete the integration
+// branch (remote, then local) and close the epic. The merge is already on
+// the target, so failures are reported but don't fail the land.
+func finishLand(g landBranchDeleter, bd epicCloser, branchName, targetBranch, epicID string) {
+	// Use bare repo git — ref-only operations
+	fmt.Printf("Deleting integration branch...\n")
+	if err := g.DeleteRemoteBranch("origin", branchName); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not delete remote branch: %v)", err)))
+	} else {
+		fmt.Printf("  %s Deleted from origin\n", style.Bold.Render("✓"))
+	}
+	if err := g.DeleteBranch(branchName, true); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not delete local branch: %v)", err)))
+	} else {
+		fmt.Printf("  %s Deleted locally\n", style.Bold.Render("✓"))
+	}
+
+	fmt.Printf("Updating epic status...\n")
+	if err := bd.CloseWithReason(landCloseReason(branchName, targetBranch), epicID); err != nil {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not close epic: %v)", err)))
+	} else {
+		fmt.Printf("  %s Epic closed\n", style.Bold.Render("✓"))
+	}
+}
+
+// repairLand completes a land whose merge was pushed but whose cleanup
+// never ran. It refuses if the integration branch is not merged into the
+// target, since then there is nothing to repair.
+func repairLand(g *git.Git, bd landCloser, branchName, targetBranch, tagName string, epic *beads.Issue, signing landSigning) error {
+	if err := fetchIntegrationRefs(g, mqIntegrationNoFetch); err != nil {
+		return fmt.Errorf("fetching from origin: %w", err)
+	}
+
+	fmt.Printf("Checking whether %s is already merged...\n", branchName)
+	merged, err := landMerged(g, branchName, targetBranch)
+	if err != nil {
+		return fmt.Errorf("checking merge state: %w", err)
+	}
+	if !merged {
+		return fmt.Errorf("nothing to repair: '%s' is not merged into '%s'; run land without --repair", branchName, targetBranch)
+	}
+	fmt.Printf("  %s Already merged into %s\n", style.Bold.Render("✓"), targetBranch)
+
+	// Skip the tag if the interrupted run already created it
+	if tagName != "" {
+		if _, err := g.Rev("refs/tags/" + tagName); err == nil {
+			tagName = ""
+		}
+	}
+
+	var steps []string
+	if tagName != "" {
+		steps = append(steps, fmt.Sprintf("Tag origin/%s as %s and push the tag", targetBranch, tagName))
+	}
+	steps = append(steps,
+		"Delete integration branch (local and remote)",
+		"Update epic status to closed")
+	if mqIntegrationLandChildren {
+		steps = append(steps, "Close the epic's open children")
+	}
+
+	if mqIntegrationLandDryRun {
+		fmt.Printf("\n%s Dry run complete. Repair would perform:\n", style.Bold.Render("🔍"))
+		printLandPlan(steps)
+		return nil
+	}
+	if !mqIntegrationLandYes {
+		fmt.Printf("\n%s About to finish landing %s:\n", style.Bold.Render("⚠"), epic.ID)
+		printLandPlan(steps)
+		fmt.Println()
+	}
+	if err := confirmLand(mqIntegrationLandYes, term.IsTerminal(int(os.Stdin.Fd())), promptYesNo); err != nil {
+		return err
+	}
+
+	if tagName != "" {
+		fmt.Printf("Tagging origin/%s as %s...\n", targetBranch, tagName)
+		if err := tagLandedEpic(g, tagName, "origin/"+targetBranch, epic.ID, epic.Title, signing); err != nil {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(could not tag: %v)", err)))
+		} else {
+			fmt.Printf("  %s Tagged and pushed\n", style.Bold.Render("✓"))
+		}
+	}
+
+	finishLand(g, bd, branchName, targetBranch, epic.ID)
+	if mqIntegrationLandChildren {
+		closeLandedChildren(bd, epic.ID)
+	}
+
+	fmt.Printf("\n%s Repaired interrupted land\n", style.Bold.Render("✓"))
+	fmt.Printf("  Epic:   %s\n", epic.ID)
+	fmt.Printf("  Branch: %s → %s\n", branchName, targetBranch)
+	return nil
+}
+
+// landPlanSteps lists what a land will do, for --dry-run and the confirmation prompt.
+func landPlanSteps(branchName, targetBranch, tagName string, skipTests bool) []string {
+	steps := []string{fmt.Sprintf("Merge %s to %s (--no-ff)", branchName, targetBranch)}
+	if !skipTests {
+		steps = append(steps, fmt.Sprintf("Run tests on %s", targetBranch))
+	}
+	steps = append(steps, fmt.Sprintf("Push %s to origin", targetBranch))
+	if tagName != "" {
+		steps = append(steps, fmt.Sprintf("Tag merge commit as %s and push the tag", tagName))
+	}
+	return append(steps,
+		"Delete integration branch (local and remote)",
+		"Update epic status to closed")
+}
+
+// printLandPlan prints numbered land steps.
+func printLandPlan(steps []string) {
+	for i, step := range steps {
+		fmt.Printf("  %d. %s\n", i+1, step)
+	}
+}
+
+// confirmLand asks before landing unless --yes was given. Without a terminal
+// to ask on, it refuses rather than blocking on stdin.
+func confirmLand(yes, interactive bool, ask func(question string) bool) error {
+	if yes {
+		return nil
+	}
+	if !interactive {
+		return fmt.Errorf("refusing to land without confirmation: stdin is not a terminal\n" +
+			"  Re-run with --yes to land non-interactively, or --dry-run to preview")
+	}
+	if !ask("Proceed with land?") {
+		return fmt.Errorf("land cancelled")
+	}
+	return nil
+}
+
+// openMRListOptions selects all open merge requests by their gt:merge-request
+// label, at any priority.
+var openMRListOptions = beads.ListOptions{
+	Label:    "gt:merge-request",
+	Status:   "open",
+	Priority: -1, // No priority filter
+}
+
+// findOpenMRsForIntegration finds all open merge requests targeting an integration branch.
+func findOpenMRsForIntegration(bd *beads.Beads, targetBranch string) ([]*beads.Issue, error) {
+	allMRs, err := bd.List(openMRListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterMRsByTarget(allMRs, targetBranch), nil
+}
+
+// filterMRsByTarget filters merge requests to those targeting a specific branch.
+// Issues that are not merge requests are dropped.
+func filterMRsByTarget(mrs []*beads.Issue, targetBranch string) []*beads.Issue {
+	var result []*beads.Issue
+	for _, mr := range mrs {
+		if !beads.IsMergeRequest(mr) {
+			continue
+		}
+		fields := beads.ParseMRFields(mr)
+		if fields != nil && fields.Target == targetBranch {
+			result = append(result, mr)
+		}
+	}
+	return result
+}
+
+// filterMRsByWorker filters merge requests to those submitted by worker,
+// compared case-insensitively. MRs without a worker field are excluded.
+// Compose with filterMRsByTarget to narrow by both dimensions.
+func filterMRsByWorker(mrs []*beads.Issue, worker string) []*beads.Issue {
+	var result []*beads.Issue
+	for _, mr := range mrs {
+		if !beads.IsMergeRequest(mr) {
+			continue
+		}
+		fields := beads.ParseMRFields(mr)
+		if fields != nil && fields.Worker != "" && strings.EqualFold(fields.Worker, worker) {
+			result = append(result, mr)
+		}
+	}
+	return result
+}
+
+// getTestCommand returns the test command from rig settings.
+func getTestCommand(rigPath string) string {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		return ""
+	}
+	if settings.MergeQueue != nil && settings.MergeQueue.TestCommand != "" {
+		return settings.MergeQueue.TestCommand
+	}
+	return ""
+}
+
+// getRunTestsSetting returns merge_queue.run_tests from the rig's settings,
+// or nil when it isn't set. MergeQueueConfig.RunTests can't tell an explicit
+// false from a missing field, so the field is read from the file directly.
+func getRunTestsSetting(rigPath string) *bool {
+	data, err := os.ReadFile(config.RigSettingsPath(rigPath)) //nolint:gosec // G304: path is the rig's settings file
+	if err != nil {
+		return nil
+	}
+	var raw struct {
+		MergeQueue *struct {
+			RunTests *bool `json:"run_tests"`
+		} `json:"merge_queue"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || raw.MergeQueue == nil {
+		return nil
+	}
+	return raw.MergeQueue.RunTests
+}
+
+// landSkipsTests reports whether a land skips its test run. --skip-tests
+// and --run-tests win; otherwise tests are skipped only when the rig sets
+// merge_queue.run_tests to false (e.g., because CI runs them instead).
+func landSkipsTests(runTests *bool, skipFlag, runFlag bool) bool {
+	switch {
+	case skipFlag:
+		return true
+	case runFlag:
+		return false
+	}
+	return runTests != nil && !*runTests
+}
+
+// expandTestCommand substitutes the {town_root}, {rig}, {epic} and {branch}
+// placeholders in a test command. Anything else, including $VAR references,
+// is passed through unchanged for the shell to expand.
+//
+// Substituted values are shell-quoted: the branch comes from the epic's
+// integration_branch metadata, which anyone who can edit the bead controls,
+// and git allows ; | $ and backticks in branch names.
+func expandTestCommand(testCmd, townRoot, rigName, epicID, branch string) string {
+	return strings.NewReplacer(
+		"{town_root}", config.ShellQuote(townRoot),
+		"{rig}", config.ShellQuote(rigName),
+		"{epic}", config.ShellQuote(epicID),
+		"{branch}", config.ShellQuote(branch),
+	).Replace(testCmd)
+}
+
+// getCheckMailBeforeLand reports whether merge_queue.check_mail_before_land
+// is enabled in rig settings.
+func getCheckMailBeforeLand(rigPath string) bool {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		return false
+	}
+	return settings.MergeQueue != nil && settings.MergeQueue.CheckMailBeforeLand
+}
+
+// checkLandMail reads the landing agent's unread mail and refuses the land
+// if any of it is about epicID, unless force is set.
+func checkLandMail(epicID string, force bool) error {
+	address := detectSender()
+	unread, err := unreadMail(address)
+	if err != nil {
+		return fmt.Errorf("checking mail before land: %w", err)
+	}
+
+	matching, err := checkEpicMail(unread, epicID, force)
+	if len(matching) == 0 {
+		fmt.Printf("  %s No unread mail about %s\n", style.Bold.Render("✓"), epicID)
+		return nil
+	}
+	fmt.Printf("\n  %s Unread mail about %s for %s:\n", style.Bold.Render("⚠"), epicID, address)
+	for _, msg := range matching {
+		fmt.Printf("    - %s from %s: %s\n", msg.ID, msg.From, msg.Subject)
+	}
+	fmt.Println()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %s Proceeding anyway (--force)\n", style.Dim.Render("⚠"))
+	return nil
+}
+
+// checkEpicMail returns the unread messages whose subject mentions epicID,
+// and an error if there are any and force is not set.
+func checkEpicMail(unread []*mail.Message, epicID string, force bool) ([]*mail.Message, error) {
+	var matching []*mail.Message
+	for _, msg := range unread {
+		if strings.Contains(strings.ToLower(msg.Subject), strings.ToLower(epicID)) {
+			matching = append(matching, msg)
+		}
+	}
+	if len(matching) > 0 && !force {
+		return matching, fmt.Errorf("cannot land: %d unread message(s) about %s (read them with 'gt mail inbox', or use --force)", len(matching), epicID)
+	}
+	return matching, nil
+}
+
+// getTagOnLandTemplate returns the tag_on_land template from rig settings.
+// Returns "" (tagging disabled) if unset.
+func getTagOnLandTemplate(rigPath string) string {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		return ""
+	}
+	if settings.MergeQueue != nil {
+		return settings.MergeQueue.TagOnLand
+	}
+	return ""
+}
+
+// resolveLandTagName expands a tag_on_land template for an epic.
+// Returns "" when template is empty. Tag names share git's branch name
+// restrictions, so the expanded name is checked with validateBranchName.
+func resolveLandTagName(template, epicID string) (string, error) {
+	if template == "" {
+		return "", nil
+	}
+	tagName := buildIntegrationBranchName(template, epicID)
+	if err := validateBranchName(tagName); err != nil {
+		return "", fmt.Errorf("invalid tag_on_land tag name: %w", err)
+	}
+	return tagName, nil
+}
+
+// landTagger creates and pushes tags. *git.Git satisfies this interface.
+type landTagger interface {
+	CreateTag(name, ref, message string) error
+	CreateSignedTag(name, ref, message, key string) error
+	PushTag(remote, name string) error
+}
+
+// tagLandedEpic creates an annotated tag at ref (the landed merge commit,
+// normally HEAD of the land worktree), signed if signing says so, and
+// pushes it to origin.
+func tagLandedEpic(g landTagger, tagName, ref, epicID, title string, signing landSigning) error {
+	message := fmt.Sprintf("Land epic %s: %s", epicID, title)
+	var err error
+	if signing.Sign {
+		err = signingFailure(g.CreateSignedTag(tagName, ref, message, signing.Key), "tag", signing.Key)
+	} else {
+		err = g.CreateTag(tagName, ref, message)
+	}
+	if err != nil {
+		return fmt.Errorf("creating tag %s: %w", tagName, err)
+	}
+	if err := g.PushTag("origin", tagName); err != nil {
+		return fmt.Errorf("pushing tag %s: %w", tagName, err)
+	}
+	return nil
+}
+
+// getTestTimeout returns the test command timeout from rig settings.
+// Returns 0 (no timeout) if unset.
+func getTestTimeout(rigPath string) time.Duration {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, err := config.LoadRigSettings(settingsPath)
+	if err != nil {
+		return 0
+	}
+	if settings.MergeQueue != nil && settings.MergeQueue.TestTimeoutSeconds > 0 {
+		return time.Duration(settings.MergeQueue.TestTimeoutSeconds) * time.Second
+	}
+	return 0
+}
+
+// allowedTestCommandsEnv lists permitted test command executables
+// (comma-separated). When set, it overrides the town settings allowlist.
+const allowedTestCommandsEnv = "GT_ALLOWED_TEST_COMMANDS"
+
+// errTestCommandNotAllowed is returned when a test command is rejected
+// by the configured allowlist.
+var errTestCommandNotAllowed = errors.New("security: test command not allowed")
+
+// getAllowedTestCommands returns the test command allowlist.
+// Priority: GT_ALLOWED_TEST_COMMANDS > town settings allowed_test_commands.
+// Returns nil when no allowlist is configured.
+func getAllowedTestCommands(townRoot string) []string {
+	if env := os.Getenv(allowedTestCommandsEnv); env != "" {
+		var allowed []string
+		for _, name := range strings.Split(env, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				allowed = append(allowed, name)
+			}
+		}
+		return allowed
+	}
+
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil
 	}
+	return settings.AllowedTestCommands
+}
 
-	// Get integration branch name from epic metadata (stored at create time)
-	// Fall back to default template for backward compatibility with old epics
-	branchName := getIntegrationBranchField(epic.Description)
-	if branchName == "" {
-		branchName = buildIntegrationBranchName(defaultIntegrationBranchTemplate, epicID)
+// checkTestCommandAllowed verifies testCmd against an allowlist of executables.
+// With no allowlist every command is allowed. Otherwise the first token must
+// match an entry exactly, and shell operators are refused since they would let
+// a command run anything after the allowed executable.
+func checkTestCommandAllowed(testCmd string, allowed []string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	fields := strings.Fields(testCmd)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if strings.ContainsAny(testCmd, ";&|`$<>\n") {
+		return fmt.Errorf("%w: %q contains shell operators, which are not permitted when an allowlist is configured",
+			errTestCommandNotAllowed, testCmd)
+	}
+	for _, name := range allowed {
+		if fields[0] == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q is not in the allowlist (%s); update %s or allowed_test_commands in town settings",
+		errTestCommandNotAllowed, fields[0], strings.Join(allowed, ", "), allowedTestCommandsEnv)
+}
+
+// errTestTimeout is returned by runTestCommand when the test command
+// exceeds its configured timeout.
+var errTestTimeout = errors.New("test command timed out")
+
+// shellRunner runs a shell script in dir, streaming its output, until it
+// exits or ctx is done. It is util.Runner's counterpart for long-running
+// commands whose output the user watches; tests substitute a fake.
+type shellRunner interface {
+	RunShell(ctx context.Context, dir, script string, stdout, stderr io.Writer) error
+}
+
+// execShellRunner is the shellRunner that runs scripts for real via
+// shellCommand, killing the whole process group when ctx is done.
+type execShellRunner struct{}
+
+// RunShell implements shellRunner.
+func (execShellRunner) RunShell(ctx context.Context, dir, script string, stdout, stderr io.Writer) error {
+	cmd := shellCommand(ctx, script)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+	return cmd.Run()
+}
+
+// runTestCommand executes a test command in the given directory.
+// The command runs through the shell so compound commands and pipes work.
+// A timeout of 0 means no timeout.
+func runTestCommand(workDir, testCmd string, timeout time.Duration) error {
+	return runTestCommandWith(execShellRunner{}, workDir, testCmd, timeout)
+}
+
+// runTestCommandWith is runTestCommand with the shell runner injected.
+func runTestCommandWith(r shellRunner, workDir, testCmd string, timeout time.Duration) error {
+	if strings.TrimSpace(testCmd) == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// Trust boundary: the test_command template comes from the rig's
+	// config.json (operator-controlled); expandTestCommand quotes the
+	// bead-derived values substituted into it.
+	err := r.RunShell(ctx, workDir, testCmd, os.Stdout, os.Stderr)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", errTestTimeout, timeout)
+	}
+	return err
+}
+
+// resetHard performs a git reset --hard to the given ref in workDir.
+// The git package has no Reset method, so this shells out directly.
+func resetHard(r util.Runner, workDir, ref string) error {
+	_, err := r.Run(workDir, "git", "reset", "--hard", ref)
+	return err
+}
+
+// integrationMRPageSize is the page size used when listing merge requests
+// for integration status.
+const integrationMRPageSize = 500
+
+// runMqIntegrationStatus shows the status of an integration branch for an epic.
+func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+	applyGitTimeout()
+
+	format, err := mqIntegrationStatusFormat.Resolve()
+	if err != nil {
+		return err
+	}
+
+	var sinceWindow time.Duration
+	if mqIntegrationStatusSince != "" {
+		d, err := parseDuration(mqIntegrationStatusSince)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid --since duration %q (use e.g. 24h or 7d)", mqIntegrationStatusSince)
+		}
+		sinceWindow = d
+	}
+
+	if mqIntegrationStatusQuiet {
+		switch {
+		case mqIntegrationStatusWatch:
+			return fmt.Errorf("--quiet and --watch cannot be used together")
+		case mqIntegrationStatusSelect != "":
+			return fmt.Errorf("--quiet and --select cannot be used together")
+		case format != output.FormatText:
+			return fmt.Errorf("--quiet cannot be used with --format %s", format)
+		}
+	}
+
+	if mqIntegrationStatusWatch {
+		if mqIntegrationStatusSelect != "" {
+			return fmt.Errorf("--watch and --select cannot be used together")
+		}
+		return runMqIntegrationStatusWatch(epicID, format, sinceWindow)
+	}
+
+	status, err := buildIntegrationStatus(epicID, sinceWindow)
+	if err != nil {
+		return err
+	}
+
+	// Formatted payload to file; human output still goes to stdout
+	if mqIntegrationStatusOutputFile != "" {
+		fileFormat := format
+		if fileFormat == output.FormatText {
+			fileFormat = output.FormatJSON
+		}
+		if err := output.WriteFile(mqIntegrationStatusOutputFile, status, fileFormat); err != nil {
+			return err
+		}
+		if format != output.FormatText && mqIntegrationStatusSelect == "" {
+			return nil
+		}
+	}
+
+	// Single field
+	if mqIntegrationStatusSelect != "" {
+		return output.PrintSelected(status, mqIntegrationStatusSelect, format)
+	}
+
+	// Structured output
+	if format != output.FormatText {
+		return output.PrintFormatted(status, format)
+	}
+
+	// Human-readable output
+	return renderIntegrationStatus(status, mqIntegrationStatusQuiet)
+}
+
+// renderIntegrationStatus prints the human-readable status. In quiet mode
+// nothing is printed and readiness is reported only through the exit code.
+func renderIntegrationStatus(status *IntegrationStatusOutput, quiet bool) error {
+	if !quiet {
+		return printIntegrationStatus(status)
+	}
+	if !status.ReadyToLand {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// buildIntegrationStatus gathers the integration status for an epic in the
+// current rig, listing merged MRs within sinceWindow (0 = all).
+func buildIntegrationStatus(epicID string, sinceWindow time.Duration) (*IntegrationStatusOutput, error) {
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize git for the rig
 	g, err := getRigGit(r.Path)
 	if err != nil {
-		return fmt.Errorf("initializing git: %w", err)
+		return nil, fmt.Errorf("initializing git: %w", err)
+	}
+
+	// Fetch from origin to ensure we have latest refs. Quiet mode skips
+	// the --no-fetch notice along with everything else.
+	if !mqIntegrationNoFetch || !mqIntegrationStatusQuiet {
+		_ = fetchIntegrationRefs(g, mqIntegrationNoFetch) // non-fatal: fall back to local refs
+	}
+
+	bd := beads.New(r.Path)
+	return computeIntegrationStatus(bd, bd, g, r.Path, epicID, sinceWindow)
+}
+
+// computeIntegrationStatus computes an epic's integration status in the rig
+// at rigPath, reading the epic through epics. Callers fetch refs first; the
+// per-epic status command and the town-wide dashboard share this.
+func computeIntegrationStatus(bd *beads.Beads, epics beads.IssueShower, g *git.Git, rigPath, epicID string, sinceWindow time.Duration) (*IntegrationStatusOutput, error) {
+	// Fetch epic to get stored branch name
+	epic, err := epics.Show(epicID)
+	if err != nil {
+		if err == beads.ErrNotFound {
+			return nil, fmt.Errorf("epic '%s' not found", epicID)
+		}
+		return nil, fmt.Errorf("fetching epic: %w", err)
 	}
 
-	// Fetch from origin to ensure we have latest refs
-	if err := g.Fetch("origin"); err != nil {
-		// Non-fatal, continue with local data
+	// Get integration branch name from epic metadata (stored at create time)
+	// Fall back to default template for backward compatibility with old epics
+	branchName := getIntegrationBranchField(epic.Description)
+	if branchName == "" {
+		branchName = buildIntegrationBranchName(defaultIntegrationBranchTemplate, epicID)
 	}
 
 	// Check if integration branch exists (locally or remotely)
 	localExists, _ := g.BranchExists(branchName)
-	remoteExists, _ := g.RemoteBranchExists("origin", branchName)
+	remoteExists, _ := integrationRemoteBranchExists(g, branchName, mqIntegrationNoFetch)
 
 	if !localExists && !remoteExists {
-		return fmt.Errorf("integration branch '%s' does not exist", branchName)
+		return nil, fmt.Errorf("integration branch '%s' does not exist", branchName)
 	}
 
 	// Determine which ref to use for comparison
@@ -642,26 +2010,29 @@ func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
 		aheadCount = 0 // Non-fatal
 	}
 
+	// Latest commit, for "who touched this last" context
+	var lastCommit *git.Commit
+	if commits, err := g.Log(ref, 1); err == nil && len(commits) > 0 {
+		lastCommit = &commits[0]
+	}
+
 	// Query for MRs targeting this integration branch (use resolved name)
 	targetBranch := branchName
 
-	// Get all merge-request issues
-	allMRs, err := bd.List(beads.ListOptions{
-		Type:   "merge-request",
-		Status: "", // all statuses
-	})
+	// Get all merge-request issues, paged to bound each bd invocation in large towns
+	allMRs, err := bd.ListPaged(beads.ListOptions{
+		Label:    "gt:merge-request",
+		Status:   "", // all statuses
+		Priority: -1, // No priority filter
+		SortBy:   "created",
+	}, integrationMRPageSize)
 	if err != nil {
-		return fmt.Errorf("querying merge requests: %w", err)
+		return nil, fmt.Errorf("querying merge requests: %w", err)
 	}
 
 	// Filter by target branch and separate into merged/pending
 	var mergedMRs, pendingMRs []*beads.Issue
-	for _, mr := range allMRs {
-		fields := beads.ParseMRFields(mr)
-		if fields == nil || fields.Target != targetBranch {
-			continue
-		}
-
+	for _, mr := range filterMRsByTarget(allMRs, targetBranch) {
 		if mr.Status == "closed" {
 			mergedMRs = append(mergedMRs, mr)
 		} else {
@@ -670,52 +2041,69 @@ func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check if auto-land is enabled in settings
-	settingsPath := filepath.Join(r.Path, "settings", "config.json")
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
 	settings, _ := config.LoadRigSettings(settingsPath) // Ignore error, use defaults
 	autoLandEnabled := false
 	if settings != nil && settings.MergeQueue != nil {
 		autoLandEnabled = settings.MergeQueue.IsIntegrationBranchAutoLandEnabled()
 	}
 
-	// Query children of the epic to determine if ready to land
-	// Use status "all" to include both open and closed children
-	// Use Priority -1 to disable priority filtering
-	children, err := bd.List(beads.ListOptions{
-		Parent:   epicID,
-		Status:   "all",
-		Priority: -1,
-	})
-	childrenTotal := 0
-	childrenClosed := 0
-	if err == nil {
-		for _, child := range children {
-			childrenTotal++
-			if child.Status == "closed" {
-				childrenClosed++
-			}
+	// Query children of the epic to determine if ready to land (non-fatal)
+	childrenTotal, childrenClosed, _, _, _ := bd.ChildrenStats(epicID)
+
+	// Open blocking dependencies also hold the land (non-fatal)
+	blockedBy, _ := openEpicBlockers(bd, epicID)
+
+	readyToLand := isReadyToLandWith(landCriteriaFromSettings(settings), aheadCount, childrenTotal, childrenClosed, len(pendingMRs), len(blockedBy))
+
+	// With --children, list the children still blocking the land (non-fatal)
+	var openChildren []IntegrationStatusMRSummary
+	if mqIntegrationStatusChildren {
+		if children, err := bd.Children(epicID); err == nil {
+			openChildren = openChildSummaries(children)
 		}
 	}
 
-	readyToLand := isReadyToLand(aheadCount, childrenTotal, childrenClosed, len(pendingMRs))
+	// Limit the listed merged MRs to the --since window; totals stay complete
+	mergedTotal := len(mergedMRs)
+	if sinceWindow > 0 {
+		mergedMRs = filterMergedSince(mergedMRs, time.Now().Add(-sinceWindow))
+	}
+
+	// Likewise --worker only narrows the listing, not readiness
+	pendingTotal := len(pendingMRs)
+	if mqIntegrationStatusWorker != "" {
+		mergedMRs = filterMRsByWorker(mergedMRs, mqIntegrationStatusWorker)
+		pendingMRs = filterMRsByWorker(pendingMRs, mqIntegrationStatusWorker)
+	}
 
 	// Build output structure
-	output := IntegrationStatusOutput{
+	status := IntegrationStatusOutput{
 		Epic:            epicID,
 		Branch:          branchName,
 		Created:         createdDate,
 		AheadOfMain:     aheadCount,
+		LastCommit:      lastCommit,
 		MergedMRs:       make([]IntegrationStatusMRSummary, 0, len(mergedMRs)),
+		MergedTotal:     mergedTotal,
+		MergedSince:     mqIntegrationStatusSince,
 		PendingMRs:      make([]IntegrationStatusMRSummary, 0, len(pendingMRs)),
+		PendingTotal:    pendingTotal,
+		Worker:          mqIntegrationStatusWorker,
 		ReadyToLand:     readyToLand,
 		AutoLandEnabled: autoLandEnabled,
 		ChildrenTotal:   childrenTotal,
 		ChildrenClosed:  childrenClosed,
+		OpenChildren:    openChildren,
+		BlockedBy:       blockedBy,
+		Priority:        epic.Priority,
+		EpicCreated:     epic.CreatedAt,
 	}
 
 	for _, mr := range mergedMRs {
 		// Extract the title without "Merge: " prefix for cleaner display
 		title := strings.TrimPrefix(mr.Title, "Merge: ")
-		output.MergedMRs = append(output.MergedMRs, IntegrationStatusMRSummary{
+		status.MergedMRs = append(status.MergedMRs, IntegrationStatusMRSummary{
 			ID:    mr.ID,
 			Title: title,
 		})
@@ -723,31 +2111,148 @@ func runMqIntegrationStatus(cmd *cobra.Command, args []string) error {
 
 	for _, mr := range pendingMRs {
 		title := strings.TrimPrefix(mr.Title, "Merge: ")
-		output.PendingMRs = append(output.PendingMRs, IntegrationStatusMRSummary{
-			ID:     mr.ID,
-			Title:  title,
-			Status: mr.Status,
+		status.PendingMRs = append(status.PendingMRs, IntegrationStatusMRSummary{
+			ID:        mr.ID,
+			Title:     title,
+			Status:    mr.Status,
+			CreatedAt: mr.CreatedAt,
 		})
 	}
 
-	// JSON output
-	if mqIntegrationStatusJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(output)
+	return &status, nil
+}
+
+// openChildSummaries returns the children that are not closed, in the order
+// given. Besides open and in_progress this keeps blocked or hooked children,
+// since any child that is not closed holds up the land.
+func openChildSummaries(children []*beads.Issue) []IntegrationStatusMRSummary {
+	open := make([]IntegrationStatusMRSummary, 0, len(children))
+	for _, child := range children {
+		if child.Status == "closed" {
+			continue
+		}
+		priority := child.Priority
+		open = append(open, IntegrationStatusMRSummary{
+			ID:        child.ID,
+			Title:     child.Title,
+			Status:    child.Status,
+			CreatedAt: child.CreatedAt,
+			Priority:  &priority,
+		})
 	}
+	return open
+}
 
-	// Human-readable output
-	return printIntegrationStatus(&output)
+// filterMergedSince returns the merged MRs closed at or after cutoff.
+// The close time falls back to the last update; MRs with no parseable
+// timestamp are kept rather than silently hidden.
+func filterMergedSince(mrs []*beads.Issue, cutoff time.Time) []*beads.Issue {
+	var recent []*beads.Issue
+	for _, mr := range mrs {
+		ts := parseBeadsTimestamp(mr.ClosedAt)
+		if ts.IsZero() {
+			ts = parseBeadsTimestamp(mr.UpdatedAt)
+		}
+		if ts.IsZero() || !ts.Before(cutoff) {
+			recent = append(recent, mr)
+		}
+	}
+	return recent
+}
+
+// sortMRSummariesOldestFirst orders MRs by creation time, oldest first.
+// The sort is stable, and MRs with no parseable timestamp keep their
+// relative order after the dated ones.
+func sortMRSummariesOldestFirst(mrs []IntegrationStatusMRSummary) {
+	sort.SliceStable(mrs, func(i, j int) bool {
+		ti := parseBeadsTimestamp(mrs[i].CreatedAt)
+		tj := parseBeadsTimestamp(mrs[j].CreatedAt)
+		if ti.IsZero() || tj.IsZero() {
+			return !ti.IsZero() && tj.IsZero()
+		}
+		return ti.Before(tj)
+	})
+}
+
+// landCriteria are the configurable parts of the ready-to-land check, from
+// merge_queue.land_requires_* in the rig settings.
+type landCriteria struct {
+	RequireAllChildrenClosed bool
+	RequireCommitsAhead      bool
+}
+
+// defaultLandCriteria requires every child closed and commits ahead.
+func defaultLandCriteria() landCriteria {
+	return landCriteria{RequireAllChildrenClosed: true, RequireCommitsAhead: true}
+}
+
+// getLandCriteria reads the rig's ready-to-land criteria, falling back to
+// the defaults when settings can't be loaded.
+func getLandCriteria(rigPath string) landCriteria {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, _ := config.LoadRigSettings(settingsPath)
+	return landCriteriaFromSettings(settings)
+}
+
+// landCriteriaFromSettings extracts the ready-to-land criteria from rig
+// settings. Nil settings give the defaults.
+func landCriteriaFromSettings(settings *config.RigSettings) landCriteria {
+	if settings == nil || settings.MergeQueue == nil {
+		return defaultLandCriteria()
+	}
+	return landCriteria{
+		RequireAllChildrenClosed: settings.MergeQueue.RequiresAllChildrenClosedToLand(),
+		RequireCommitsAhead:      settings.MergeQueue.RequiresCommitsAheadToLand(),
+	}
+}
+
+// isReadyToLand determines if an integration branch is ready to land under
+// the default criteria: has commits ahead of main, has children, all
+// children closed, no pending MRs, and no open blocking dependencies.
+func isReadyToLand(aheadCount, childrenTotal, childrenClosed, pendingMRCount, openBlockerCount int) bool {
+	return isReadyToLandWith(defaultLandCriteria(), aheadCount, childrenTotal, childrenClosed, pendingMRCount, openBlockerCount)
 }
 
-// isReadyToLand determines if an integration branch is ready to land.
-// Ready when: has commits ahead of main, has children, all children closed, no pending MRs.
-func isReadyToLand(aheadCount, childrenTotal, childrenClosed, pendingMRCount int) bool {
-	return aheadCount > 0 &&
-		childrenTotal > 0 &&
-		childrenTotal == childrenClosed &&
-		pendingMRCount == 0
+// isReadyToLandWith is isReadyToLand with configurable criteria. An epic
+// must always have children, no pending MRs and no open blockers; criteria
+// decide whether all children must be closed and whether commits ahead are
+// required.
+func isReadyToLandWith(criteria landCriteria, aheadCount, childrenTotal, childrenClosed, pendingMRCount, openBlockerCount int) bool {
+	if criteria.RequireCommitsAhead && aheadCount == 0 {
+		return false
+	}
+	if criteria.RequireAllChildrenClosed && childrenClosed < childrenTotal {
+		return false
+	}
+	return childrenTotal > 0 &&
+		pendingMRCount == 0 &&
+		openBlockerCount == 0
+}
+
+// epicDepReader is the subset of beads.Beads used to find an epic's blockers.
+type epicDepReader interface {
+	Dependencies(id string) (blockedBy, blocks []string, err error)
+	ShowMultiple(ids []string) (map[string]*beads.Issue, error)
+}
+
+// openEpicBlockers returns the issues epicID depends on that are not closed,
+// in dependency order. A blocker that can't be found is counted as open.
+func openEpicBlockers(bd epicDepReader, epicID string) ([]string, error) {
+	blockedBy, _, err := bd.Dependencies(epicID)
+	if err != nil || len(blockedBy) == 0 {
+		return nil, err
+	}
+	issues, err := bd.ShowMultiple(blockedBy)
+	if err != nil {
+		return nil, err
+	}
+	var open []string
+	for _, id := range blockedBy {
+		if issue, ok := issues[id]; !ok || issue.Status != "closed" {
+			open = append(open, id)
+		}
+	}
+	return open, nil
 }
 
 // printIntegrationStatus prints the integration status in human-readable format.
@@ -756,11 +2261,35 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 	if output.Created != "" {
 		fmt.Printf("Created: %s\n", output.Created)
 	}
-	fmt.Printf("Ahead of main: %d commits\n", output.AheadOfMain)
+	fmt.Printf("Priority: P%d\n", output.Priority)
+	if c := output.LastCommit; c != nil && output.AheadOfMain > 0 {
+		fmt.Printf("Ahead of main: %d commits, latest: '%s' by %s %s\n",
+			output.AheadOfMain, c.Subject, c.Author, formatAge(c.Date))
+	} else {
+		fmt.Printf("Ahead of main: %d commits\n", output.AheadOfMain)
+	}
 	fmt.Printf("Epic children: %d/%d closed\n", output.ChildrenClosed, output.ChildrenTotal)
+	for _, child := range output.OpenChildren {
+		info := child.Status
+		if child.Priority != nil {
+			info = fmt.Sprintf("P%d, %s", *child.Priority, child.Status)
+		}
+		fmt.Printf("  %-12s  %s%s\n", child.ID, child.Title, style.Dim.Render(" ("+info+")"))
+	}
 
 	// Merged MRs
-	fmt.Printf("\nMerged MRs (%d):\n", len(output.MergedMRs))
+	var mergedFilters []string
+	if output.MergedSince != "" {
+		mergedFilters = append(mergedFilters, "last "+output.MergedSince)
+	}
+	if output.Worker != "" {
+		mergedFilters = append(mergedFilters, "worker "+output.Worker)
+	}
+	if len(mergedFilters) > 0 {
+		fmt.Printf("\nMerged MRs (%d of %d, %s):\n", len(output.MergedMRs), output.MergedTotal, strings.Join(mergedFilters, ", "))
+	} else {
+		fmt.Printf("\nMerged MRs (%d):\n", len(output.MergedMRs))
+	}
 	if len(output.MergedMRs) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
 	} else {
@@ -769,8 +2298,13 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 		}
 	}
 
-	// Pending MRs
-	fmt.Printf("\nPending MRs (%d):\n", len(output.PendingMRs))
+	// Pending MRs, oldest first so the most stalled MR leads
+	sortMRSummariesOldestFirst(output.PendingMRs)
+	if output.Worker != "" {
+		fmt.Printf("\nPending MRs (%d of %d, worker %s):\n", len(output.PendingMRs), output.PendingTotal, output.Worker)
+	} else {
+		fmt.Printf("\nPending MRs (%d):\n", len(output.PendingMRs))
+	}
 	if len(output.PendingMRs) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
 	} else {
@@ -799,9 +2333,12 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 		} else if output.ChildrenClosed < output.ChildrenTotal {
 			fmt.Printf("%s Waiting for %d/%d children to close.\n",
 				style.Dim.Render("○"), output.ChildrenTotal-output.ChildrenClosed, output.ChildrenTotal)
-		} else if len(output.PendingMRs) > 0 {
+		} else if output.PendingTotal > 0 {
 			fmt.Printf("%s Waiting for %d pending MRs to merge.\n",
-				style.Dim.Render("○"), len(output.PendingMRs))
+				style.Dim.Render("○"), output.PendingTotal)
+		} else if len(output.BlockedBy) > 0 {
+			fmt.Printf("%s Waiting on dependencies: %s\n",
+				style.Dim.Render("○"), strings.Join(output.BlockedBy, ", "))
 		} else if output.AheadOfMain == 0 {
 			fmt.Printf("%s No commits ahead of main.\n", style.Dim.Render("○"))
 		}
@@ -815,3 +2352,232 @@ func printIntegrationStatus(output *IntegrationStatusOutput) error {
 
 	return nil
 }
+
+// IntegrationListEntry is one integration branch in `gt mq integration list` output.
+type IntegrationListEntry struct {
+	Epic           string `json:"epic"`
+	Branch         string `json:"branch"`
+	AheadOfMain    int    `json:"ahead_of_main"`
+	ChildrenTotal  int    `json:"children_total"`
+	ChildrenClosed int    `json:"children_closed"`
+	PendingMRs     int    `json:"pending_mrs"`
+	ReadyToLand    bool   `json:"ready_to_land"`
+}
+
+// templatePlaceholderRegex matches {name} placeholders in a branch template.
+var templatePlaceholderRegex = regexp.MustCompile(`\{[a-z]+\}`)
+
+// quotedPlaceholderRegex matches placeholders after regexp.QuoteMeta escaping.
+var quotedPlaceholderRegex = regexp.MustCompile(`\\\{[a-z]+\\\}`)
+
+// integrationBranchGlob converts a branch template into a git branch pattern
+// by replacing each placeholder with a wildcard (e.g., "integration/*").
+func integrationBranchGlob(template string) string {
+	return templatePlaceholderRegex.ReplaceAllString(template, "*")
+}
+
+// resolveIntegrationEpic maps an integration branch back to its epic ID.
+// Epic metadata (integration_branch: field) takes precedence; otherwise the
+// epic is extracted from the {epic} placeholder of the template.
+// Returns "" if the branch cannot be resolved.
+func resolveIntegrationEpic(branch, template string, branchEpics map[string]string) string {
+	if epicID, ok := branchEpics[branch]; ok {
+		return epicID
+	}
+	if !strings.Contains(template, "{epic}") {
+		return ""
+	}
+
+	pattern := regexp.QuoteMeta(template)
+	pattern = strings.Replace(pattern, regexp.QuoteMeta("{epic}"), `(?P<epic>[^/]+)`, 1)
+	pattern = quotedPlaceholderRegex.ReplaceAllString(pattern, `[^/]+`)
+	re, err := regexp.Compile("^" + pattern + "$")
+	if err != nil {
+		return ""
+	}
+	m := re.FindStringSubmatch(branch)
+	if m == nil {
+		return ""
+	}
+	return m[re.SubexpIndex("epic")]
+}
+
+// filterReadyIntegrations returns only the entries that are ready to land.
+func filterReadyIntegrations(entries []IntegrationListEntry) []IntegrationListEntry {
+	var ready []IntegrationListEntry
+	for _, e := range entries {
+		if e.ReadyToLand {
+			ready = append(ready, e)
+		}
+	}
+	return ready
+}
+
+// runMqIntegrationList lists all integration branches in the current rig.
+func runMqIntegrationList(cmd *cobra.Command, args []string) error {
+	applyGitTimeout()
+
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
+
+	bd := beads.New(r.Path)
+
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		return fmt.Errorf("initializing git: %w", err)
+	}
+
+	// Fetch from origin to ensure we have latest refs (non-fatal)
+	_ = fetchIntegrationRefs(g, mqIntegrationNoFetch)
+
+	found, err := findIntegrationBranches(bd, g, r.Path)
+	if err != nil {
+		return err
+	}
+
+	criteria := getLandCriteria(r.Path)
+	entries := make([]IntegrationListEntry, 0, len(found.Branches))
+	for _, ib := range found.Branches {
+		epicID, branch := ib.Epic, ib.Branch
+
+		ref := branch
+		if !ib.Local {
+			ref = "origin/" + branch
+		}
+		aheadCount, err := g.CommitsAhead("main", ref)
+		if err != nil {
+			aheadCount = 0 // Non-fatal
+		}
+
+		pendingMRs, err := findOpenMRsForIntegration(bd, branch)
+		if err != nil {
+			return fmt.Errorf("querying merge requests for %s: %w", branch, err)
+		}
+
+		childrenTotal, childrenClosed, _, _, _ := bd.ChildrenStats(epicID) // Non-fatal
+		blockedBy, _ := openEpicBlockers(bd, epicID)                       // Non-fatal
+		entries = append(entries, IntegrationListEntry{
+			Epic:           epicID,
+			Branch:         branch,
+			AheadOfMain:    aheadCount,
+			ChildrenTotal:  childrenTotal,
+			ChildrenClosed: childrenClosed,
+			PendingMRs:     len(pendingMRs),
+			ReadyToLand:    isReadyToLandWith(criteria, aheadCount, childrenTotal, childrenClosed, len(pendingMRs), len(blockedBy)),
+		})
+	}
+
+	if mqIntegrationListReadyOnly {
+		entries = filterReadyIntegrations(entries)
+	}
+
+	if mqIntegrationListJSON {
+		if entries == nil {
+			entries = []IntegrationListEntry{}
+		}
+		return output.PrintFormatted(entries, output.FormatJSON)
+	}
+
+	fmt.Printf("%s Integration branches for '%s':\n\n", style.Bold.Render("📋"), r.Name)
+	if len(entries) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+	} else {
+		table := style.NewTable(
+			style.Column{Name: "EPIC", Width: 14},
+			style.Column{Name: "BRANCH", Width: 32},
+			style.Column{Name: "AHEAD", Width: 6, Align: style.AlignRight},
+			style.Column{Name: "CHILDREN", Width: 9, Align: style.AlignRight},
+			style.Column{Name: "READY", Width: 6},
+		)
+		for _, e := range entries {
+			ready := style.Dim.Render("no")
+			if e.ReadyToLand {
+				ready = style.Success.Render("yes")
+			}
+			table.AddRow(
+				e.Epic,
+				e.Branch,
+				fmt.Sprintf("%d", e.AheadOfMain),
+				fmt.Sprintf("%d/%d", e.ChildrenClosed, e.ChildrenTotal),
+				ready,
+			)
+		}
+		fmt.Print(table.Render())
+	}
+
+	if found.Unresolved > 0 {
+		fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("(%d branch(es) matching %s have no known epic)", found.Unresolved, found.Glob)))
+	}
+	return nil
+}
+
+// integrationBranch is an integration branch resolved back to its epic.
+type integrationBranch struct {
+	Epic   string
+	Branch string
+	Local  bool // false = only on origin
+	Remote bool // false = only local
+}
+
+// integrationBranches is the result of findIntegrationBranches.
+type integrationBranches struct {
+	Branches   []integrationBranch // sorted by branch name
+	Unresolved int                 // branches matching Glob with no known epic
+	Glob       string
+}
+
+// findIntegrationBranches enumerates local and remote branches matching the
+// rig's integration_branch_template and maps each back to its epic via the
+// epic's integration_branch metadata, falling back to the {epic} placeholder.
+func findIntegrationBranches(bd *beads.Beads, g *git.Git, rigPath string) (integrationBranches, error) {
+	template := getIntegrationBranchTemplate(rigPath, "")
+	result := integrationBranches{Glob: integrationBranchGlob(template)}
+
+	localBranches, err := g.ListBranches(result.Glob)
+	if err != nil {
+		return result, fmt.Errorf("listing branches: %w", err)
+	}
+	remoteBranches, _ := g.ListRemoteBranches("origin", result.Glob) // Non-fatal
+
+	isLocal := make(map[string]bool, len(localBranches))
+	isRemote := make(map[string]bool, len(remoteBranches))
+	var branches []string
+	for _, b := range localBranches {
+		isLocal[b] = true
+		branches = append(branches, b)
+	}
+	for _, b := range remoteBranches {
+		isRemote[b] = true
+		if !isLocal[b] {
+			branches = append(branches, b)
+		}
+	}
+	sort.Strings(branches)
+
+	// Map stored integration branch names back to their epics
+	branchEpics := make(map[string]string)
+	epics, err := bd.List(beads.ListOptions{
+		Type:     "epic",
+		Status:   "all",
+		Priority: -1,
+	})
+	if err == nil {
+		for _, epic := range epics {
+			if name := getIntegrationBranchField(epic.Description); name != "" {
+				branchEpics[name] = epic.ID
+			}
+		}
+	}
+
+	for _, branch := range branches {
+		epicID := resolveIntegrationEpic(branch, template, branchEpics)
+		if epicID == "" {
+			result.Unresolved++
+			continue
+		}
+		result.Branches = append(result.Branches, integrationBranch{Epic: epicID, Branch: branch, Local: isLocal[branch], Remote: isRemote[branch]})
+	}
+	return result, nil
+}
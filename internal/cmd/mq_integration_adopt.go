@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"golang.org/x/term"
+)
+
+// mrAdopter rewrites and reparents an MR bead; *beads.Beads implements it.
+type mrAdopter interface {
+	mrUpdater
+	SetParent(id, parentID string) error
+}
+
+// adoptCandidate is an open MR that belongs under an epic but doesn't
+// target its integration branch.
+type adoptCandidate struct {
+	MR     *beads.Issue
+	Issue  string // Work item that ties the MR to the epic
+	Target string // Current target
+}
+
+// runMqIntegrationAdoptMRs retargets the open MRs for an epic's work onto
+// its integration branch and parents them under the epic.
+func runMqIntegrationAdoptMRs(cmd *cobra.Command, args []string) error {
+	epicID := args[0]
+
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
+
+	bd := beads.New(r.Path)
+	epic, err := bd.Show(epicID)
+	if err != nil {
+		if err == beads.ErrNotFound {
+			return fmt.Errorf("epic '%s' not found", epicID)
+		}
+		return fmt.Errorf("fetching epic: %w", err)
+	}
+	branchName := getIntegrationBranchField(epic.Description)
+	if branchName == "" {
+		branchName = buildIntegrationBranchName(defaultIntegrationBranchTemplate, epicID)
+	}
+
+	members, err := collectEpicDescendants(bd, epicID)
+	if err != nil {
+		return fmt.Errorf("listing %s children: %w", epicID, err)
+	}
+	mrs, err := bd.List(openMRListOptions)
+	if err != nil {
+		return fmt.Errorf("querying merge requests: %w", err)
+	}
+	candidates := matchMRsToEpic(mrs, epicID, members, branchName)
+
+	fmt.Printf("%s Open MRs for %s not targeting %s:\n\n", style.Bold.Render("🔀"), epicID, branchName)
+	if len(candidates) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		return nil
+	}
+	for _, c := range candidates {
+		fmt.Printf("  %s (%s): %s → %s\n", c.MR.ID, c.Issue, c.Target, branchName)
+	}
+
+	if mqIntegrationAdoptDryRun {
+		fmt.Printf("\n%s Dry run: %d MR(s) would be adopted by %s\n", style.Bold.Render("🔍"), len(candidates), epicID)
+		return nil
+	}
+	if !mqIntegrationAdoptYes {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("refusing to adopt MRs without confirmation: stdin is not a terminal\n" +
+				"  Re-run with --yes, or --dry-run to preview")
+		}
+		fmt.Println()
+		if !promptYesNo(fmt.Sprintf("Retarget %d MR(s) to %s and parent them under %s?", len(candidates), branchName, epicID)) {
+			return fmt.Errorf("adopt cancelled")
+		}
+	}
+
+	failed := adoptMRs(bd, candidates, epicID, branchName)
+	fmt.Println()
+	for _, c := range candidates {
+		if err, ok := failed[c.MR.ID]; ok {
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), c.MR.ID, err)
+			continue
+		}
+		fmt.Printf("  %s %s → %s\n", style.Success.Render("✓"), c.MR.ID, branchName)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d MR(s) could not be adopted", len(failed), len(candidates))
+	}
+	fmt.Printf("\n%s Adopted %d MR(s) into %s\n", style.Bold.Render("✓"), len(candidates), epicID)
+	return nil
+}
+
+// collectEpicDescendants returns the IDs of every bead under epicID, at
+// any depth.
+func collectEpicDescendants(bd *beads.Beads, epicID string) (map[string]bool, error) {
+	members := map[string]bool{}
+	queue := []string{epicID}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		children, err := bd.Children(parent)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if !members[child.ID] {
+				members[child.ID] = true
+				queue = append(queue, child.ID)
+			}
+		}
+	}
+	return members, nil
+}
+
+// matchMRsToEpic returns the open MRs that belong under epicID but don't
+// target branchName. An MR belongs to the epic when its work item (see
+// mrWorkItem) is the epic, one of members (the epic's descendants), or a
+// hierarchical child ID such as <epic>.1.
+func matchMRsToEpic(mrs []*beads.Issue, epicID string, members map[string]bool, branchName string) []adoptCandidate {
+	var candidates []adoptCandidate
+	for _, mr := range mrs {
+		if mr.Status == "closed" {
+			continue
+		}
+		fields := beads.ParseMRFields(mr)
+		if fields == nil || fields.Target == branchName {
+			continue
+		}
+		issue := mrWorkItem(fields)
+		if issue == "" {
+			continue
+		}
+		if issue == epicID || members[issue] || strings.HasPrefix(issue, epicID+".") {
+			candidates = append(candidates, adoptCandidate{MR: mr, Issue: issue, Target: fields.Target})
+		}
+	}
+	return candidates
+}
+
+// mrWorkItem returns the issue an MR delivers: its source_issue, or else
+// the issue ID parsed from its source branch name.
+func mrWorkItem(fields *beads.MRFields) string {
+	if fields.SourceIssue != "" || fields.Branch == "" {
+		return fields.SourceIssue
+	}
+	return parseBranchName(fields.Branch).Issue
+}
+
+// adoptMRs points each candidate at branchName and moves it under epicID.
+// Every MR is attempted; the returned map holds the errors by MR ID.
+func adoptMRs(a mrAdopter, candidates []adoptCandidate, epicID, branchName string) map[string]error {
+	failed := map[string]error{}
+	for _, c := range candidates {
+		desc := beads.SetDescriptionField(c.MR.Description, "target", branchName)
+		if err := a.Update(c.MR.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+			failed[c.MR.ID] = err
+			continue
+		}
+		if c.MR.Parent == epicID {
+			continue
+		}
+		if err := a.SetParent(c.MR.ID, epicID); err != nil {
+			failed[c.MR.ID] = fmt.Errorf("retargeted, but setting parent: %w", err)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestMatchMRsToEpic(t *testing.T) {
+	mr := func(id, status, desc string) *beads.Issue {
+		return &beads.Issue{ID: id, Status: status, Labels: []string{"gt:merge-request"}, Description: desc}
+	}
+	mrs := []*beads.Issue{
+		mr("gt-mr-child", "open", "branch: polecat/nux/gt-login\ntarget: main\nsource_issue: gt-login"),
+		mr("gt-mr-branch", "open", "branch: polecat/toast/gt-signup@mk123\ntarget: main"),
+		mr("gt-mr-sub", "open", "branch: feature/gt-auth.2-tokens\ntarget: main"),
+		mr("gt-mr-epic", "in_progress", "branch: polecat/nux/gt-auth\ntarget: main"),
+		mr("gt-mr-done", "open", "branch: polecat/nux/gt-login\ntarget: integration/gt-auth\nsource_issue: gt-login"),
+		mr("gt-mr-closed", "closed", "branch: polecat/nux/gt-login\ntarget: main\nsource_issue: gt-login"),
+		mr("gt-mr-other", "open", "branch: polecat/nux/gt-billing\ntarget: main"),
+		mr("gt-mr-modern", "open", "branch: polecat/nux-mk123\ntarget: main"),
+	}
+	members := map[string]bool{"gt-login": true, "gt-signup": true}
+
+	got := map[string]string{}
+	for _, c := range matchMRsToEpic(mrs, "gt-auth", members, "integration/gt-auth") {
+		got[c.MR.ID] = c.Issue
+		if c.Target != "main" {
+			t.Errorf("%s: Target = %q, want main", c.MR.ID, c.Target)
+		}
+	}
+	want := map[string]string{
+		"gt-mr-child":  "gt-login",  // source_issue is a child
+		"gt-mr-branch": "gt-signup", // issue parsed from the branch, @timestamp stripped
+		"gt-mr-sub":    "gt-auth.2", // hierarchical child ID
+		"gt-mr-epic":   "gt-auth",   // the epic itself
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("matchMRsToEpic() = %v, want %v", got, want)
+	}
+}
+
+// fakeMRAdopter records retargets and reparents, failing SetParent for
+// the IDs in failParent.
+type fakeMRAdopter struct {
+	fakeMRUpdater
+	parents    map[string]string
+	failParent map[string]bool
+}
+
+func (f *fakeMRAdopter) SetParent(id, parentID string) error {
+	if f.failParent[id] {
+		return errors.New("bd update failed")
+	}
+	f.parents[id] = parentID
+	return nil
+}
+
+func TestAdoptMRs(t *testing.T) {
+	candidates := []adoptCandidate{
+		{MR: &beads.Issue{ID: "gt-mr-1", Description: "branch: polecat/nux/gt-login\ntarget: main"}},
+		{MR: &beads.Issue{ID: "gt-mr-2", Parent: "gt-auth", Description: "target: main"}},
+		{MR: &beads.Issue{ID: "gt-mr-3", Description: "target: main"}},
+		{MR: &beads.Issue{ID: "gt-mr-4", Description: "target: main"}},
+	}
+	a := &fakeMRAdopter{
+		fakeMRUpdater: fakeMRUpdater{descriptions: map[string]string{}, fail: map[string]bool{"gt-mr-3": true}},
+		parents:       map[string]string{},
+		failParent:    map[string]bool{"gt-mr-4": true},
+	}
+
+	failed := adoptMRs(a, candidates, "gt-auth", "integration/gt-auth")
+
+	if got := a.descriptions["gt-mr-1"]; got != "branch: polecat/nux/gt-login\ntarget: integration/gt-auth" {
+		t.Errorf("gt-mr-1 description = %q", got)
+	}
+	if want := map[string]string{"gt-mr-1": "gt-auth"}; !reflect.DeepEqual(a.parents, want) {
+		t.Errorf("parents = %v, want %v (gt-mr-2 already under the epic)", a.parents, want)
+	}
+	if len(failed) != 2 || failed["gt-mr-3"] == nil || failed["gt-mr-4"] == nil {
+		t.Errorf("failed = %v, want gt-mr-3 (retarget) and gt-mr-4 (parent)", failed)
+	}
+	if _, ok := a.parents["gt-mr-3"]; ok {
+		t.Error("gt-mr-3 should not be reparented after its retarget failed")
+	}
+}
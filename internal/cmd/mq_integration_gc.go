@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// gcCandidate is an integration branch that gt mq integration gc may delete.
+type gcCandidate struct {
+	integrationBranch
+	Target string // Branch the epic lands into
+}
+
+// runMqIntegrationGC deletes integration branches whose epics have landed.
+func runMqIntegrationGC(cmd *cobra.Command, args []string) error {
+	applyGitTimeout()
+
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
+
+	bd := beads.New(r.Path)
+	g, err := getRigGit(r.Path)
+	if err != nil {
+		return fmt.Errorf("initializing git: %w", err)
+	}
+
+	// Ancestry is checked against origin, so refs must be current
+	if err := fetchIntegrationRefs(g, mqIntegrationNoFetch); err != nil {
+		return fmt.Errorf("fetching from origin: %w", err)
+	}
+
+	found, err := findIntegrationBranches(bd, g, r.Path)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(found.Branches))
+	for _, ib := range found.Branches {
+		ids = append(ids, ib.Epic)
+	}
+	epics, err := bd.ShowMultiple(ids)
+	if err != nil {
+		return fmt.Errorf("fetching epics: %w", err)
+	}
+
+	checker := integrationBranchChecker{g: g, noFetch: mqIntegrationNoFetch}
+	targetFor := func(epic *beads.Issue) (string, error) {
+		return resolveLandTarget(bd, checker, epic)
+	}
+	candidates := selectGCBranches(found.Branches, epics, targetFor, g.IsAncestor)
+
+	fmt.Printf("%s Landed integration branches in '%s':\n\n", style.Bold.Render("🧹"), r.Name)
+	if len(candidates) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(nothing to prune)"))
+		return nil
+	}
+
+	failed := 0
+	for _, c := range candidates {
+		if mqIntegrationGCDryRun {
+			fmt.Printf("  %s (epic %s, merged into %s)\n", c.Branch, c.Epic, c.Target)
+			continue
+		}
+		if err := deleteGCBranch(g, c); err != nil {
+			failed++
+			fmt.Printf("  %s %s (epic %s): %v\n", style.Error.Render("✗"), c.Branch, c.Epic, err)
+			continue
+		}
+		fmt.Printf("  %s %s (epic %s): deleted\n", style.Success.Render("✓"), c.Branch, c.Epic)
+	}
+
+	if mqIntegrationGCDryRun {
+		fmt.Printf("\n%s Dry run: %d branch(es) would be deleted\n", style.Bold.Render("🔍"), len(candidates))
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d branch(es) could not be deleted", failed, len(candidates))
+	}
+	fmt.Printf("\n%s Deleted %d branch(es)\n", style.Bold.Render("✓"), len(candidates))
+	return nil
+}
+
+// selectGCBranches returns the branches that are safe to delete: the epic is
+// closed and every copy of the branch (local and on origin) is an ancestor of
+// origin/<target>. Branches whose epic is unknown or whose target can't be
+// resolved are kept.
+func selectGCBranches(branches []integrationBranch, epics map[string]*beads.Issue, targetFor func(*beads.Issue) (string, error), isAncestor func(ancestor, descendant string) (bool, error)) []gcCandidate {
+	var selected []gcCandidate
+	for _, ib := range branches {
+		epic, ok := epics[ib.Epic]
+		if !ok || epic.Status != "closed" {
+			continue
+		}
+		target, err := targetFor(epic)
+		if err != nil || target == "" || target == ib.Branch {
+			continue
+		}
+		if !branchMergedInto(ib, target, isAncestor) {
+			continue
+		}
+		selected = append(selected, gcCandidate{integrationBranch: ib, Target: target})
+	}
+	return selected
+}
+
+// branchMergedInto reports whether the local and remote tips of ib (where
+// they exist) are all contained in origin/<target>.
+func branchMergedInto(ib integrationBranch, target string, isAncestor func(ancestor, descendant string) (bool, error)) bool {
+	var tips []string
+	if ib.Local {
+		tips = append(tips, ib.Branch)
+	}
+	if ib.Remote {
+		tips = append(tips, "origin/"+ib.Branch)
+	}
+	for _, tip := range tips {
+		merged, err := isAncestor(tip, "origin/"+target)
+		if err != nil || !merged {
+			return false
+		}
+	}
+	return len(tips) > 0
+}
+
+// deleteGCBranch deletes c from origin and locally, wherever it exists.
+func deleteGCBranch(g landBranchDeleter, c gcCandidate) error {
+	var errs []string
+	if c.Remote {
+		if err := g.DeleteRemoteBranch("origin", c.Branch); err != nil {
+			errs = append(errs, fmt.Sprintf("deleting from origin: %v", err))
+		}
+	}
+	if c.Local {
+		if err := g.DeleteBranch(c.Branch, true); err != nil {
+			errs = append(errs, fmt.Sprintf("deleting locally: %v", err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestSelectGCBranches(t *testing.T) {
+	branches := []integrationBranch{
+		{Epic: "gt-landed", Branch: "integration/gt-landed", Local: true, Remote: true},
+		{Epic: "gt-open", Branch: "integration/gt-open", Remote: true},
+		{Epic: "gt-unmerged", Branch: "integration/gt-unmerged", Remote: true},
+		{Epic: "gt-stale-local", Branch: "integration/gt-stale-local", Local: true, Remote: true},
+		{Epic: "gt-child", Branch: "integration/gt-child", Remote: true},
+		{Epic: "gt-unknown", Branch: "integration/gt-unknown", Remote: true},
+		{Epic: "gt-notarget", Branch: "integration/gt-notarget", Remote: true},
+	}
+	epics := map[string]*beads.Issue{
+		"gt-landed":      {ID: "gt-landed", Status: "closed"},
+		"gt-open":        {ID: "gt-open", Status: "open"},
+		"gt-unmerged":    {ID: "gt-unmerged", Status: "closed"},
+		"gt-stale-local": {ID: "gt-stale-local", Status: "closed"},
+		"gt-child":       {ID: "gt-child", Status: "closed", Parent: "gt-parent"},
+		"gt-notarget":    {ID: "gt-notarget", Status: "closed"},
+	}
+	targetFor := func(epic *beads.Issue) (string, error) {
+		switch {
+		case epic.ID == "gt-notarget":
+			return "", errors.New("parent lookup failed")
+		case epic.Parent != "":
+			return "integration/gt-parent", nil
+		}
+		return "main", nil
+	}
+	// Tips contained in their target; everything else is unmerged.
+	merged := map[string]bool{
+		"integration/gt-landed -> origin/main":                        true,
+		"origin/integration/gt-landed -> origin/main":                 true,
+		"origin/integration/gt-open -> origin/main":                   true,
+		"origin/integration/gt-stale-local -> origin/main":            true,
+		"origin/integration/gt-child -> origin/integration/gt-parent": true,
+		"origin/integration/gt-unknown -> origin/main":                true,
+		"origin/integration/gt-notarget -> origin/main":               true,
+	}
+	isAncestor := func(ancestor, descendant string) (bool, error) {
+		return merged[ancestor+" -> "+descendant], nil
+	}
+
+	got := selectGCBranches(branches, epics, targetFor, isAncestor)
+	want := []gcCandidate{
+		{integrationBranch: branches[0], Target: "main"},
+		{integrationBranch: branches[4], Target: "integration/gt-parent"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("selectGCBranches() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBranchMergedInto_AncestorError(t *testing.T) {
+	ib := integrationBranch{Epic: "gt-1", Branch: "integration/gt-1", Remote: true}
+	isAncestor := func(ancestor, descendant string) (bool, error) {
+		return false, errors.New("unknown revision")
+	}
+	if branchMergedInto(ib, "main", isAncestor) {
+		t.Error("branchMergedInto() = true when ancestry can't be checked, want false")
+	}
+}
+
+// fakeBranchDeleter records deletions and fails the ones named in fail.
+type fakeBranchDeleter struct {
+	deleted []string
+	fail    map[string]bool
+}
+
+func (f *fakeBranchDeleter) DeleteRemoteBranch(remote, branch string) error {
+	return f.record(remote + "/" + branch)
+}
+
+func (f *fakeBranchDeleter) DeleteBranch(name string, force bool) error {
+	return f.record(name)
+}
+
+func (f *fakeBranchDeleter) record(ref string) error {
+	if f.fail[ref] {
+		return errors.New("refusing")
+	}
+	f.deleted = append(f.deleted, ref)
+	return nil
+}
+
+func TestDeleteGCBranch(t *testing.T) {
+	remoteOnly := gcCandidate{integrationBranch: integrationBranch{Branch: "integration/a", Remote: true}}
+	d := &fakeBranchDeleter{}
+	if err := deleteGCBranch(d, remoteOnly); err != nil {
+		t.Fatalf("deleteGCBranch() error = %v", err)
+	}
+	if want := []string{"origin/integration/a"}; !reflect.DeepEqual(d.deleted, want) {
+		t.Errorf("deleted = %v, want %v", d.deleted, want)
+	}
+
+	both := gcCandidate{integrationBranch: integrationBranch{Branch: "integration/b", Local: true, Remote: true}}
+	d = &fakeBranchDeleter{fail: map[string]bool{"origin/integration/b": true}}
+	err := deleteGCBranch(d, both)
+	if err == nil || !strings.Contains(err.Error(), "deleting from origin") {
+		t.Errorf("deleteGCBranch() error = %v, want remote delete failure", err)
+	}
+	if want := []string{"integration/b"}; !reflect.DeepEqual(d.deleted, want) {
+		t.Errorf("local branch should still be deleted after a remote failure: deleted = %v", d.deleted)
+	}
+}
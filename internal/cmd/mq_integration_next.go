@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/output"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// LandCandidate is a ready-to-land epic and the rig it belongs to.
+type LandCandidate struct {
+	Rig         string `json:"rig"`
+	Epic        string `json:"epic"`
+	Branch      string `json:"branch"`
+	Priority    int    `json:"priority"`
+	EpicCreated string `json:"epic_created,omitempty"`
+}
+
+// runMqIntegrationNext prints the ready epic that should land first.
+func runMqIntegrationNext(cmd *cobra.Command, args []string) error {
+	applyGitTimeout()
+
+	format, err := mqIntegrationNextFormat.Resolve()
+	if err != nil {
+		return err
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	rigs, err := discoverTownRigs(townRoot)
+	if err != nil {
+		return err
+	}
+	rigs, err = filterRigsByName(rigs, mqIntegrationNextRig)
+	if err != nil {
+		return err
+	}
+
+	next := selectNextLand(collectTownIntegrationStatus(rigs, runtime.NumCPU()))
+
+	if mqIntegrationNextQuiet {
+		if next != nil {
+			fmt.Println(next.Epic)
+		}
+		return nil
+	}
+	if format != output.FormatText {
+		return output.PrintFormatted(next, format)
+	}
+
+	if next == nil {
+		fmt.Printf("%s No epics ready to land\n", style.Dim.Render("ℹ"))
+		return nil
+	}
+	fmt.Printf("%s Next epic to land:\n\n", style.Bold.Render("🎯"))
+	fmt.Printf("  Epic:     %s\n", next.Epic)
+	fmt.Printf("  Rig:      %s\n", next.Rig)
+	fmt.Printf("  Branch:   %s\n", next.Branch)
+	fmt.Printf("  Priority: P%d\n", next.Priority)
+	if next.EpicCreated != "" {
+		fmt.Printf("  Age:      %s\n", formatMRAge(next.EpicCreated))
+	}
+	return nil
+}
+
+// filterRigsByName narrows rigs to the one named name. An empty name keeps
+// them all.
+func filterRigsByName(rigs []*rig.Rig, name string) ([]*rig.Rig, error) {
+	if name == "" {
+		return rigs, nil
+	}
+	for _, r := range rigs {
+		if r.Name == name {
+			return []*rig.Rig{r}, nil
+		}
+	}
+	return nil, fmt.Errorf("rig '%s' not found", name)
+}
+
+// selectNextLand picks the ready epic to land first: highest priority
+// (lowest number), then oldest epic, then epic ID so the choice is stable.
+// Returns nil when nothing is ready.
+func selectNextLand(rigs []MQDashboardRig) *LandCandidate {
+	var ready []LandCandidate
+	for _, r := range rigs {
+		for _, e := range r.Epics {
+			if !e.ReadyToLand {
+				continue
+			}
+			ready = append(ready, LandCandidate{
+				Rig:         r.Rig,
+				Epic:        e.Epic,
+				Branch:      e.Branch,
+				Priority:    e.Priority,
+				EpicCreated: e.EpicCreated,
+			})
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	sort.SliceStable(ready, func(i, j int) bool { return landCandidateLess(ready[i], ready[j]) })
+	return &ready[0]
+}
+
+// landCandidateLess orders candidates for selectNextLand. Epics whose
+// creation time can't be parsed sort after those that can.
+func landCandidateLess(a, b LandCandidate) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	ta, errA := time.Parse(time.RFC3339, a.EpicCreated)
+	tb, errB := time.Parse(time.RFC3339, b.EpicCreated)
+	switch {
+	case errA == nil && errB == nil && !ta.Equal(tb):
+		return ta.Before(tb)
+	case errA == nil && errB != nil:
+		return true
+	case errA != nil && errB == nil:
+		return false
+	}
+	return a.Epic < b.Epic
+}
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/util"
+	"golang.org/x/term"
+)
+
+// minMergeCommitPrefix is the shortest recorded merge_commit that is matched
+// against integration branch history. Shorter values are too ambiguous.
+const minMergeCommitPrefix = 7
+
+// partialLandPlan is what 'gt mq integration land --up-to' will land.
+type partialLandPlan struct {
+	UpTo      string   // MR whose merge commit bounds the land
+	Commit    string   // Full SHA of that merge commit on the integration branch
+	Included  []string // Merged MRs landed by this plan, in merge order
+	Excluded  []string // Merged MRs left on the integration branch, in merge order
+	Unplaced  []string // MRs whose merge commit isn't on the unlanded history (e.g., landed earlier)
+	Remaining int      // Integration branch commits left after Commit
+}
+
+// getPartialLandEnabled reports whether merge_queue.partial_land is set for the rig.
+func getPartialLandEnabled(rigPath string) bool {
+	settingsPath := filepath.Join(rigPath, "settings", "config.json")
+	settings, err := config.LoadRigSettings(settingsPath)
+	if err != nil || settings.MergeQueue == nil {
+		return false
+	}
+	return settings.MergeQueue.IsPartialLandEnabled()
+}
+
+// findMergedMRsForIntegration returns the closed merge requests that targeted
+// an integration branch.
+func findMergedMRsForIntegration(bd *beads.Beads, branchName string) ([]*beads.Issue, error) {
+	mrs, err := bd.List(beads.ListOptions{
+		Label:    "gt:merge-request",
+		Status:   "closed",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterMRsByTarget(mrs, branchName), nil
+}
+
+// selectPartialLand locates upToID's merge commit in firstParent, the
+// integration branch's unlanded first-parent history (oldest first), and
+// splits the branch's other closed MRs into those merged at or before it
+// and those merged after. Everything up to that commit lands, including
+// commits pushed to the branch directly.
+func selectPartialLand(upToID string, mrs []*beads.Issue, firstParent []string) (*partialLandPlan, error) {
+	var upTo *beads.Issue
+	for _, mr := range mrs {
+		if mr.ID == upToID {
+			upTo = mr
+			break
+		}
+	}
+	if upTo == nil {
+		return nil, fmt.Errorf("'%s' is not a merged MR targeting this integration branch", upToID)
+	}
+	fields := beads.ParseMRFields(upTo)
+	if fields == nil || fields.MergeCommit == "" {
+		return nil, fmt.Errorf("MR %s has no merge_commit recorded; can't locate it on the integration branch", upToID)
+	}
+	upToIdx := indexOfCommit(firstParent, fields.MergeCommit)
+	if upToIdx < 0 {
+		return nil, fmt.Errorf("merge commit %s of MR %s is not on the integration branch's unlanded history (already landed?)", fields.MergeCommit, upToID)
+	}
+
+	plan := &partialLandPlan{
+		UpTo:      upToID,
+		Commit:    firstParent[upToIdx],
+		Remaining: len(firstParent) - upToIdx - 1,
+	}
+
+	type placedMR struct {
+		id  string
+		idx int
+	}
+	var placed []placedMR
+	for _, mr := range mrs {
+		f := beads.ParseMRFields(mr)
+		if f == nil || f.MergeCommit == "" {
+			continue // Closed without merging (rejected, superseded)
+		}
+		idx := indexOfCommit(firstParent, f.MergeCommit)
+		if idx < 0 {
+			plan.Unplaced = append(plan.Unplaced, mr.ID)
+			continue
+		}
+		placed = append(placed, placedMR{id: mr.ID, idx: idx})
+	}
+	// Merge order is the position on the branch
+	sort.SliceStable(placed, func(i, j int) bool { return placed[i].idx < placed[j].idx })
+	for _, p := range placed {
+		if p.idx <= upToIdx {
+			plan.Included = append(plan.Included, p.id)
+		} else {
+			plan.Excluded = append(plan.Excluded, p.id)
+		}
+	}
+	return plan, nil
+}
+
+// indexOfCommit returns the position of sha in commits, accepting an
+// abbreviated sha of at least minMergeCommitPrefix characters. Returns -1
+// when it is absent or too short to match safely.
+func indexOfCommit(commits []string, sha string) int {
+	sha = strings.ToLower(strings.TrimSpace(sha))
+	if len(sha) < minMergeCommitPrefix {
+		return -1
+	}
+	for i, c := range commits {
+		if strings.HasPrefix(c, sha) {
+			return i
+		}
+	}
+	return -1
+}
+
+// partialLandPlanSteps lists what a partial land will do.
+func partialLandPlanSteps(plan *partialLandPlan, branchName, targetBranch string, skipTests bool) []string {
+	steps := []string{fmt.Sprintf("Merge %s up to %s (%s) to %s (--no-ff)", branchName, plan.UpTo, shortSHA(plan.Commit), targetBranch)}
+	if !skipTests {
+		steps = append(steps, fmt.Sprintf("Run tests on %s", targetBranch))
+	}
+	return append(steps,
+		fmt.Sprintf("Push %s to origin", targetBranch),
+		"Leave the integration branch and epic open")
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// runPartialLand lands an integration branch up through the merge commit of
+// the --up-to MR. The branch and epic stay open, so the rest lands later
+// with a normal land.
+func runPartialLand(bd *beads.Beads, g *git.Git, r *rig.Rig, townRoot string, epic *beads.Issue, branchName, targetBranch string, skipTests bool, signing landSigning) (err error) {
+	epicID := epic.ID
+	upToID := mqIntegrationLandUpTo
+
+	// History is read from origin, so refs must be current
+	if err := fetchIntegrationRefs(g, mqIntegrationNoFetch); err != nil {
+		return fmt.Errorf("fetching from origin: %w", err)
+	}
+
+	fmt.Printf("Locating %s on %s...\n", upToID, branchName)
+	mrs, err := findMergedMRsForIntegration(bd, branchName)
+	if err != nil {
+		return fmt.Errorf("querying merged MRs: %w", err)
+	}
+	firstParent, err := g.FirstParentCommits("origin/"+targetBranch, "origin/"+branchName)
+	if err != nil {
+		return fmt.Errorf("reading %s history: %w", branchName, err)
+	}
+	plan, err := selectPartialLand(upToID, mrs, firstParent)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %s Merge commit %s\n", style.Bold.Render("✓"), shortSHA(plan.Commit))
+	fmt.Printf("  Landing %d MR(s): %s\n", len(plan.Included), strings.Join(plan.Included, ", "))
+	if len(plan.Excluded) > 0 {
+		fmt.Printf("  Leaving %d MR(s) on %s: %s\n", len(plan.Excluded), branchName, strings.Join(plan.Excluded, ", "))
+	}
+	if len(plan.Unplaced) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(already landed or not on %s: %s)", branchName, strings.Join(plan.Unplaced, ", "))))
+	}
+	if plan.Remaining > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(%d commit(s) stay on %s)", plan.Remaining, branchName)))
+	}
+
+	if getCheckMailBeforeLand(r.Path) {
+		fmt.Printf("Checking mail about %s...\n", epicID)
+		if err := checkLandMail(epicID, mqIntegrationLandForce); err != nil {
+			return err
+		}
+	}
+
+	testCmd := expandTestCommand(getTestCommand(r.Path), townRoot, r.Name, epicID, branchName)
+	if !skipTests {
+		if err := checkTestCommandAllowed(testCmd, getAllowedTestCommands(townRoot)); err != nil {
+			return err
+		}
+	}
+
+	steps := partialLandPlanSteps(plan, branchName, targetBranch, skipTests)
+	if mqIntegrationLandDryRun {
+		fmt.Printf("\n%s Dry run complete. Would perform:\n", style.Bold.Render("🔍"))
+		printLandPlan(steps)
+		return nil
+	}
+	if !mqIntegrationLandYes {
+		fmt.Printf("\n%s About to partially land %s up to %s:\n", style.Bold.Render("⚠"), epicID, upToID)
+		printLandPlan(steps)
+		fmt.Println()
+	}
+	if err := confirmLand(mqIntegrationLandYes, term.IsTerminal(int(os.Stdin.Fd())), promptYesNo); err != nil {
+		return err
+	}
+
+	reason := "up to " + upToID
+	emitLandEvent(events.TypeLandStarted, epicID, branchName, targetBranch, reason)
+	defer func() {
+		if err != nil {
+			emitLandEvent(events.TypeLandFailed, epicID, branchName, targetBranch, err.Error())
+		}
+	}()
+
+	fmt.Printf("Creating temporary worktree for merge...\n")
+	landGit, cleanup, err := createLandWorktree(r.Path, targetBranch)
+	if err != nil {
+		return fmt.Errorf("creating land worktree: %w", err)
+	}
+	defer cleanup()
+
+	if current, err := landGit.CurrentBranch(); err != nil {
+		return fmt.Errorf("checking land worktree branch: %w", err)
+	} else if current != targetBranch {
+		return fmt.Errorf("land worktree is on '%s', expected '%s'; refusing to merge", current, targetBranch)
+	}
+	if !mqIntegrationNoFetch {
+		if err := landGit.Pull("origin", targetBranch); err != nil {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(pull from origin/%s skipped)", targetBranch)))
+		}
+	}
+	if mqIntegrationLandStrict {
+		if err := checkLandBase(landGit, targetBranch); err != nil {
+			return err
+		}
+	}
+
+	var changes []git.FileChange
+	build := func() error {
+		fmt.Printf("Merging %s up to %s into %s...\n", branchName, upToID, targetBranch)
+		mergeMsg := fmt.Sprintf("Merge %s up to %s: %s\n\nEpic: %s\nPartial land: %s", branchName, upToID, epic.Title, epicID, strings.Join(plan.Included, ", "))
+		if err := landMerge(landGit, plan.Commit, mergeMsg, signing); err != nil {
+			_ = landGit.AbortMerge()
+			return fmt.Errorf("merge failed: %w", err)
+		}
+		fmt.Printf("  %s Merged successfully\n", style.Bold.Render("✓"))
+		emitLandEvent(events.TypeLandMerged, epicID, branchName, targetBranch, reason)
+
+		if ran, err := runLandTests(runTestCommand, landGit.WorkDir(), testCmd, getTestTimeout(r.Path), skipTests); err != nil {
+			return err
+		} else if ran {
+			emitLandEvent(events.TypeLandTestsPassed, epicID, branchName, targetBranch, reason)
+		}
+
+		var err error
+		changes, err = verifyLandMergeChanges(landGit, branchName, targetBranch)
+		return err
+	}
+	if err := build(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Pushing %s to origin...\n", targetBranch)
+	rebuild := rebuildLand(landGit, util.ExecRunner{}, targetBranch, mqIntegrationLandStrict, build)
+	if err := pushLandWithRetry(landGit, targetBranch, getPushRetries(r.Path), time.Sleep, rebuild); err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+	fmt.Printf("  %s Pushed to origin\n", style.Bold.Render("✓"))
+	emitLandEvent(events.TypeLandPushed, epicID, branchName, targetBranch, reason)
+	if err := verifyLandContains(landGit, targetBranch, branchName, plan.Commit); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s Partially landed integration branch\n", style.Bold.Render("✓"))
+	fmt.Printf("  Epic:    %s (still open)\n", epicID)
+	fmt.Printf("  Branch:  %s → %s up to %s\n", branchName, targetBranch, upToID)
+	if changes != nil {
+		fmt.Printf("  Changes: %s\n", formatDiffSummary(changes))
+	}
+	return nil
+}
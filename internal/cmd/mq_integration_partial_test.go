@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// mergedMR builds a closed MR into integration/gt-epic with the given merge commit.
+func mergedMR(id, mergeCommit string) *beads.Issue {
+	desc := "branch: polecat/nux/" + id + "\ntarget: integration/gt-epic"
+	if mergeCommit != "" {
+		desc += "\nmerge_commit: " + mergeCommit
+	}
+	return &beads.Issue{ID: id, Status: "closed", Description: desc, Labels: []string{"gt:merge-request"}}
+}
+
+func TestSelectPartialLand(t *testing.T) {
+	// Unlanded first-parent history of the integration branch, oldest first.
+	// c3 is a direct push between MR merges.
+	history := []string{
+		"aaaaaaa1111111111111111111111111111111111",
+		"bbbbbbb2222222222222222222222222222222222",
+		"ccccccc3333333333333333333333333333333333",
+		"ddddddd4444444444444444444444444444444444",
+	}
+	mrs := []*beads.Issue{
+		mergedMR("gt-mr-late", "ddddddd4444444444444444444444444444444444"),
+		mergedMR("gt-mr-first", "aaaaaaa1"),
+		mergedMR("gt-mr-second", "BBBBBBB2222"),
+		mergedMR("gt-mr-landed", "9999999999"),
+		mergedMR("gt-mr-rejected", ""),
+	}
+
+	plan, err := selectPartialLand("gt-mr-second", mrs, history)
+	if err != nil {
+		t.Fatalf("selectPartialLand() error = %v", err)
+	}
+	want := &partialLandPlan{
+		UpTo:      "gt-mr-second",
+		Commit:    history[1],
+		Included:  []string{"gt-mr-first", "gt-mr-second"},
+		Excluded:  []string{"gt-mr-late"},
+		Unplaced:  []string{"gt-mr-landed"},
+		Remaining: 2,
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("selectPartialLand() = %+v\nwant %+v", plan, want)
+	}
+
+	// Up to the last MR lands everything on the branch
+	plan, err = selectPartialLand("gt-mr-late", mrs, history)
+	if err != nil {
+		t.Fatalf("selectPartialLand(late) error = %v", err)
+	}
+	if plan.Remaining != 0 || len(plan.Excluded) != 0 || len(plan.Included) != 3 {
+		t.Errorf("selectPartialLand(late) = %+v, want all three MRs and nothing remaining", plan)
+	}
+}
+
+func TestSelectPartialLand_Errors(t *testing.T) {
+	history := []string{"aaaaaaa1111111111111111111111111111111111"}
+	mrs := []*beads.Issue{
+		mergedMR("gt-mr-ok", "aaaaaaa1"),
+		mergedMR("gt-mr-nocommit", ""),
+		mergedMR("gt-mr-gone", "fffffff9"),
+		mergedMR("gt-mr-short", "aaaa"),
+	}
+	tests := []struct {
+		upTo    string
+		wantErr string
+	}{
+		{"gt-mr-unknown", "not a merged MR targeting this integration branch"},
+		{"gt-mr-nocommit", "no merge_commit recorded"},
+		{"gt-mr-gone", "already landed?"},
+		{"gt-mr-short", "already landed?"},
+	}
+	for _, tt := range tests {
+		_, err := selectPartialLand(tt.upTo, mrs, history)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("selectPartialLand(%s) error = %v, want %q", tt.upTo, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPartialLandPlanSteps(t *testing.T) {
+	plan := &partialLandPlan{UpTo: "gt-mr-2", Commit: "bbbbbbb2222222222222222222222222222222222"}
+	got := partialLandPlanSteps(plan, "integration/gt-epic", "main", true)
+	want := []string{
+		"Merge integration/gt-epic up to gt-mr-2 (bbbbbbb2) to main (--no-ff)",
+		"Push main to origin",
+		"Leave the integration branch and epic open",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("partialLandPlanSteps() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// mrUpdater rewrites an MR bead; *beads.Beads implements it.
+type mrUpdater interface {
+	Update(id string, opts beads.UpdateOptions) error
+}
+
+// runMqIntegrationReassignTarget points open MRs that target --from at --to.
+func runMqIntegrationReassignTarget(cmd *cobra.Command, args []string) error {
+	from, to := mqIntegrationReassignFrom, mqIntegrationReassignTo
+	if from == "" || to == "" {
+		return fmt.Errorf("both --from and --to are required")
+	}
+	if from == to {
+		return fmt.Errorf("--from and --to are both '%s'; nothing to reassign", from)
+	}
+	if err := validateBranchName(to); err != nil {
+		return fmt.Errorf("invalid --to: %w", err)
+	}
+
+	_, r, err := resolveMQRig()
+	if err != nil {
+		return err
+	}
+
+	bd := beads.New(r.Path)
+	mrs, err := bd.List(beads.ListOptions{
+		Label:    "gt:merge-request",
+		Status:   "", // all statuses; closed MRs are skipped below
+		Priority: -1, // No priority filter
+	})
+	if err != nil {
+		return fmt.Errorf("querying merge requests: %w", err)
+	}
+	orphaned := selectMRsForRetarget(mrs, from)
+
+	fmt.Printf("%s Open MRs targeting %s:\n\n", style.Bold.Render("🔀"), from)
+	if len(orphaned) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		return nil
+	}
+	if mqIntegrationReassignDryRun {
+		for _, mr := range orphaned {
+			fmt.Printf("  %s: %s → %s\n", mr.ID, from, to)
+		}
+		fmt.Printf("\n%s Dry run: %d MR(s) would be retargeted\n", style.Bold.Render("🔍"), len(orphaned))
+		return nil
+	}
+
+	failed := retargetMRs(bd, orphaned, to)
+	for _, mr := range orphaned {
+		if err, ok := failed[mr.ID]; ok {
+			fmt.Printf("  %s %s: %v\n", style.Error.Render("✗"), mr.ID, err)
+			continue
+		}
+		fmt.Printf("  %s %s: %s → %s\n", style.Success.Render("✓"), mr.ID, from, to)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d MR(s) could not be retargeted", len(failed), len(orphaned))
+	}
+	fmt.Printf("\n%s Retargeted %d MR(s)\n", style.Bold.Render("✓"), len(orphaned))
+	return nil
+}
+
+// selectMRsForRetarget returns the merge requests that are not closed and
+// whose target field is from.
+func selectMRsForRetarget(mrs []*beads.Issue, from string) []*beads.Issue {
+	var selected []*beads.Issue
+	for _, mr := range filterMRsByTarget(mrs, from) {
+		if mr.Status != "closed" {
+			selected = append(selected, mr)
+		}
+	}
+	return selected
+}
+
+// retargetMRs rewrites the target field of each MR's description to to,
+// leaving the rest of the description intact. Every MR is attempted; the
+// returned map holds the errors by MR ID.
+func retargetMRs(u mrUpdater, mrs []*beads.Issue, to string) map[string]error {
+	failed := map[string]error{}
+	for _, mr := range mrs {
+		desc := beads.SetDescriptionField(mr.Description, "target", to)
+		if err := u.Update(mr.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+			failed[mr.ID] = err
+		}
+	}
+	return failed
+}
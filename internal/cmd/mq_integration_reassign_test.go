@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestSelectMRsForRetarget(t *testing.T) {
+	mr := func(id, status, target string) *beads.Issue {
+		return &beads.Issue{
+			ID:          id,
+			Status:      status,
+			Labels:      []string{"gt:merge-request"},
+			Description: "branch: polecat/nux\ntarget: " + target,
+		}
+	}
+	mrs := []*beads.Issue{
+		mr("gt-mr-open", "open", "integration/gt-auth"),
+		mr("gt-mr-busy", "in_progress", "integration/gt-auth"),
+		mr("gt-mr-merged", "closed", "integration/gt-auth"),
+		mr("gt-mr-other", "open", "main"),
+		{ID: "gt-task", Status: "open", Description: "target: integration/gt-auth"},
+	}
+
+	var got []string
+	for _, m := range selectMRsForRetarget(mrs, "integration/gt-auth") {
+		got = append(got, m.ID)
+	}
+	if want := []string{"gt-mr-open", "gt-mr-busy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selectMRsForRetarget() = %v, want %v", got, want)
+	}
+}
+
+// fakeMRUpdater records description updates and fails the IDs in fail.
+type fakeMRUpdater struct {
+	descriptions map[string]string
+	fail         map[string]bool
+}
+
+func (f *fakeMRUpdater) Update(id string, opts beads.UpdateOptions) error {
+	if f.fail[id] {
+		return errors.New("bd update failed")
+	}
+	if opts.Description != nil {
+		f.descriptions[id] = *opts.Description
+	}
+	return nil
+}
+
+func TestRetargetMRs(t *testing.T) {
+	mrs := []*beads.Issue{
+		{ID: "gt-mr-1", Description: "branch: polecat/nux\ntarget: integration/gt-auth\nworker: nux\n\nFixes login."},
+		{ID: "gt-mr-2", Description: "target: integration/gt-auth"},
+	}
+	u := &fakeMRUpdater{descriptions: map[string]string{}, fail: map[string]bool{"gt-mr-2": true}}
+
+	failed := retargetMRs(u, mrs, "feat/gt-auth")
+
+	want := "branch: polecat/nux\ntarget: feat/gt-auth\nworker: nux\n\nFixes login."
+	if got := u.descriptions["gt-mr-1"]; got != want {
+		t.Errorf("gt-mr-1 description = %q, want %q", got, want)
+	}
+	if fields := beads.ParseMRFields(&beads.Issue{Description: u.descriptions["gt-mr-1"]}); fields == nil || fields.Target != "feat/gt-auth" {
+		t.Errorf("rewritten MR should parse with the new target: %+v", fields)
+	}
+	if len(failed) != 1 || failed["gt-mr-2"] == nil {
+		t.Errorf("failed = %v, want only gt-mr-2", failed)
+	}
+}
@@ -1,13 +1,28 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/output"
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 func TestFilterMRsByTarget(t *testing.T) {
@@ -100,6 +115,123 @@ func TestFilterMRsByTarget_NoMRFields(t *testing.T) {
 	}
 }
 
+func TestFilterMRsByWorker(t *testing.T) {
+	mrs := []*beads.Issue{
+		makeTestMR("mr-1", "polecat/Nux/gt-001", "integration/gt-epic", "Nux", "open"),
+		makeTestMR("mr-2", "polecat/Toast/gt-002", "main", "Toast", "open"),
+		makeTestMR("mr-3", "polecat/Nux/gt-003", "main", "nux", "closed"),
+		makeTestMR("mr-4", "polecat/Baker/gt-004", "integration/gt-epic", "", "open"),
+	}
+
+	tests := []struct {
+		name      string
+		worker    string
+		wantCount int
+		wantIDs   []string
+	}{
+		{
+			name:      "filter to Nux",
+			worker:    "Nux",
+			wantCount: 2,
+			wantIDs:   []string{"mr-1", "mr-3"},
+		},
+		{
+			name:      "case-insensitive match",
+			worker:    "NUX",
+			wantCount: 2,
+			wantIDs:   []string{"mr-1", "mr-3"},
+		},
+		{
+			name:      "filter to Toast",
+			worker:    "toast",
+			wantCount: 1,
+			wantIDs:   []string{"mr-2"},
+		},
+		{
+			name:      "filter to non-existent worker",
+			worker:    "Able",
+			wantCount: 0,
+			wantIDs:   []string{},
+		},
+		{
+			name:      "empty worker matches nothing",
+			worker:    "",
+			wantCount: 0,
+			wantIDs:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterMRsByWorker(mrs, tt.worker)
+			if len(got) != tt.wantCount {
+				t.Errorf("filterMRsByWorker() returned %d MRs, want %d", len(got), tt.wantCount)
+			}
+
+			gotIDs := make(map[string]bool)
+			for _, mr := range got {
+				gotIDs[mr.ID] = true
+			}
+			for _, wantID := range tt.wantIDs {
+				if !gotIDs[wantID] {
+					t.Errorf("filterMRsByWorker() missing expected MR %s", wantID)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterMRsByWorker_EmptyInput(t *testing.T) {
+	if got := filterMRsByWorker(nil, "Nux"); got != nil {
+		t.Errorf("filterMRsByWorker(nil) = %v, want nil", got)
+	}
+}
+
+func TestFilterMRsByWorker_NoMRFields(t *testing.T) {
+	plainIssue := &beads.Issue{
+		ID:          "issue-1",
+		Title:       "Not an MR",
+		Type:        "merge-request",
+		Status:      "open",
+		Description: "Just a plain description with no MR fields",
+	}
+
+	got := filterMRsByWorker([]*beads.Issue{plainIssue}, "Nux")
+	if len(got) != 0 {
+		t.Errorf("filterMRsByWorker() should filter out issues without MR fields, got %d", len(got))
+	}
+}
+
+func TestFilterMRsByWorker_ComposesWithTarget(t *testing.T) {
+	mrs := []*beads.Issue{
+		makeTestMR("mr-1", "polecat/Nux/gt-001", "integration/gt-epic", "Nux", "open"),
+		makeTestMR("mr-2", "polecat/Nux/gt-002", "main", "Nux", "open"),
+		makeTestMR("mr-3", "polecat/Toast/gt-003", "integration/gt-epic", "Toast", "open"),
+	}
+
+	got := filterMRsByWorker(filterMRsByTarget(mrs, "integration/gt-epic"), "nux")
+	if len(got) != 1 || got[0].ID != "mr-1" {
+		t.Errorf("filterMRsByWorker(filterMRsByTarget()) = %v, want [mr-1]", got)
+	}
+}
+
+func TestFilterMRsByTarget_RejectsNonMRs(t *testing.T) {
+	task := makeTestMR("gt-1", "polecat/Nux/gt-001", "main", "Nux", "open")
+	task.Type = "task"
+
+	labelOnly := makeTestMR("mr-2", "polecat/Toast/gt-002", "main", "Toast", "open")
+	labelOnly.Type = "task"
+	labelOnly.Labels = []string{"gt:merge-request"}
+
+	got := filterMRsByTarget([]*beads.Issue{task, labelOnly}, "main")
+	if len(got) != 1 || got[0].ID != "mr-2" {
+		t.Errorf("filterMRsByTarget() = %v, want only the labelled MR", got)
+	}
+	if got := filterMRsByWorker([]*beads.Issue{task}, "Nux"); len(got) != 0 {
+		t.Errorf("filterMRsByWorker() should drop non-MRs, got %d", len(got))
+	}
+}
+
 func TestValidateBranchName(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -218,6 +350,19 @@ func TestValidateBranchName(t *testing.T) {
 	}
 }
 
+func TestValidateBranchName_ExpandedDateTemplates(t *testing.T) {
+	for _, template := range []string{
+		"integration/{date}/{epic}",
+		"{year}/{month}/{epic}",
+		"integration/{epic}-{date}",
+	} {
+		branchName := buildIntegrationBranchName(template, "gt-auth")
+		if err := validateBranchName(branchName); err != nil {
+			t.Errorf("expanded %q -> %q should be valid: %v", template, branchName, err)
+		}
+	}
+}
+
 func TestGetIntegrationBranchField(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -405,6 +550,118 @@ func TestGetIntegrationBranchTemplate(t *testing.T) {
 	})
 }
 
+func TestFilterMergedSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ts := func(age time.Duration) string { return now.Add(-age).Format(time.RFC3339) }
+
+	mrs := []*beads.Issue{
+		{ID: "gt-mr-new", ClosedAt: ts(2 * time.Hour)},
+		{ID: "gt-mr-old", ClosedAt: ts(10 * 24 * time.Hour)},
+		{ID: "gt-mr-updated", UpdatedAt: ts(3 * 24 * time.Hour)},
+		{ID: "gt-mr-stale-update", UpdatedAt: ts(30 * 24 * time.Hour)},
+		{ID: "gt-mr-unknown"},
+	}
+
+	got := filterMergedSince(mrs, now.Add(-7*24*time.Hour))
+	var ids []string
+	for _, mr := range got {
+		ids = append(ids, mr.ID)
+	}
+	want := "gt-mr-new,gt-mr-updated,gt-mr-unknown"
+	if strings.Join(ids, ",") != want {
+		t.Errorf("filterMergedSince() = %v, want %s", ids, want)
+	}
+}
+
+func TestSortMRSummariesOldestFirst(t *testing.T) {
+	mrs := []IntegrationStatusMRSummary{
+		{ID: "mr-new", CreatedAt: "2025-03-01T12:00:00Z"},
+		{ID: "mr-undated-1"},
+		{ID: "mr-old", CreatedAt: "2025-01-01T12:00:00Z"},
+		{ID: "mr-tie-a", CreatedAt: "2025-02-01T12:00:00Z"},
+		{ID: "mr-undated-2", CreatedAt: "not a time"},
+		{ID: "mr-tie-b", CreatedAt: "2025-02-01T12:00:00Z"},
+	}
+
+	sortMRSummariesOldestFirst(mrs)
+
+	want := []string{"mr-old", "mr-tie-a", "mr-tie-b", "mr-new", "mr-undated-1", "mr-undated-2"}
+	for i, id := range want {
+		if mrs[i].ID != id {
+			t.Errorf("position %d = %s, want %s", i, mrs[i].ID, id)
+		}
+	}
+}
+
+func TestOpenChildSummaries(t *testing.T) {
+	// Epic stuck at 3/5 closed: the two open children should be listed.
+	children := []*beads.Issue{
+		{ID: "gt-c1", Title: "schema", Status: "closed"},
+		{ID: "gt-c2", Title: "api", Status: "in_progress", CreatedAt: "2026-01-02T00:00:00Z", Priority: 1},
+		{ID: "gt-c3", Title: "docs", Status: "closed"},
+		{ID: "gt-c4", Title: "ui", Status: "open", Priority: 3},
+		{ID: "gt-c5", Title: "tests", Status: "closed"},
+	}
+
+	got := openChildSummaries(children)
+	p1, p3 := 1, 3
+	want := []IntegrationStatusMRSummary{
+		{ID: "gt-c2", Title: "api", Status: "in_progress", CreatedAt: "2026-01-02T00:00:00Z", Priority: &p1},
+		{ID: "gt-c4", Title: "ui", Status: "open", Priority: &p3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("openChildSummaries() = %+v, want %+v", got, want)
+	}
+
+	out := captureStdout(t, func() {
+		_ = printIntegrationStatus(&IntegrationStatusOutput{
+			Branch:         "integration/gt-epic",
+			Priority:       2,
+			ChildrenTotal:  5,
+			ChildrenClosed: 3,
+			OpenChildren:   got,
+		})
+	})
+	for _, id := range []string{"gt-c2", "gt-c4"} {
+		if !strings.Contains(out, id) {
+			t.Errorf("status output missing open child %s:\n%s", id, out)
+		}
+	}
+	if strings.Contains(out, "gt-c1") {
+		t.Errorf("status output lists a closed child:\n%s", out)
+	}
+	for _, s := range []string{"Priority: P2", "(P1, in_progress)", "(P3, open)"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("status output missing %q:\n%s", s, out)
+		}
+	}
+}
+
+func TestIntegrationStatusSelect(t *testing.T) {
+	status := &IntegrationStatusOutput{
+		Epic:        "gt-epic",
+		ReadyToLand: true,
+		MergedMRs:   []IntegrationStatusMRSummary{{ID: "mr-1", Title: "auth"}},
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"ready_to_land", "true\n"},
+		{"merged_mrs.0.id", "mr-1\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := output.FprintSelected(&buf, status, tt.path, output.FormatText); err != nil {
+			t.Fatalf("FprintSelected(%q) error = %v", tt.path, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("--select %s = %q, want %q", tt.path, buf.String(), tt.want)
+		}
+	}
+}
+
 func TestIsReadyToLand(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -412,6 +669,7 @@ func TestIsReadyToLand(t *testing.T) {
 		childrenTotal  int
 		childrenClosed int
 		pendingMRCount int
+		openBlockers   int
 		want           bool
 	}{
 		{
@@ -454,6 +712,24 @@ func TestIsReadyToLand(t *testing.T) {
 			pendingMRCount: 2,
 			want:           false,
 		},
+		{
+			name:           "open blocking dependency",
+			aheadCount:     3,
+			childrenTotal:  5,
+			childrenClosed: 5,
+			pendingMRCount: 0,
+			openBlockers:   1,
+			want:           false,
+		},
+		{
+			name:           "blocked and pending MRs",
+			aheadCount:     3,
+			childrenTotal:  5,
+			childrenClosed: 5,
+			pendingMRCount: 1,
+			openBlockers:   2,
+			want:           false,
+		},
 		{
 			name:           "single child closed with commits",
 			aheadCount:     1,
@@ -466,15 +742,111 @@ func TestIsReadyToLand(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isReadyToLand(tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount)
+			got := isReadyToLand(tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount, tt.openBlockers)
+			if got != tt.want {
+				t.Errorf("isReadyToLand(%d, %d, %d, %d, %d) = %v, want %v",
+					tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount, tt.openBlockers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsReadyToLandWith(t *testing.T) {
+	strict := defaultLandCriteria()
+	trailingChildren := landCriteria{RequireAllChildrenClosed: false, RequireCommitsAhead: true}
+	noCommitsNeeded := landCriteria{RequireAllChildrenClosed: true, RequireCommitsAhead: false}
+	relaxed := landCriteria{}
+
+	tests := []struct {
+		name           string
+		criteria       landCriteria
+		aheadCount     int
+		childrenTotal  int
+		childrenClosed int
+		pendingMRCount int
+		openBlockers   int
+		want           bool
+	}{
+		{"strict: child still open", strict, 3, 5, 4, 0, 0, false},
+		{"trailing children allowed: child still open", trailingChildren, 3, 5, 4, 0, 0, true},
+		{"trailing children allowed: no commits ahead", trailingChildren, 0, 5, 4, 0, 0, false},
+		{"strict: no commits ahead", strict, 0, 5, 5, 0, 0, false},
+		{"commits not required: no commits ahead", noCommitsNeeded, 0, 5, 5, 0, 0, true},
+		{"commits not required: child still open", noCommitsNeeded, 0, 5, 4, 0, 0, false},
+		{"fully relaxed: open child, nothing ahead", relaxed, 0, 5, 0, 0, 0, true},
+		{"fully relaxed: still needs children", relaxed, 3, 0, 0, 0, 0, false},
+		{"fully relaxed: still needs no pending MRs", relaxed, 3, 5, 5, 1, 0, false},
+		{"fully relaxed: still needs no open blockers", relaxed, 3, 5, 5, 0, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isReadyToLandWith(tt.criteria, tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount, tt.openBlockers)
 			if got != tt.want {
-				t.Errorf("isReadyToLand(%d, %d, %d, %d) = %v, want %v",
-					tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount, got, tt.want)
+				t.Errorf("isReadyToLandWith(%+v, %d, %d, %d, %d, %d) = %v, want %v", tt.criteria,
+					tt.aheadCount, tt.childrenTotal, tt.childrenClosed, tt.pendingMRCount, tt.openBlockers, got, tt.want)
 			}
 		})
 	}
 }
 
+func TestGetLandCriteria(t *testing.T) {
+	rigPath := t.TempDir()
+	if got := getLandCriteria(rigPath); got != defaultLandCriteria() {
+		t.Errorf("getLandCriteria() without settings = %+v, want defaults", got)
+	}
+
+	off := false
+	settings := config.NewRigSettings()
+	settings.MergeQueue = config.DefaultMergeQueueConfig()
+	settings.MergeQueue.LandRequiresAllChildrenClosed = &off
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+	want := landCriteria{RequireAllChildrenClosed: false, RequireCommitsAhead: true}
+	if got := getLandCriteria(rigPath); got != want {
+		t.Errorf("getLandCriteria() = %+v, want %+v", got, want)
+	}
+}
+
+// fakeEpicDeps serves Dependencies and ShowMultiple from fixed data.
+type fakeEpicDeps struct {
+	blockedBy []string
+	issues    map[string]*beads.Issue
+	err       error
+}
+
+func (f *fakeEpicDeps) Dependencies(id string) ([]string, []string, error) {
+	return f.blockedBy, nil, f.err
+}
+
+func (f *fakeEpicDeps) ShowMultiple(ids []string) (map[string]*beads.Issue, error) {
+	return f.issues, nil
+}
+
+func TestOpenEpicBlockers(t *testing.T) {
+	deps := &fakeEpicDeps{
+		blockedBy: []string{"gt-done", "gt-wip", "gt-elsewhere"},
+		issues: map[string]*beads.Issue{
+			"gt-done": {ID: "gt-done", Status: "closed"},
+			"gt-wip":  {ID: "gt-wip", Status: "in_progress"},
+		},
+	}
+	got, err := openEpicBlockers(deps, "gt-epic")
+	if err != nil {
+		t.Fatalf("openEpicBlockers() error = %v", err)
+	}
+	if want := []string{"gt-wip", "gt-elsewhere"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("openEpicBlockers() = %v, want %v", got, want)
+	}
+
+	if got, err := openEpicBlockers(&fakeEpicDeps{}, "gt-epic"); err != nil || got != nil {
+		t.Errorf("openEpicBlockers() with no deps = %v, %v; want nil, nil", got, err)
+	}
+	if _, err := openEpicBlockers(&fakeEpicDeps{err: errors.New("bd failed")}, "gt-epic"); err == nil {
+		t.Error("openEpicBlockers() should return the dep list error")
+	}
+}
+
 // TestResolveEpicTarget verifies that the --epic flag resolution uses the configured
 // integration branch template rather than hardcoding "integration/" prefix.
 // This is the regression test for the bug where mq_submit.go used:
@@ -551,3 +923,2125 @@ func TestResolveEpicTarget(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveIntegrationBranchName checks that 'gt mq integration name'
+// resolves the same name as the template lookup create and submit use.
+func TestResolveIntegrationBranchName(t *testing.T) {
+	defaultRig := t.TempDir()
+	customRig := t.TempDir()
+	settings := config.NewRigSettings()
+	settings.MergeQueue = config.DefaultMergeQueueConfig()
+	settings.MergeQueue.IntegrationBranchTemplate = "{prefix}/{epic}"
+	if err := config.SaveRigSettings(config.RigSettingsPath(customRig), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		rigPath  string
+		override string
+		want     string
+	}{
+		{name: "default template", rigPath: defaultRig, want: "integration/gt-epic"},
+		{name: "rig template", rigPath: customRig, want: "gt/gt-epic"},
+		{name: "--template wins over rig", rigPath: customRig, override: "feature/{epic}", want: "feature/gt-epic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveIntegrationBranchName(tt.rigPath, tt.override, "gt-epic")
+			if err != nil {
+				t.Fatalf("resolveIntegrationBranchName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveIntegrationBranchName() = %q, want %q", got, tt.want)
+			}
+			if legacy := buildIntegrationBranchName(getIntegrationBranchTemplate(tt.rigPath, tt.override), "gt-epic"); got != legacy {
+				t.Errorf("name %q differs from template resolution %q", got, legacy)
+			}
+		})
+	}
+
+	if _, err := resolveIntegrationBranchName(defaultRig, "bad name/{epic}", "gt-epic"); err == nil || !strings.Contains(err.Error(), "invalid branch name") {
+		t.Errorf("expected invalid branch name error, got %v", err)
+	}
+}
+
+func TestResolveIntegrationBaseRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseBranch  string
+		wantRef     string
+		wantDisplay string
+	}{
+		{
+			name:        "default is origin/main",
+			baseBranch:  "",
+			wantRef:     "origin/main",
+			wantDisplay: "main",
+		},
+		{
+			name:        "branch is prefixed with origin",
+			baseBranch:  "develop",
+			wantRef:     "origin/develop",
+			wantDisplay: "develop",
+		},
+		{
+			name:        "branch with origin prefix is not doubled",
+			baseBranch:  "origin/release/1.x",
+			wantRef:     "origin/release/1.x",
+			wantDisplay: "release/1.x",
+		},
+		{
+			name:        "tag ref is used verbatim",
+			baseBranch:  "refs/tags/v1.2.0",
+			wantRef:     "refs/tags/v1.2.0",
+			wantDisplay: "refs/tags/v1.2.0",
+		},
+		{
+			name:        "full SHA is used verbatim",
+			baseBranch:  "0123456789abcdef0123456789abcdef01234567",
+			wantRef:     "0123456789abcdef0123456789abcdef01234567",
+			wantDisplay: "0123456789abcdef0123456789abcdef01234567",
+		},
+		{
+			name:        "short SHA is used verbatim",
+			baseBranch:  "abc1234",
+			wantRef:     "abc1234",
+			wantDisplay: "abc1234",
+		},
+		{
+			name:        "too-short hex is treated as a branch",
+			baseBranch:  "abc12",
+			wantRef:     "origin/abc12",
+			wantDisplay: "abc12",
+		},
+		{
+			name:        "non-hex 7-char name is treated as a branch",
+			baseBranch:  "feature",
+			wantRef:     "origin/feature",
+			wantDisplay: "feature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRef, gotDisplay := resolveIntegrationBaseRef(tt.baseBranch)
+			if gotRef != tt.wantRef {
+				t.Errorf("resolveIntegrationBaseRef(%q) ref = %q, want %q", tt.baseBranch, gotRef, tt.wantRef)
+			}
+			if gotDisplay != tt.wantDisplay {
+				t.Errorf("resolveIntegrationBaseRef(%q) display = %q, want %q", tt.baseBranch, gotDisplay, tt.wantDisplay)
+			}
+		})
+	}
+}
+
+func TestIntegrationBranchGlob(t *testing.T) {
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{"integration/{epic}", "integration/*"},
+		{"{user}/{epic}", "*/*"},
+		{"feature/{prefix}/{epic}", "feature/*/*"},
+		{"integration/{date}/{epic}", "integration/*/*"},
+		{"release", "release"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			if got := integrationBranchGlob(tt.template); got != tt.want {
+				t.Errorf("integrationBranchGlob(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIntegrationEpic(t *testing.T) {
+	branchEpics := map[string]string{
+		"integration/custom-name": "gt-epic1",
+	}
+
+	tests := []struct {
+		name     string
+		branch   string
+		template string
+		want     string
+	}{
+		{
+			name:     "metadata wins over template",
+			branch:   "integration/custom-name",
+			template: "integration/{epic}",
+			want:     "gt-epic1",
+		},
+		{
+			name:     "default template",
+			branch:   "integration/gt-auth",
+			template: "integration/{epic}",
+			want:     "gt-auth",
+		},
+		{
+			name:     "template with user and prefix",
+			branch:   "alice/gt/gt-auth",
+			template: "{user}/{prefix}/{epic}",
+			want:     "gt-auth",
+		},
+		{
+			name:     "template with date",
+			branch:   "integration/2026-03-07/gt-auth",
+			template: "integration/{date}/{epic}",
+			want:     "gt-auth",
+		},
+		{
+			name:     "literal dots are not wildcards",
+			branch:   "releaseXgt-auth",
+			template: "release.{epic}",
+			want:     "",
+		},
+		{
+			name:     "branch does not match template",
+			branch:   "feature/gt-auth",
+			template: "integration/{epic}",
+			want:     "",
+		},
+		{
+			name:     "template without epic placeholder",
+			branch:   "integration/gt",
+			template: "integration/{prefix}",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveIntegrationEpic(tt.branch, tt.template, branchEpics)
+			if got != tt.want {
+				t.Errorf("resolveIntegrationEpic(%q, %q) = %q, want %q", tt.branch, tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterReadyIntegrations(t *testing.T) {
+	entries := []IntegrationListEntry{
+		{Epic: "gt-a", ReadyToLand: true},
+		{Epic: "gt-b", ReadyToLand: false},
+		{Epic: "gt-c", ReadyToLand: true},
+	}
+
+	got := filterReadyIntegrations(entries)
+	if len(got) != 2 || got[0].Epic != "gt-a" || got[1].Epic != "gt-c" {
+		t.Errorf("filterReadyIntegrations() = %+v, want gt-a and gt-c", got)
+	}
+
+	if got := filterReadyIntegrations([]IntegrationListEntry{{Epic: "gt-x"}}); len(got) != 0 {
+		t.Errorf("expected no ready entries, got %+v", got)
+	}
+}
+
+func TestRunTestCommand_CompoundCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell syntax")
+	}
+	dir := t.TempDir()
+
+	if err := runTestCommand(dir, "echo one > out.txt && echo two | tr a-z A-Z >> out.txt", 0); err != nil {
+		t.Fatalf("runTestCommand() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "one\nTWO\n" {
+		t.Errorf("out.txt = %q, want %q", got, "one\nTWO\n")
+	}
+
+	// A failing second step must fail the whole command
+	if err := runTestCommand(dir, "true && false", 0); err == nil {
+		t.Error("expected error when a compound step fails")
+	}
+}
+
+func TestExpandTestCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		testCmd string
+		want    string
+	}{
+		{
+			name:    "rig and epic",
+			testCmd: "make test RIG={rig} EPIC={epic}",
+			want:    "make test RIG=gastown EPIC=gt-auth",
+		},
+		{
+			name:    "town root and branch",
+			testCmd: "{town_root}/scripts/ci.sh {branch}",
+			want:    "/home/gt/scripts/ci.sh integration/gt-auth",
+		},
+		{
+			name:    "no placeholders unchanged",
+			testCmd: "go test ./...",
+			want:    "go test ./...",
+		},
+		{
+			name:    "shell variables left alone",
+			testCmd: "CI=$CI go test ./{rig}/... $GT_RIG",
+			want:    "CI=$CI go test ./gastown/... $GT_RIG",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandTestCommand(tt.testCmd, "/home/gt", "gastown", "gt-auth", "integration/gt-auth")
+			if got != tt.want {
+				t.Errorf("expandTestCommand(%q) = %q, want %q", tt.testCmd, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTestCommand_QuotesValues(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell quoting")
+	}
+	dir := t.TempDir()
+
+	// A branch name taken from epic metadata must not inject shell commands
+	for _, branch := range []string{"x;touch pwned", "x$(touch pwned)", "x`touch pwned`", "x|touch pwned"} {
+		testCmd := expandTestCommand("echo {branch} > out.txt", dir, "gastown", "gt-auth", branch)
+		if err := runTestCommand(dir, testCmd, 0); err != nil {
+			t.Fatalf("runTestCommand(%q) error = %v", testCmd, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "pwned")); err == nil {
+			t.Fatalf("branch %q ran an injected command via %q", branch, testCmd)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.TrimSpace(string(got)) != branch {
+			t.Errorf("echo {branch} printed %q, want %q", strings.TrimSpace(string(got)), branch)
+		}
+	}
+}
+
+func TestRunTestCommand_Timeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX sleep")
+	}
+
+	start := time.Now()
+	err := runTestCommand(t.TempDir(), "sleep 10", 200*time.Millisecond)
+	if !errors.Is(err, errTestTimeout) {
+		t.Fatalf("runTestCommand() error = %v, want errTestTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("timeout not enforced promptly, took %s", elapsed)
+	}
+
+	// A command that finishes in time is not reported as a timeout
+	if err := runTestCommand(t.TempDir(), "true", 5*time.Second); err != nil {
+		t.Errorf("runTestCommand() error = %v, want nil", err)
+	}
+}
+
+// fakeShellRunner records the script it was asked to run and returns err,
+// or blocks until ctx is done when block is set.
+type fakeShellRunner struct {
+	dir, script string
+	err         error
+	block       bool
+}
+
+func (f *fakeShellRunner) RunShell(ctx context.Context, dir, script string, stdout, stderr io.Writer) error {
+	f.dir, f.script = dir, script
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return f.err
+}
+
+func TestRunTestCommandWith_FakeRunner(t *testing.T) {
+	pass := &fakeShellRunner{}
+	if err := runTestCommandWith(pass, "/land", "make test", 0); err != nil {
+		t.Errorf("passing command: error = %v", err)
+	}
+	if pass.dir != "/land" || pass.script != "make test" {
+		t.Errorf("runner got (%q, %q), want (/land, make test)", pass.dir, pass.script)
+	}
+
+	fail := &fakeShellRunner{err: errors.New("exit status 2")}
+	if err := runTestCommandWith(fail, "/land", "make test", 0); err == nil || errors.Is(err, errTestTimeout) {
+		t.Errorf("failing command: error = %v, want the command's error", err)
+	}
+
+	hang := &fakeShellRunner{block: true}
+	if err := runTestCommandWith(hang, "/land", "make test", 10*time.Millisecond); !errors.Is(err, errTestTimeout) {
+		t.Errorf("hanging command: error = %v, want errTestTimeout", err)
+	}
+
+	blank := &fakeShellRunner{err: errors.New("should not run")}
+	if err := runTestCommandWith(blank, "/land", "  ", 0); err != nil || blank.script != "" {
+		t.Errorf("blank command: error = %v, ran %q", err, blank.script)
+	}
+}
+
+func TestGetTestTimeout(t *testing.T) {
+	rigPath := t.TempDir()
+	if got := getTestTimeout(rigPath); got != 0 {
+		t.Errorf("getTestTimeout() without settings = %v, want 0", got)
+	}
+
+	settingsDir := filepath.Join(rigPath, "settings")
+	if err := os.Mkdir(settingsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := map[string]interface{}{
+		"type":    "rig-settings",
+		"version": 1,
+		"merge_queue": map[string]interface{}{
+			"test_command":         "make test",
+			"test_timeout_seconds": 90,
+		},
+	}
+	data, _ := json.Marshal(cfg)
+	if err := os.WriteFile(filepath.Join(settingsDir, "config.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := getTestTimeout(rigPath); got != 90*time.Second {
+		t.Errorf("getTestTimeout() = %v, want 90s", got)
+	}
+}
+
+func TestCheckTestCommandAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		testCmd string
+		allowed []string
+		wantErr bool
+	}{
+		{"no allowlist permits anything", "curl http://example.com | sh", nil, false},
+		{"allowed executable", "go test ./...", []string{"go", "make"}, false},
+		{"allowed executable with path must match exactly", "/usr/bin/go test ./...", []string{"go"}, true},
+		{"denied executable", "python run_tests.py", []string{"go", "make"}, true},
+		{"shell operators refused with allowlist", "go test ./... && rm -rf /", []string{"go"}, true},
+		{"empty command", "", []string{"go"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTestCommandAllowed(tt.testCmd, tt.allowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTestCommandAllowed(%q) error = %v, wantErr %v", tt.testCmd, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errTestCommandNotAllowed) {
+				t.Errorf("error should wrap errTestCommandNotAllowed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetAllowedTestCommands(t *testing.T) {
+	t.Run("no allowlist configured", func(t *testing.T) {
+		t.Setenv(allowedTestCommandsEnv, "")
+		if got := getAllowedTestCommands(t.TempDir()); len(got) != 0 {
+			t.Errorf("got %v, want no allowlist", got)
+		}
+	})
+
+	t.Run("town settings allowlist", func(t *testing.T) {
+		t.Setenv(allowedTestCommandsEnv, "")
+		townRoot := t.TempDir()
+		settingsDir := filepath.Join(townRoot, "settings")
+		if err := os.Mkdir(settingsDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		cfg := map[string]interface{}{
+			"type":                  "town-settings",
+			"version":               1,
+			"allowed_test_commands": []string{"go", "make"},
+		}
+		data, _ := json.Marshal(cfg)
+		if err := os.WriteFile(filepath.Join(settingsDir, "config.json"), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		got := getAllowedTestCommands(townRoot)
+		if strings.Join(got, ",") != "go,make" {
+			t.Errorf("got %v, want [go make]", got)
+		}
+	})
+
+	t.Run("env overrides town settings", func(t *testing.T) {
+		t.Setenv(allowedTestCommandsEnv, " make , ,npm")
+		got := getAllowedTestCommands(t.TempDir())
+		if strings.Join(got, ",") != "make,npm" {
+			t.Errorf("got %v, want [make npm]", got)
+		}
+	})
+}
+
+// fakeRefFetcher records fetch calls for testing --no-fetch.
+type fakeRefFetcher struct {
+	fetched []string
+}
+
+func (f *fakeRefFetcher) FetchPrune(remote string) error {
+	f.fetched = append(f.fetched, remote)
+	return nil
+}
+
+func TestFetchIntegrationRefs(t *testing.T) {
+	t.Run("fetches origin by default", func(t *testing.T) {
+		f := &fakeRefFetcher{}
+		if err := fetchIntegrationRefs(f, false); err != nil {
+			t.Fatalf("fetchIntegrationRefs() error = %v", err)
+		}
+		if len(f.fetched) != 1 || f.fetched[0] != "origin" {
+			t.Errorf("fetched = %v, want [origin]", f.fetched)
+		}
+	})
+
+	t.Run("no-fetch skips fetch", func(t *testing.T) {
+		f := &fakeRefFetcher{}
+		if err := fetchIntegrationRefs(f, true); err != nil {
+			t.Fatalf("fetchIntegrationRefs() error = %v", err)
+		}
+		if len(f.fetched) != 0 {
+			t.Errorf("fetch attempted with --no-fetch: %v", f.fetched)
+		}
+	})
+}
+
+func TestFetchIntegrationRefs_PrunesDeletedBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath, src := setupInterruptedLand(t)
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := g.RemoteTrackingBranchExists("origin", "integration/gt-epic"); !exists {
+		t.Fatal("expected origin/integration/gt-epic before deletion")
+	}
+
+	// Another clone lands the epic and deletes the branch on origin
+	gitIn(t, src, "branch", "-D", "integration/gt-epic")
+
+	if err := fetchIntegrationRefs(g, false); err != nil {
+		t.Fatalf("fetchIntegrationRefs() error = %v", err)
+	}
+	exists, err := g.RemoteTrackingBranchExists("origin", "integration/gt-epic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Error("stale origin/integration/gt-epic tracking ref should be pruned")
+	}
+}
+
+// TestOpenMRListOptionsResultSet verifies the open-MR query selects MRs by
+// label at any priority, including label-only MRs created with type "task".
+func TestOpenMRListOptionsResultSet(t *testing.T) {
+	if openMRListOptions.Label != "gt:merge-request" {
+		t.Errorf("Label = %q, want gt:merge-request", openMRListOptions.Label)
+	}
+	if openMRListOptions.Priority != -1 {
+		t.Errorf("Priority = %d, want -1 (no priority filter)", openMRListOptions.Priority)
+	}
+
+	labelOnly := makeTestMR("mr-1", "polecat/Nux/gt-001", "integration/gt-epic", "Nux", "open")
+	labelOnly.Type = "task"
+	labelOnly.Labels = []string{"gt:merge-request"}
+
+	urgent := makeTestMR("mr-2", "polecat/Toast/gt-002", "integration/gt-epic", "Toast", "open")
+	urgent.Priority = 0
+	urgent.Labels = []string{"gt:merge-request"}
+
+	closed := makeTestMR("mr-3", "polecat/Able/gt-003", "integration/gt-epic", "Able", "closed")
+	closed.Labels = []string{"gt:merge-request"}
+
+	task := makeTestIssue("gt-004", "Regular task", "task", "open")
+
+	mock := newMockBeads()
+	for _, issue := range []*beads.Issue{labelOnly, urgent, closed, task} {
+		mock.addIssue(issue)
+	}
+
+	mrs, err := mock.List(openMRListOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := filterMRsByTarget(mrs, "integration/gt-epic")
+
+	ids := make(map[string]bool)
+	for _, mr := range got {
+		ids[mr.ID] = true
+	}
+	if len(got) != 2 || !ids["mr-1"] || !ids["mr-2"] {
+		t.Errorf("open MRs = %v, want mr-1 and mr-2", ids)
+	}
+}
+
+func TestResolveLandTagName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{"disabled", "", "", false},
+		{"epic template", "epic/{epic}", "epic/gt-auth", false},
+		{"prefix template", "landed/{prefix}/{epic}", "landed/gt/gt-auth", false},
+		{"invalid characters", "land {epic}", "", true},
+		{"lock suffix", "{epic}.lock", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLandTagName(tt.template, "gt-auth")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveLandTagName(%q) error = %v, wantErr %v", tt.template, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveLandTagName(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTagOnLandTemplate(t *testing.T) {
+	rigPath := t.TempDir()
+	if got := getTagOnLandTemplate(rigPath); got != "" {
+		t.Errorf("getTagOnLandTemplate() without settings = %q, want empty", got)
+	}
+
+	settingsDir := filepath.Join(rigPath, "settings")
+	if err := os.Mkdir(settingsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := map[string]interface{}{
+		"type":    "rig-settings",
+		"version": 1,
+		"merge_queue": map[string]interface{}{
+			"tag_on_land": "epic/{epic}",
+		},
+	}
+	data, _ := json.Marshal(cfg)
+	if err := os.WriteFile(filepath.Join(settingsDir, "config.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := getTagOnLandTemplate(rigPath); got != "epic/{epic}" {
+		t.Errorf("getTagOnLandTemplate() = %q, want %q", got, "epic/{epic}")
+	}
+}
+
+// fakeLandTagger records tag operations instead of running git.
+type fakeLandTagger struct {
+	created   []string
+	pushed    []string
+	message   string
+	createErr error
+	pushErr   error
+}
+
+func (f *fakeLandTagger) CreateTag(name, ref, message string) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.created = append(f.created, name+"@"+ref)
+	f.message = message
+	return nil
+}
+
+func (f *fakeLandTagger) CreateSignedTag(name, ref, message, key string) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	f.created = append(f.created, name+"@"+ref+" signed by "+key)
+	f.message = message
+	return nil
+}
+
+func (f *fakeLandTagger) PushTag(remote, name string) error {
+	if f.pushErr != nil {
+		return f.pushErr
+	}
+	f.pushed = append(f.pushed, remote+"/"+name)
+	return nil
+}
+
+func TestTagLandedEpic(t *testing.T) {
+	t.Run("creates annotated tag at HEAD and pushes it", func(t *testing.T) {
+		f := &fakeLandTagger{}
+		if err := tagLandedEpic(f, "epic/gt-auth", "HEAD", "gt-auth", "Auth overhaul", landSigning{}); err != nil {
+			t.Fatalf("tagLandedEpic() error = %v", err)
+		}
+		if len(f.created) != 1 || f.created[0] != "epic/gt-auth@HEAD" {
+			t.Errorf("created = %v, want [epic/gt-auth@HEAD]", f.created)
+		}
+		if len(f.pushed) != 1 || f.pushed[0] != "origin/epic/gt-auth" {
+			t.Errorf("pushed = %v, want [origin/epic/gt-auth]", f.pushed)
+		}
+		if !strings.Contains(f.message, "gt-auth") || !strings.Contains(f.message, "Auth overhaul") {
+			t.Errorf("tag message %q should mention epic and title", f.message)
+		}
+	})
+
+	t.Run("signed tag uses the signing key", func(t *testing.T) {
+		f := &fakeLandTagger{}
+		if err := tagLandedEpic(f, "epic/gt-auth", "HEAD", "gt-auth", "Auth", landSigning{Sign: true, Key: "ABCD1234"}); err != nil {
+			t.Fatalf("tagLandedEpic() error = %v", err)
+		}
+		if len(f.created) != 1 || f.created[0] != "epic/gt-auth@HEAD signed by ABCD1234" {
+			t.Errorf("created = %v, want a tag signed by ABCD1234", f.created)
+		}
+	})
+
+	t.Run("create failure skips push", func(t *testing.T) {
+		f := &fakeLandTagger{createErr: errors.New("tag exists")}
+		if err := tagLandedEpic(f, "epic/gt-auth", "HEAD", "gt-auth", "Auth", landSigning{}); err == nil {
+			t.Fatal("expected error when tag creation fails")
+		}
+		if len(f.pushed) != 0 {
+			t.Errorf("push should be skipped after create failure, pushed = %v", f.pushed)
+		}
+	})
+
+	t.Run("push failure is reported", func(t *testing.T) {
+		f := &fakeLandTagger{pushErr: errors.New("rejected")}
+		err := tagLandedEpic(f, "epic/gt-auth", "HEAD", "gt-auth", "Auth", landSigning{})
+		if err == nil || !strings.Contains(err.Error(), "pushing tag") {
+			t.Errorf("tagLandedEpic() error = %v, want pushing tag error", err)
+		}
+	})
+}
+
+// fakeEpicTree serves issues and branch existence for land target tests.
+type fakeEpicTree struct {
+	issues         map[string]*beads.Issue
+	branches       map[string]bool
+	remoteBranches map[string]bool
+	remoteErr      error
+}
+
+func (f *fakeEpicTree) Show(id string) (*beads.Issue, error) {
+	issue, ok := f.issues[id]
+	if !ok {
+		return nil, beads.ErrNotFound
+	}
+	return issue, nil
+}
+
+func (f *fakeEpicTree) BranchExists(name string) (bool, error) {
+	return f.branches[name], nil
+}
+
+func (f *fakeEpicTree) RemoteBranchExists(remote, name string) (bool, error) {
+	if f.remoteErr != nil {
+		return false, f.remoteErr
+	}
+	return f.remoteBranches[name], nil
+}
+
+func TestResolveLandTarget(t *testing.T) {
+	tree := &fakeEpicTree{
+		issues: map[string]*beads.Issue{
+			"gt-root": {ID: "gt-root", Type: "epic",
+				Description: "integration_branch: integration/gt-root\nbase_branch: develop"},
+			"gt-child": {ID: "gt-child", Type: "epic", Parent: "gt-root",
+				Description: "integration_branch: integration/gt-child\nbase_branch: develop"},
+			"gt-grandchild": {ID: "gt-grandchild", Type: "epic", Parent: "gt-child"},
+			"gt-orphan":     {ID: "gt-orphan", Type: "epic", Parent: "gt-task"},
+			"gt-task":       {ID: "gt-task", Type: "task"},
+		},
+		branches: map[string]bool{
+			"integration/gt-root":  true,
+			"integration/gt-child": true,
+		},
+	}
+
+	tests := []struct {
+		name string
+		epic string
+		want string
+	}{
+		{name: "top-level epic lands to base_branch", epic: "gt-root", want: "develop"},
+		{name: "child lands into parent integration branch", epic: "gt-child", want: "integration/gt-root"},
+		{name: "grandchild lands into nearest ancestor branch", epic: "gt-grandchild", want: "integration/gt-child"},
+		{name: "no ancestor epic falls back to main", epic: "gt-orphan", want: "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLandTarget(tree, tree, tree.issues[tt.epic])
+			if err != nil {
+				t.Fatalf("resolveLandTarget() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveLandTarget(%s) = %q, want %q", tt.epic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLandTarget_ParentBranchGone(t *testing.T) {
+	// Once the parent's integration branch is landed and deleted, the child
+	// cascades past it to the next ancestor with a branch.
+	tree := &fakeEpicTree{
+		issues: map[string]*beads.Issue{
+			"gt-root":  {ID: "gt-root", Type: "epic"},
+			"gt-mid":   {ID: "gt-mid", Type: "epic", Parent: "gt-root"},
+			"gt-child": {ID: "gt-child", Type: "epic", Parent: "gt-mid", Description: "base_branch: main"},
+		},
+		branches: map[string]bool{"integration/gt-root": true},
+	}
+
+	got, err := resolveLandTarget(tree, tree, tree.issues["gt-child"])
+	if err != nil {
+		t.Fatalf("resolveLandTarget() error = %v", err)
+	}
+	if got != "integration/gt-root" {
+		t.Errorf("resolveLandTarget() = %q, want %q", got, "integration/gt-root")
+	}
+}
+
+func TestResolveLandTarget_MissingParent(t *testing.T) {
+	tree := &fakeEpicTree{issues: map[string]*beads.Issue{
+		"gt-child": {ID: "gt-child", Type: "epic", Parent: "gt-gone"},
+	}}
+
+	if _, err := resolveLandTarget(tree, tree, tree.issues["gt-child"]); err == nil {
+		t.Error("resolveLandTarget() should fail when the parent cannot be looked up")
+	}
+}
+
+// gitIn runs git in dir, failing the test on error.
+func gitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// setupLandRig creates a rig directory whose .repo.git has a main branch and
+// a "conflict" branch that edits the same line of README.md.
+func setupLandRig(t *testing.T) string {
+	t.Helper()
+	src := t.TempDir()
+	gitIn(t, src, "init", "--initial-branch=main")
+	gitIn(t, src, "config", "user.email", "test@test.com")
+	gitIn(t, src, "config", "user.name", "Test User")
+	writeReadme := func(content string) {
+		if err := os.WriteFile(filepath.Join(src, "README.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeReadme("base\n")
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "base")
+	gitIn(t, src, "checkout", "-b", "conflict")
+	writeReadme("theirs\n")
+	gitIn(t, src, "commit", "-am", "theirs")
+	gitIn(t, src, "checkout", "main")
+	writeReadme("ours\n")
+	gitIn(t, src, "commit", "-am", "ours")
+
+	rigPath := t.TempDir()
+	gitIn(t, rigPath, "clone", "--bare", src, ".repo.git")
+	return rigPath
+}
+
+func TestAbortLand_NothingInProgress(t *testing.T) {
+	cleaned, err := abortLand(t.TempDir())
+	if err != nil {
+		t.Fatalf("abortLand() error = %v", err)
+	}
+	if len(cleaned) != 0 {
+		t.Errorf("abortLand() cleaned = %v, want nothing", cleaned)
+	}
+}
+
+func TestAbortLand_MergeInProgress(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath := setupLandRig(t)
+
+	landGit, _, err := createLandWorktree(rigPath, "main")
+	if err != nil {
+		t.Fatalf("createLandWorktree() error = %v", err)
+	}
+	gitIn(t, landGit.WorkDir(), "config", "user.email", "test@test.com")
+	gitIn(t, landGit.WorkDir(), "config", "user.name", "Test User")
+	if err := landGit.MergeNoFF("conflict", "merge conflict"); err == nil {
+		t.Fatal("expected conflicting merge to fail")
+	}
+	if !landMergeInProgress(util.ExecRunner{}, landGit.WorkDir()) {
+		t.Fatal("expected a merge in progress before abort")
+	}
+
+	cleaned, err := abortLand(rigPath)
+	if err != nil {
+		t.Fatalf("abortLand() error = %v", err)
+	}
+	if len(cleaned) != 2 || !strings.Contains(cleaned[0], "Aborted merge") {
+		t.Errorf("abortLand() cleaned = %v, want merge abort and worktree removal", cleaned)
+	}
+	if _, err := os.Stat(landWorktreePath(rigPath)); !os.IsNotExist(err) {
+		t.Errorf("land worktree should be removed, stat err = %v", err)
+	}
+
+	// A second abort is a no-op
+	cleaned, err = abortLand(rigPath)
+	if err != nil || len(cleaned) != 0 {
+		t.Errorf("second abortLand() = %v, %v; want no-op", cleaned, err)
+	}
+}
+
+func TestAbortLand_StaleWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath := setupLandRig(t)
+
+	if _, _, err := createLandWorktree(rigPath, "main"); err != nil {
+		t.Fatalf("createLandWorktree() error = %v", err)
+	}
+
+	cleaned, err := abortLand(rigPath)
+	if err != nil {
+		t.Fatalf("abortLand() error = %v", err)
+	}
+	if len(cleaned) != 1 || !strings.Contains(cleaned[0], "Removed land worktree") {
+		t.Errorf("abortLand() cleaned = %v, want only worktree removal", cleaned)
+	}
+}
+
+func TestAdoptIntegrationBranch(t *testing.T) {
+	checker := &fakeEpicTree{
+		branches:       map[string]bool{"feature/local": true, "feature/both": true},
+		remoteBranches: map[string]bool{"feature/remote": true, "feature/both": true},
+	}
+
+	tests := []struct {
+		name         string
+		branch       string
+		baseBranch   string
+		wantLocation string
+		wantBase     string
+	}{
+		{name: "local only", branch: "feature/local", wantLocation: "local only"},
+		{name: "remote only", branch: "feature/remote", wantLocation: "origin only"},
+		{name: "local and remote with base branch", branch: "feature/both", baseBranch: "develop",
+			wantLocation: "local and origin", wantBase: "develop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, location, err := adoptIntegrationBranch(checker, "Auth overhaul", tt.branch, tt.baseBranch)
+			if err != nil {
+				t.Fatalf("adoptIntegrationBranch() error = %v", err)
+			}
+			if location != tt.wantLocation {
+				t.Errorf("location = %q, want %q", location, tt.wantLocation)
+			}
+			if got := getIntegrationBranchField(desc); got != tt.branch {
+				t.Errorf("integration_branch = %q, want %q", got, tt.branch)
+			}
+			if got := beads.GetBaseBranchField(desc); got != tt.wantBase {
+				t.Errorf("base_branch = %q, want %q", got, tt.wantBase)
+			}
+			if !strings.Contains(desc, "Auth overhaul") {
+				t.Errorf("original description lost: %q", desc)
+			}
+		})
+	}
+}
+
+func TestAdoptIntegrationBranch_Errors(t *testing.T) {
+	t.Run("nonexistent branch", func(t *testing.T) {
+		checker := &fakeEpicTree{}
+		_, _, err := adoptIntegrationBranch(checker, "", "feature/missing", "")
+		if err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Errorf("expected does-not-exist error, got %v", err)
+		}
+	})
+
+	t.Run("invalid branch name", func(t *testing.T) {
+		checker := &fakeEpicTree{branches: map[string]bool{"bad name": true}}
+		if _, _, err := adoptIntegrationBranch(checker, "", "bad name", ""); err == nil {
+			t.Error("expected error for invalid branch name")
+		}
+	})
+
+	t.Run("missing locally and remote unreachable", func(t *testing.T) {
+		checker := &fakeEpicTree{remoteErr: errors.New("network down")}
+		_, _, err := adoptIntegrationBranch(checker, "", "feature/x", "")
+		if err == nil || !strings.Contains(err.Error(), "network down") {
+			t.Errorf("expected remote check error, got %v", err)
+		}
+	})
+}
+
+func TestConfirmLand(t *testing.T) {
+	neverAsked := func(string) bool {
+		t.Error("prompt should not be shown")
+		return false
+	}
+
+	t.Run("non-TTY without --yes refuses", func(t *testing.T) {
+		err := confirmLand(false, false, neverAsked)
+		if err == nil || !strings.Contains(err.Error(), "--yes") {
+			t.Errorf("expected refusal mentioning --yes, got %v", err)
+		}
+	})
+
+	t.Run("--yes skips the prompt", func(t *testing.T) {
+		if err := confirmLand(true, false, neverAsked); err != nil {
+			t.Errorf("confirmLand(--yes) error = %v", err)
+		}
+	})
+
+	t.Run("interactive accept and decline", func(t *testing.T) {
+		if err := confirmLand(false, true, func(string) bool { return true }); err != nil {
+			t.Errorf("accepted prompt should proceed, got %v", err)
+		}
+		if err := confirmLand(false, true, func(string) bool { return false }); err == nil {
+			t.Error("declined prompt should cancel the land")
+		}
+	})
+}
+
+func TestLandPlanSteps(t *testing.T) {
+	steps := landPlanSteps("integration/gt-auth", "main", "", true)
+	for _, step := range steps {
+		if strings.Contains(step, "Run tests") || strings.Contains(step, "Tag") {
+			t.Errorf("unexpected step %q with tests skipped and no tag", step)
+		}
+	}
+
+	steps = landPlanSteps("integration/gt-auth", "main", "epic/gt-auth", false)
+	want := []string{
+		"Merge integration/gt-auth to main (--no-ff)",
+		"Run tests on main",
+		"Push main to origin",
+		"Tag merge commit as epic/gt-auth and push the tag",
+		"Delete integration branch (local and remote)",
+		"Update epic status to closed",
+	}
+	if strings.Join(steps, "|") != strings.Join(want, "|") {
+		t.Errorf("landPlanSteps() = %q, want %q", steps, want)
+	}
+}
+
+// fakeEpicCloser records close calls. List returns children; IDs in fail
+// can't be closed.
+type fakeEpicCloser struct {
+	closed   []string
+	reasons  []string
+	children []*beads.Issue
+	listErr  error
+	fail     map[string]bool
+}
+
+func (f *fakeEpicCloser) CloseWithReason(reason string, ids ...string) error {
+	for _, id := range ids {
+		if f.fail[id] {
+			return errors.New("refusing")
+		}
+	}
+	f.closed = append(f.closed, ids...)
+	f.reasons = append(f.reasons, reason)
+	return nil
+}
+
+func (f *fakeEpicCloser) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	return f.children, f.listErr
+}
+
+func (f *fakeEpicCloser) BulkCloseWithReason(reason string, ids []string) (closed int, errs []error) {
+	for _, id := range ids {
+		if err := f.CloseWithReason(reason, id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		closed++
+	}
+	return closed, errs
+}
+
+func TestCloseLandedChildren(t *testing.T) {
+	children := []*beads.Issue{
+		{ID: "gt-a", Status: "open"},
+		{ID: "gt-b", Status: "closed"},
+		{ID: "gt-c", Status: "in_progress"},
+	}
+
+	closer := &fakeEpicCloser{children: children}
+	out := captureStdout(t, func() { closeLandedChildren(closer, "gt-epic") })
+	if strings.Join(closer.closed, ",") != "gt-a,gt-c" {
+		t.Errorf("closed = %v, want the open children gt-a and gt-c", closer.closed)
+	}
+	if len(closer.reasons) == 0 || closer.reasons[0] != "Epic gt-epic landed" {
+		t.Errorf("reasons = %v", closer.reasons)
+	}
+	if !strings.Contains(out, "Closed 2 of 2") {
+		t.Errorf("output = %q", out)
+	}
+
+	// One failure is reported without stopping the others
+	closer = &fakeEpicCloser{children: children, fail: map[string]bool{"gt-a": true}}
+	out = captureStdout(t, func() { closeLandedChildren(closer, "gt-epic") })
+	if strings.Join(closer.closed, ",") != "gt-c" {
+		t.Errorf("closed = %v, want [gt-c]", closer.closed)
+	}
+	if !strings.Contains(out, "gt-a: refusing") || !strings.Contains(out, "Closed 1 of 2") {
+		t.Errorf("output should report the gt-a failure: %q", out)
+	}
+
+	closer = &fakeEpicCloser{children: []*beads.Issue{{ID: "gt-b", Status: "closed"}}}
+	out = captureStdout(t, func() { closeLandedChildren(closer, "gt-epic") })
+	if len(closer.closed) != 0 || !strings.Contains(out, "no open children") {
+		t.Errorf("nothing should close: closed=%v output=%q", closer.closed, out)
+	}
+}
+
+// setupInterruptedLand creates a rig whose origin has integration/gt-epic
+// already merged into main, as if a land pushed but stopped before cleanup.
+// Returns the rig path and the origin repo path.
+func setupInterruptedLand(t *testing.T) (string, string) {
+	t.Helper()
+	src := t.TempDir()
+	gitIn(t, src, "init", "--initial-branch=main")
+	gitIn(t, src, "config", "user.email", "test@test.com")
+	gitIn(t, src, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(src, "README.md"), []byte("base\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "base")
+	gitIn(t, src, "checkout", "-b", "integration/gt-epic")
+	if err := os.WriteFile(filepath.Join(src, "feature.txt"), []byte("feature\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "feature")
+	gitIn(t, src, "checkout", "main")
+	gitIn(t, src, "merge", "--no-ff", "-m", "Merge integration/gt-epic", "integration/gt-epic")
+	gitIn(t, src, "branch", "unmerged", "main~1")
+	gitIn(t, src, "checkout", "unmerged")
+	if err := os.WriteFile(filepath.Join(src, "wip.txt"), []byte("wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "wip")
+	gitIn(t, src, "checkout", "main")
+
+	rigPath := t.TempDir()
+	gitIn(t, rigPath, "clone", "--bare", src, ".repo.git")
+	bare := filepath.Join(rigPath, ".repo.git")
+	gitIn(t, bare, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	gitIn(t, bare, "config", "user.email", "test@test.com")
+	gitIn(t, bare, "config", "user.name", "Test User")
+	gitIn(t, bare, "fetch", "origin")
+	return rigPath, src
+}
+
+func TestCreateIntegrationBranch_JSON(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath, src := setupInterruptedLand(t)
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bd := &fakeMRUpdater{descriptions: map[string]string{}}
+	epic := &beads.Issue{ID: "gt-new", Type: "epic", Description: "Ship it."}
+
+	var progress bytes.Buffer
+	result, err := createIntegrationBranch(bd, g, epic, "integration/gt-new", "", "", false, &progress)
+	if err != nil {
+		t.Fatalf("createIntegrationBranch() error = %v\n%s", err, progress.String())
+	}
+
+	var out bytes.Buffer
+	if err := output.FprintFormatted(&out, result, output.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "epic": "gt-new",
+  "branch": "integration/gt-new",
+  "base_branch": "main",
+  "created": true
+}
+`
+	if out.String() != want {
+		t.Errorf("JSON output = %s, want %s", out.String(), want)
+	}
+
+	if !strings.Contains(progress.String(), "Created integration branch") {
+		t.Errorf("progress = %q, want the human summary", progress.String())
+	}
+	if got := getIntegrationBranchField(bd.descriptions["gt-new"]); got != "integration/gt-new" {
+		t.Errorf("epic integration_branch = %q, want integration/gt-new", got)
+	}
+	gitIn(t, src, "rev-parse", "--verify", "refs/heads/integration/gt-new")
+
+	if _, err := createIntegrationBranch(bd, g, epic, "integration/gt-new", "", "", false, io.Discard); err == nil {
+		t.Error("creating the same branch twice should fail")
+	}
+}
+
+func TestCreateIntegrationBranch_FromTagLandsIntoBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath, src := setupInterruptedLand(t)
+	gitIn(t, src, "tag", "v1.0", "main~1")
+	gitIn(t, filepath.Join(rigPath, ".repo.git"), "fetch", "origin", "refs/tags/v1.0:refs/tags/v1.0")
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, branch, target, want string
+	}{
+		{name: "tag base lands into main", branch: "integration/gt-tag", want: "main"},
+		{name: "tag base with --target", branch: "integration/gt-tag-target", target: "release/1.x", want: "release/1.x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bd := &fakeMRUpdater{descriptions: map[string]string{}}
+			epic := &beads.Issue{ID: "gt-tag", Type: "epic"}
+			result, err := createIntegrationBranch(bd, g, epic, tt.branch, "refs/tags/v1.0", tt.target, false, io.Discard)
+			if err != nil {
+				t.Fatalf("createIntegrationBranch() error = %v", err)
+			}
+			if result.BaseBranch != tt.want || result.BaseRef != "refs/tags/v1.0" {
+				t.Errorf("result base_branch = %q, base_ref = %q, want %q and refs/tags/v1.0", result.BaseBranch, result.BaseRef, tt.want)
+			}
+
+			// Land reads base_branch back, so it must name a branch, not the tag
+			epic.Description = bd.descriptions["gt-tag"]
+			got, err := resolveLandTarget(nil, nil, epic)
+			if err != nil {
+				t.Fatalf("resolveLandTarget() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveLandTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveIntegrationLandTarget(t *testing.T) {
+	tests := []struct {
+		base, target, want string
+		wantErr            bool
+	}{
+		{base: "", want: "main"},
+		{base: "develop", want: "develop"},
+		{base: "origin/release/1.x", want: "release/1.x"},
+		{base: "refs/tags/v1.2.0", want: "main"},
+		{base: "abc1234", want: "main"},
+		{base: "refs/tags/v1.2.0", target: "origin/release/1.2", want: "release/1.2"},
+		{base: "develop", target: "main", want: "main"},
+		{target: "refs/tags/v2", wantErr: true},
+		{target: "deadbeef", wantErr: true},
+		{target: "bad..name", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := resolveIntegrationLandTarget(tt.base, tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolveIntegrationLandTarget(%q, %q) error = %v, wantErr %v", tt.base, tt.target, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("resolveIntegrationLandTarget(%q, %q) = %q, want %q", tt.base, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestResolveLandTarget_TagBaseBranch(t *testing.T) {
+	// Older versions of create recorded a tag as base_branch
+	epic := &beads.Issue{ID: "gt-old", Type: "epic", Description: "base_branch: refs/tags/v1.2.0"}
+	if _, err := resolveLandTarget(nil, nil, epic); err == nil || !strings.Contains(err.Error(), "not a branch") {
+		t.Errorf("resolveLandTarget() error = %v, want a not-a-branch error", err)
+	}
+}
+
+func TestRepairLand_AlreadyMerged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath, src := setupInterruptedLand(t)
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldYes, oldDryRun := mqIntegrationLandYes, mqIntegrationLandDryRun
+	mqIntegrationLandYes, mqIntegrationLandDryRun = true, false
+	t.Cleanup(func() { mqIntegrationLandYes, mqIntegrationLandDryRun = oldYes, oldDryRun })
+
+	merged, err := landMerged(g, "integration/gt-epic", "main")
+	if err != nil || !merged {
+		t.Fatalf("landMerged() = %v, %v; want true", merged, err)
+	}
+
+	closer := &fakeEpicCloser{}
+	epic := &beads.Issue{ID: "gt-epic", Title: "Epic", Type: "epic"}
+	if err := repairLand(g, closer, "integration/gt-epic", "main", "epic/gt-epic", epic, landSigning{}); err != nil {
+		t.Fatalf("repairLand() error = %v", err)
+	}
+
+	if len(closer.closed) != 1 || closer.closed[0] != "gt-epic" {
+		t.Errorf("closed = %v, want [gt-epic]", closer.closed)
+	}
+	if want := []string{"Landed integration/gt-epic to main"}; !reflect.DeepEqual(closer.reasons, want) {
+		t.Errorf("close reasons = %q, want %q", closer.reasons, want)
+	}
+	if exists, _ := g.BranchExists("integration/gt-epic"); exists {
+		t.Error("local integration branch should be deleted")
+	}
+	if exists, _ := git.NewGit(src).BranchExists("integration/gt-epic"); exists {
+		t.Error("integration branch should be deleted from origin")
+	}
+	if _, err := git.NewGit(src).Rev("refs/tags/epic/gt-epic"); err != nil {
+		t.Errorf("tag should be pushed to origin: %v", err)
+	}
+
+	// Running repair again finds nothing left to do
+	if err := repairLand(g, closer, "integration/gt-epic", "main", "", epic, landSigning{}); err == nil {
+		t.Error("expected second repair to fail once the branch is gone")
+	}
+}
+
+func TestRepairLand_NotMerged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	rigPath, _ := setupInterruptedLand(t)
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closer := &fakeEpicCloser{}
+	epic := &beads.Issue{ID: "gt-epic", Title: "Epic", Type: "epic"}
+	err = repairLand(g, closer, "unmerged", "main", "", epic, landSigning{})
+	if err == nil || !strings.Contains(err.Error(), "nothing to repair") {
+		t.Fatalf("repairLand() error = %v, want nothing to repair", err)
+	}
+	if len(closer.closed) != 0 {
+		t.Errorf("epic should not be closed, closed = %v", closer.closed)
+	}
+	if exists, _ := g.BranchExists("unmerged"); !exists {
+		t.Error("unmerged branch must not be deleted")
+	}
+}
+
+func TestCheckEpicMail(t *testing.T) {
+	unread := []*mail.Message{
+		{ID: "hq-1", From: "gastown/witness", Subject: "Hold GT-AUTH: review pending"},
+		{ID: "hq-2", From: "mayor/", Subject: "Status update"},
+	}
+
+	t.Run("matching unread mail blocks", func(t *testing.T) {
+		matching, err := checkEpicMail(unread, "gt-auth", false)
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Errorf("checkEpicMail() error = %v, want block mentioning --force", err)
+		}
+		if len(matching) != 1 || matching[0].ID != "hq-1" {
+			t.Errorf("matching = %v, want [hq-1]", matching)
+		}
+	})
+
+	t.Run("force overrides", func(t *testing.T) {
+		matching, err := checkEpicMail(unread, "gt-auth", true)
+		if err != nil {
+			t.Errorf("checkEpicMail(force) error = %v, want nil", err)
+		}
+		if len(matching) != 1 {
+			t.Errorf("matching = %v, want the blocking message still reported", matching)
+		}
+	})
+
+	t.Run("no mail about epic", func(t *testing.T) {
+		matching, err := checkEpicMail(unread, "gt-billing", false)
+		if err != nil || len(matching) != 0 {
+			t.Errorf("checkEpicMail() = %v, %v; want no matches", matching, err)
+		}
+	})
+}
+
+// setupLandTown creates a town with one rig, "myrig", whose origin has an
+// integration branch for gt-epic that lands cleanly on main. A stub bd on
+// PATH knows the epic and reports no open MRs; when BD_CALLS_LOG is set it
+// appends each invocation's arguments there. Returns the rig path.
+func setupLandTown(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub bd is a shell script")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	src := t.TempDir()
+	gitIn(t, src, "init", "--initial-branch=main")
+	gitIn(t, src, "config", "user.email", "test@test.com")
+	gitIn(t, src, "config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(src, "README.md"), []byte("base\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "base")
+	gitIn(t, src, "checkout", "-b", "integration/gt-epic")
+	if err := os.WriteFile(filepath.Join(src, "feature.txt"), []byte("feature\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, src, "add", ".")
+	gitIn(t, src, "commit", "-m", "feature")
+	gitIn(t, src, "checkout", "main")
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	gitIn(t, src, "clone", "--bare", src, origin)
+
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town","version":2,"name":"test"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rigs := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{"myrig": {GitURL: origin}}}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigs); err != nil {
+		t.Fatal(err)
+	}
+
+	rigPath := filepath.Join(townRoot, "myrig")
+	if err := os.MkdirAll(rigPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, rigPath, "clone", "--bare", origin, ".repo.git")
+	bare := filepath.Join(rigPath, ".repo.git")
+	gitIn(t, bare, "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	gitIn(t, bare, "config", "user.email", "test@test.com")
+	gitIn(t, bare, "config", "user.name", "Test User")
+	gitIn(t, bare, "fetch", "origin")
+
+	settings := config.NewRigSettings()
+	settings.MergeQueue = config.DefaultMergeQueueConfig()
+	settings.MergeQueue.TestCommand = "true"
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+[ -n "$BD_CALLS_LOG" ] && echo "$*" >> "$BD_CALLS_LOG"
+cmd=""
+for arg in "$@"; do
+  case "$arg" in
+    --*) ;;
+    *) cmd="$arg"; break ;;
+  esac
+done
+case "$cmd" in
+  show) echo '[{"id":"gt-epic","title":"Epic","issue_type":"epic","status":"open","description":"integration_branch: integration/gt-epic"}]' ;;
+  list) echo '[]' ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(allowedTestCommandsEnv, "")
+
+	return rigPath
+}
+
+func TestLandEmitsEvents(t *testing.T) {
+	rigPath := setupLandTown(t)
+	t.Chdir(rigPath)
+
+	sink := filepath.Join(t.TempDir(), "events.jsonl")
+	t.Setenv(events.SinkEnv, sink)
+
+	oldYes, oldSkip, oldForce := mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce
+	mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce = true, false, false
+	t.Cleanup(func() {
+		mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce = oldYes, oldSkip, oldForce
+	})
+
+	captureStdout(t, func() {
+		if err := runMqIntegrationLand(nil, []string{"gt-epic"}); err != nil {
+			t.Errorf("runMqIntegrationLand() error = %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(sink)
+	if err != nil {
+		t.Fatalf("reading events sink: %v", err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var ev events.Event
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("event line %q is not JSON: %v", line, err)
+		}
+		if ev.Timestamp == "" || ev.Payload["epic"] != "gt-epic" || ev.Payload["branch"] != "integration/gt-epic" {
+			t.Errorf("event %q missing timestamp or epic/branch payload: %s", ev.Type, line)
+		}
+		got = append(got, ev.Type)
+	}
+
+	want := []string{
+		events.TypeLandStarted,
+		events.TypeLandMerged,
+		events.TypeLandTestsPassed,
+		events.TypeLandPushed,
+		events.TypeLandCompleted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("event sequence = %v, want %v", got, want)
+	}
+}
+
+func TestLandClosesEpicWithReason(t *testing.T) {
+	rigPath := setupLandTown(t)
+	t.Chdir(rigPath)
+
+	calls := filepath.Join(t.TempDir(), "bd-calls.log")
+	t.Setenv("BD_CALLS_LOG", calls)
+
+	oldYes, oldSkip, oldForce := mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce
+	mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce = true, true, false
+	t.Cleanup(func() {
+		mqIntegrationLandYes, mqIntegrationLandSkipTests, mqIntegrationLandForce = oldYes, oldSkip, oldForce
+	})
+
+	captureStdout(t, func() {
+		if err := runMqIntegrationLand(nil, []string{"gt-epic"}); err != nil {
+			t.Errorf("runMqIntegrationLand() error = %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatalf("reading bd calls: %v", err)
+	}
+	if !strings.Contains(string(data), "close gt-epic --reason=Landed integration/gt-epic to main") {
+		t.Errorf("bd close was not given the land reason; calls:\n%s", data)
+	}
+}
+
+func TestLandEmitsNoEventsWithoutSink(t *testing.T) {
+	t.Setenv(events.SinkEnv, "")
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	emitLandEvent(events.TypeLandStarted, "gt-epic", "integration/gt-epic", "main", "")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("emitLandEvent wrote files with no sink configured: %v", entries)
+	}
+}
+
+// fakeDiffStater returns canned DiffStat results and records the range.
+type fakeDiffStater struct {
+	changes []git.FileChange
+	err     error
+	calls   []string
+}
+
+func (f *fakeDiffStater) DiffStat(fromRef, toRef string) ([]git.FileChange, error) {
+	f.calls = append(f.calls, fromRef+".."+toRef)
+	return f.changes, f.err
+}
+
+func TestVerifyLandMergeChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       *fakeDiffStater
+		want    int
+		wantErr bool
+	}{
+		{name: "files changed", d: &fakeDiffStater{changes: []git.FileChange{{Path: "a.go", Added: 1, Deleted: 1}}}, want: 1},
+		{name: "empty merge", d: &fakeDiffStater{changes: []git.FileChange{}}, wantErr: true},
+		// A diff that can't be read isn't proof of an empty merge
+		{name: "diff fails", d: &fakeDiffStater{err: errors.New("bad revision")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := verifyLandMergeChanges(tt.d, "integration/gt-auth", "main")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyLandMergeChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(err.Error(), "has NOT been deleted") {
+				t.Errorf("error should say the branch was kept: %v", err)
+			}
+			if len(changes) != tt.want {
+				t.Errorf("got %d changes, want %d", len(changes), tt.want)
+			}
+			if len(tt.d.calls) != 1 || tt.d.calls[0] != "HEAD~1..HEAD" {
+				t.Errorf("calls = %v, want one diff of HEAD~1..HEAD", tt.d.calls)
+			}
+		})
+	}
+}
+
+func TestFormatDiffSummary(t *testing.T) {
+	changes := []git.FileChange{
+		{Path: "a.go", Added: 100, Deleted: 30},
+		{Path: "b.go", Added: 20, Deleted: 4},
+		{Path: "logo.png", Binary: true},
+	}
+	if got, want := formatDiffSummary(changes), "+120 -34 across 3 files"; got != want {
+		t.Errorf("formatDiffSummary() = %q, want %q", got, want)
+	}
+	if got, want := formatDiffSummary(changes[:1]), "+100 -30 across 1 file"; got != want {
+		t.Errorf("formatDiffSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestLandSkipsTests(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name     string
+		runTests *bool
+		skipFlag bool
+		runFlag  bool
+		want     bool
+	}{
+		{name: "unset config, no flags", runTests: nil, want: false},
+		{name: "run_tests true, no flags", runTests: &yes, want: false},
+		{name: "run_tests false, no flags", runTests: &no, want: true},
+		{name: "unset config, --skip-tests", runTests: nil, skipFlag: true, want: true},
+		{name: "run_tests true, --skip-tests", runTests: &yes, skipFlag: true, want: true},
+		{name: "run_tests false, --skip-tests", runTests: &no, skipFlag: true, want: true},
+		{name: "unset config, --run-tests", runTests: nil, runFlag: true, want: false},
+		{name: "run_tests true, --run-tests", runTests: &yes, runFlag: true, want: false},
+		{name: "run_tests false, --run-tests", runTests: &no, runFlag: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := landSkipsTests(tt.runTests, tt.skipFlag, tt.runFlag); got != tt.want {
+				t.Errorf("landSkipsTests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRunTestsSetting(t *testing.T) {
+	write := func(t *testing.T, settings string) string {
+		t.Helper()
+		rigPath := t.TempDir()
+		path := config.RigSettingsPath(rigPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(settings), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return rigPath
+	}
+
+	if got := getRunTestsSetting(t.TempDir()); got != nil {
+		t.Errorf("no settings file: got %v, want nil", *got)
+	}
+	if got := getRunTestsSetting(write(t, `{"merge_queue": {"test_command": "make test"}}`)); got != nil {
+		t.Errorf("run_tests unset: got %v, want nil", *got)
+	}
+	if got := getRunTestsSetting(write(t, `{"merge_queue": {"run_tests": false}}`)); got == nil || *got {
+		t.Errorf("run_tests false: got %v, want false", got)
+	}
+	if got := getRunTestsSetting(write(t, `{"merge_queue": {"run_tests": true}}`)); got == nil || !*got {
+		t.Errorf("run_tests true: got %v, want true", got)
+	}
+}
+
+// fakeContainsChecker answers BranchContains from a set of branch@commit keys.
+type fakeContainsChecker struct {
+	contains map[string]bool
+	err      error
+	fetchErr error
+	fetched  []string
+}
+
+func (f *fakeContainsChecker) FetchBranch(remote, branch string) error {
+	f.fetched = append(f.fetched, remote+"/"+branch)
+	return f.fetchErr
+}
+
+func (f *fakeContainsChecker) BranchContains(branch, commit string) (bool, error) {
+	return f.contains[branch+"@"+commit], f.err
+}
+
+func TestVerifyLandContains(t *testing.T) {
+	// Only the remote-tracking branch counts; the local one always has tip
+	c := &fakeContainsChecker{contains: map[string]bool{
+		"origin/main@abc1234def": true,
+		"main@fff0000aaa":        true,
+	}}
+	if err := verifyLandContains(c, "main", "integration/gt-epic", "abc1234def"); err != nil {
+		t.Errorf("verifyLandContains() error = %v, want nil", err)
+	}
+	if len(c.fetched) != 1 || c.fetched[0] != "origin/main" {
+		t.Errorf("fetched = %v, want [origin/main]", c.fetched)
+	}
+	err := verifyLandContains(c, "main", "integration/gt-epic", "fff0000aaa")
+	if err == nil || !strings.Contains(err.Error(), "did not land") {
+		t.Errorf("verifyLandContains() error = %v, want a did-not-land error", err)
+	}
+	c.err = errors.New("bad object")
+	if err := verifyLandContains(c, "main", "integration/gt-epic", "abc1234def"); err == nil {
+		t.Error("verifyLandContains() should fail when git can't answer")
+	}
+	c.err, c.fetchErr = nil, errors.New("network down")
+	if err := verifyLandContains(c, "main", "integration/gt-epic", "abc1234def"); err == nil {
+		t.Error("verifyLandContains() should fail when origin can't be re-fetched")
+	}
+}
+
+func TestVerifyLandContains_PushDidNotUpdateRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	remote := t.TempDir()
+	gitIn(t, remote, "init", "--bare", "--initial-branch=main")
+	work := t.TempDir()
+	gitIn(t, work, "clone", remote, ".")
+	gitIn(t, work, "config", "user.email", "test@test.com")
+	gitIn(t, work, "config", "user.name", "Test User")
+	gitIn(t, work, "commit", "--allow-empty", "-m", "base")
+	gitIn(t, work, "push", "origin", "main")
+
+	// The merge exists locally, but the push never reached origin
+	gitIn(t, work, "commit", "--allow-empty", "-m", "merge integration/gt-epic")
+	g := git.NewGit(work)
+	tip, err := g.Rev("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = verifyLandContains(g, "main", "integration/gt-epic", tip)
+	if err == nil || !strings.Contains(err.Error(), "did not land") {
+		t.Fatalf("verifyLandContains() error = %v, want a did-not-land error", err)
+	}
+
+	gitIn(t, work, "push", "origin", "main")
+	if err := verifyLandContains(g, "main", "integration/gt-epic", tip); err != nil {
+		t.Errorf("verifyLandContains() after push error = %v, want nil", err)
+	}
+}
+
+func TestRunLandTests_FakeRunner(t *testing.T) {
+	var gotDir, gotCmd string
+	var gotTimeout time.Duration
+	pass := func(workDir, testCmd string, timeout time.Duration) error {
+		gotDir, gotCmd, gotTimeout = workDir, testCmd, timeout
+		return nil
+	}
+	fail := func(string, string, time.Duration) error { return errors.New("exit status 1") }
+	timedOut := func(string, string, time.Duration) error { return errTestTimeout }
+	mustNotRun := func(string, string, time.Duration) error {
+		t.Error("test command should not run")
+		return nil
+	}
+
+	if ran, err := runLandTests(pass, "/land", "make test", time.Minute, false); err != nil || !ran {
+		t.Errorf("passing tests: ran=%v err=%v, want ran and no error", ran, err)
+	}
+	if gotDir != "/land" || gotCmd != "make test" || gotTimeout != time.Minute {
+		t.Errorf("runner got (%q, %q, %s)", gotDir, gotCmd, gotTimeout)
+	}
+	if ran, err := runLandTests(fail, "/land", "make test", 0, false); ran || err == nil || !strings.Contains(err.Error(), "tests failed") {
+		t.Errorf("failing tests: ran=%v err=%v, want tests failed error", ran, err)
+	}
+	if _, err := runLandTests(timedOut, "/land", "make test", 0, false); !errors.Is(err, errTestTimeout) {
+		t.Errorf("timed out tests: err=%v, want errTestTimeout", err)
+	}
+	if ran, err := runLandTests(mustNotRun, "/land", "make test", 0, true); ran || err != nil {
+		t.Errorf("--skip-tests: ran=%v err=%v", ran, err)
+	}
+	if ran, err := runLandTests(mustNotRun, "/land", "", 0, false); ran || err != nil {
+		t.Errorf("no test command: ran=%v err=%v", ran, err)
+	}
+}
+
+func TestResetHardAndMergeInProgress_FakeRunner(t *testing.T) {
+	r := &fakeRunner{results: map[string]fakeRunResult{
+		"git reset --hard origin/main": {},
+	}}
+	if err := resetHard(r, "/repo", "origin/main"); err != nil {
+		t.Errorf("resetHard() error = %v", err)
+	}
+	if err := resetHard(r, "/repo", "nope"); err == nil {
+		t.Error("resetHard() should fail when git reset fails")
+	}
+
+	// rev-parse --verify MERGE_HEAD fails when no merge is in progress
+	if landMergeInProgress(r, "/land") {
+		t.Error("landMergeInProgress() = true when MERGE_HEAD doesn't resolve")
+	}
+	r.results["git rev-parse -q --verify MERGE_HEAD"] = fakeRunResult{stdout: "abc123"}
+	if !landMergeInProgress(r, "/land") {
+		t.Error("landMergeInProgress() = false when MERGE_HEAD resolves")
+	}
+}
+
+// fakeHistoryReader returns canned history for any ID.
+type fakeHistoryReader struct {
+	changes []beads.StateChange
+	err     error
+}
+
+func (f fakeHistoryReader) History(id string) ([]beads.StateChange, error) {
+	return f.changes, f.err
+}
+
+func TestPrintRecentStatusHistory(t *testing.T) {
+	h := fakeHistoryReader{changes: []beads.StateChange{
+		{Timestamp: "t1", Actor: "mayor", Field: "created", To: "open"},
+		{Timestamp: "t2", Actor: "mayor", Field: "priority", From: "P2", To: "P1"},
+		{Timestamp: "t3", Actor: "gastown/refinery", Field: "status", From: "open", To: "closed"},
+		{Timestamp: "t4", Actor: "gastown/crew/max", Field: "status", From: "closed", To: "open"},
+	}}
+	out := captureStdout(t, func() { printRecentStatusHistory(h, "gt-epic", 2) })
+	if !strings.Contains(out, "status: open → closed") || !strings.Contains(out, "status: closed → open") {
+		t.Errorf("output should show the last two status changes:\n%s", out)
+	}
+	if strings.Contains(out, "created") || strings.Contains(out, "priority") {
+		t.Errorf("output should be limited to recent status changes:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		printRecentStatusHistory(fakeHistoryReader{err: beads.ErrHistoryNotSupported}, "gt-epic", 5)
+	})
+	if !strings.Contains(out, "history not supported") {
+		t.Errorf("unsupported backend should be noted, got:\n%s", out)
+	}
+}
+
+func TestRenderIntegrationStatus_Quiet(t *testing.T) {
+	notReady := &IntegrationStatusOutput{Epic: "gt-epic", Branch: "integration/gt-epic"}
+	var err error
+	out := captureStdout(t, func() { err = renderIntegrationStatus(notReady, true) })
+	if out != "" {
+		t.Errorf("quiet mode printed output:\n%s", out)
+	}
+	if code, ok := IsSilentExit(err); !ok || code != 1 {
+		t.Errorf("not ready: err = %v, want silent exit 1", err)
+	}
+
+	ready := &IntegrationStatusOutput{Epic: "gt-epic", Branch: "integration/gt-epic", ReadyToLand: true}
+	out = captureStdout(t, func() { err = renderIntegrationStatus(ready, true) })
+	if out != "" || err != nil {
+		t.Errorf("ready: output %q, err %v; want no output and nil error", out, err)
+	}
+
+	out = captureStdout(t, func() { err = renderIntegrationStatus(ready, false) })
+	if err != nil || !strings.Contains(out, "gt-epic") {
+		t.Errorf("non-quiet mode should print the status: err %v, output:\n%s", err, out)
+	}
+}
+
+// fakeBaseRefresher reports whether origin/<target> is contained in HEAD.
+type fakeBaseRefresher struct {
+	contained bool
+	fetchErr  error
+	fetched   []string
+}
+
+func (f *fakeBaseRefresher) FetchBranch(remote, branch string) error {
+	f.fetched = append(f.fetched, remote+"/"+branch)
+	return f.fetchErr
+}
+
+func (f *fakeBaseRefresher) IsAncestor(ancestor, descendant string) (bool, error) {
+	if ancestor != "origin/main" || descendant != "HEAD" {
+		return false, fmt.Errorf("unexpected IsAncestor(%s, %s)", ancestor, descendant)
+	}
+	return f.contained, nil
+}
+
+func TestCheckLandBase(t *testing.T) {
+	g := &fakeBaseRefresher{contained: true}
+	if err := checkLandBase(g, "main"); err != nil {
+		t.Errorf("up-to-date base: error = %v", err)
+	}
+	if want := []string{"origin/main"}; !reflect.DeepEqual(g.fetched, want) {
+		t.Errorf("fetched = %v, want %v", g.fetched, want)
+	}
+
+	err := checkLandBase(&fakeBaseRefresher{}, "main")
+	if err == nil || !strings.Contains(err.Error(), "advanced on origin") || !strings.Contains(err.Error(), "re-run") {
+		t.Errorf("advanced base: error = %v, want target advanced", err)
+	}
+
+	err = checkLandBase(&fakeBaseRefresher{contained: true, fetchErr: errors.New("timeout")}, "main")
+	if err == nil || !strings.Contains(err.Error(), "re-fetching origin/main") {
+		t.Errorf("fetch failure: error = %v", err)
+	}
+}
+
+// fakePusher fails its first len(failures) pushes with those errors.
+type fakePusher struct {
+	failures []error
+	pushes   int
+}
+
+func (f *fakePusher) Push(remote, branch string, force bool) error {
+	f.pushes++
+	if f.pushes <= len(f.failures) {
+		return f.failures[f.pushes-1]
+	}
+	return nil
+}
+
+func pushError(stderr string) error {
+	return &git.GitError{Command: "push", Stderr: stderr, Err: errors.New("exit status 1")}
+}
+
+func TestClassifyPushError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want pushFailure
+	}{
+		{pushError(" ! [rejected]        main -> main (fetch first)\nerror: failed to push some refs"), pushRejected},
+		{pushError(" ! [rejected]        main -> main (non-fast-forward)"), pushRejected},
+		{pushError("fatal: unable to access 'https://example.com/': Could not resolve host: example.com"), pushTransient},
+		{pushError("fatal: the remote end hung up unexpectedly"), pushTransient},
+		{fmt.Errorf("%w: git push origin main", git.ErrNetworkTimeout), pushTransient},
+		{pushError(" ! [remote rejected] main -> main (protected branch hook declined)"), pushFatal},
+		{pushError("remote: Permission to org/repo.git denied"), pushFatal},
+	}
+	for _, tt := range tests {
+		if got := classifyPushError(tt.err); got != tt.want {
+			t.Errorf("classifyPushError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestPushLandWithRetry(t *testing.T) {
+	network := pushError("fatal: the remote end hung up unexpectedly")
+	rejected := pushError(" ! [rejected]        main -> main (fetch first)")
+
+	var slept []time.Duration
+	sleep := func(d time.Duration) { slept = append(slept, d) }
+	rebuilds := 0
+	rebuild := func() error {
+		rebuilds++
+		return nil
+	}
+
+	// Fails twice, then succeeds within the cap
+	p := &fakePusher{failures: []error{network, rejected}}
+	if err := pushLandWithRetry(p, "main", 2, sleep, rebuild); err != nil {
+		t.Fatalf("pushLandWithRetry() error = %v, want eventual success", err)
+	}
+	if p.pushes != 3 || rebuilds != 1 {
+		t.Errorf("pushes = %d, rebuilds = %d; want 3 pushes and 1 rebuild", p.pushes, rebuilds)
+	}
+	if want := []time.Duration{pushRetryBaseDelay}; !reflect.DeepEqual(slept, want) {
+		t.Errorf("slept %v, want %v", slept, want)
+	}
+
+	// Gives up after the cap
+	slept = nil
+	p = &fakePusher{failures: []error{network, network, network, network}}
+	if err := pushLandWithRetry(p, "main", 2, sleep, rebuild); err == nil {
+		t.Fatal("pushLandWithRetry() should fail once retries are exhausted")
+	}
+	if p.pushes != 3 {
+		t.Errorf("pushes = %d, want 3 (1 + 2 retries)", p.pushes)
+	}
+	if want := []time.Duration{pushRetryBaseDelay, 2 * pushRetryBaseDelay}; !reflect.DeepEqual(slept, want) {
+		t.Errorf("slept %v, want doubling backoff %v", slept, want)
+	}
+
+	// Fatal errors aren't retried
+	p = &fakePusher{failures: []error{pushError("remote: Permission denied")}}
+	if err := pushLandWithRetry(p, "main", 2, sleep, rebuild); err == nil || p.pushes != 1 {
+		t.Errorf("fatal push: err = %v after %d pushes, want one failed push", err, p.pushes)
+	}
+
+	// A failed rebuild stops the retry
+	p = &fakePusher{failures: []error{rejected}}
+	err := pushLandWithRetry(p, "main", 2, sleep, func() error { return errors.New("tests failed") })
+	if err == nil || !strings.Contains(err.Error(), "rebuilding land") || p.pushes != 1 {
+		t.Errorf("failed rebuild: err = %v after %d pushes", err, p.pushes)
+	}
+
+	// Without a rebuild, a rejected push isn't retried
+	p = &fakePusher{failures: []error{rejected}}
+	if err := pushLandWithRetry(p, "main", 2, sleep, nil); err == nil || p.pushes != 1 {
+		t.Errorf("nil rebuild: err = %v after %d pushes, want one failed push", err, p.pushes)
+	}
+}
+
+// fakeLandRebaser is a fakeBaseRefresher with a land worktree.
+type fakeLandRebaser struct {
+	fakeBaseRefresher
+}
+
+func (f *fakeLandRebaser) WorkDir() string { return "/land" }
+
+func TestRebuildLand_RerunsTestsAfterRejectedPush(t *testing.T) {
+	r := &fakeRunner{results: map[string]fakeRunResult{
+		"git reset --hard origin/main": {},
+	}}
+	g := &fakeLandRebaser{}
+
+	testRuns := 0
+	runTests := func(string, string, time.Duration) error {
+		testRuns++
+		return nil
+	}
+	build := func() error {
+		_, err := runLandTests(runTests, "/land", "make test", 0, false)
+		return err
+	}
+	if err := build(); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &fakePusher{failures: []error{pushError(" ! [rejected]        main -> main (fetch first)")}}
+	if err := pushLandWithRetry(p, "main", 2, func(time.Duration) {}, rebuildLand(g, r, "main", false, build)); err != nil {
+		t.Fatalf("pushLandWithRetry() error = %v", err)
+	}
+	if testRuns != 2 {
+		t.Errorf("tests ran %d times, want 2 (again after the rejected push)", testRuns)
+	}
+	if want := []string{"origin/main"}; !reflect.DeepEqual(g.fetched, want) {
+		t.Errorf("fetched = %v, want %v", g.fetched, want)
+	}
+
+	// Tests failing on the new target stop the push
+	p = &fakePusher{failures: []error{pushError(" ! [rejected]        main -> main (fetch first)")}}
+	failing := func() error {
+		_, err := runLandTests(func(string, string, time.Duration) error { return errors.New("exit status 1") }, "/land", "make test", 0, false)
+		return err
+	}
+	err := pushLandWithRetry(p, "main", 2, func(time.Duration) {}, rebuildLand(g, r, "main", false, failing))
+	if err == nil || !strings.Contains(err.Error(), "tests failed") || p.pushes != 1 {
+		t.Errorf("failing re-test: err = %v after %d pushes", err, p.pushes)
+	}
+
+	// --strict-base refuses to rebuild on a moved target
+	strict := &fakeLandRebaser{}
+	err = rebuildLand(strict, r, "main", true, func() error {
+		t.Error("build should not run under --strict-base")
+		return nil
+	})()
+	if err == nil || !strings.Contains(err.Error(), "advanced on origin") {
+		t.Errorf("strict: err = %v, want target advanced", err)
+	}
+}
+
+func TestGetLandSigning(t *testing.T) {
+	write := func(t *testing.T, settings string) string {
+		t.Helper()
+		rigPath := t.TempDir()
+		path := config.RigSettingsPath(rigPath)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(settings), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return rigPath
+	}
+
+	tests := []struct {
+		name     string
+		rigPath  string
+		signFlag bool
+		want     landSigning
+	}{
+		{"no settings", t.TempDir(), false, landSigning{}},
+		{"no settings, --sign", t.TempDir(), true, landSigning{Sign: true}},
+		{"sign_commits", write(t, `{"merge_queue": {"sign_commits": true, "signing_key": "ABCD1234"}}`), false, landSigning{Sign: true, Key: "ABCD1234"}},
+		{"--sign with key from settings", write(t, `{"merge_queue": {"signing_key": "ABCD1234"}}`), true, landSigning{Sign: true, Key: "ABCD1234"}},
+		{"key alone doesn't sign", write(t, `{"merge_queue": {"signing_key": "ABCD1234"}}`), false, landSigning{Key: "ABCD1234"}},
+	}
+	for _, tt := range tests {
+		if got := getLandSigning(tt.rigPath, tt.signFlag); got != tt.want {
+			t.Errorf("%s: getLandSigning() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeLandMerger records which merge landMerge chose.
+type fakeLandMerger struct {
+	merged string
+	err    error
+}
+
+func (f *fakeLandMerger) MergeNoFF(branch, message string) error {
+	f.merged = "unsigned " + branch
+	return f.err
+}
+
+func (f *fakeLandMerger) MergeNoFFSigned(branch, message, key string) error {
+	f.merged = "signed " + branch + " by " + key
+	return f.err
+}
+
+func TestLandMerge(t *testing.T) {
+	f := &fakeLandMerger{}
+	if err := landMerge(f, "origin/integration/gt-auth", "msg", landSigning{}); err != nil {
+		t.Fatal(err)
+	}
+	if f.merged != "unsigned origin/integration/gt-auth" {
+		t.Errorf("merged = %q, want an unsigned merge", f.merged)
+	}
+
+	if err := landMerge(f, "origin/integration/gt-auth", "msg", landSigning{Sign: true, Key: "ABCD1234"}); err != nil {
+		t.Fatal(err)
+	}
+	if f.merged != "signed origin/integration/gt-auth by ABCD1234" {
+		t.Errorf("merged = %q, want a merge signed by ABCD1234", f.merged)
+	}
+
+	f.err = &git.GitError{Command: "merge", Stderr: "error: gpg failed to sign the data\nfatal: failed to write commit object"}
+	err := landMerge(f, "origin/integration/gt-auth", "msg", landSigning{Sign: true, Key: "ABCD1234"})
+	if err == nil || !strings.Contains(err.Error(), "could not GPG-sign the merge commit with key ABCD1234") {
+		t.Errorf("landMerge() error = %v, want a signing failure naming the key", err)
+	}
+}
+
+func TestSigningFailure(t *testing.T) {
+	gpgErr := &git.GitError{Command: "tag", Stderr: "error: gpg failed to sign the data\nerror: unable to sign the tag"}
+	err := signingFailure(gpgErr, "tag", "")
+	if err == nil || !strings.Contains(err.Error(), "could not GPG-sign the tag with the default signing key") {
+		t.Errorf("signingFailure(gpg error) = %v, want a signing failure", err)
+	}
+	if !errors.Is(err, gpgErr) {
+		t.Error("signingFailure should wrap the git error")
+	}
+
+	conflict := &git.GitError{Command: "merge", Stderr: "CONFLICT (content): Merge conflict in main.go"}
+	if got := signingFailure(conflict, "merge commit", ""); got != conflict {
+		t.Errorf("signingFailure(conflict) = %v, want the error unchanged", got)
+	}
+	plain := errors.New("boom")
+	if got := signingFailure(plain, "merge commit", ""); got != plain {
+		t.Errorf("signingFailure(non-git error) = %v, want it unchanged", got)
+	}
+	if signingFailure(nil, "tag", "") != nil {
+		t.Error("signingFailure(nil) should be nil")
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/output"
+	"github.com/steveyegge/gastown/internal/style"
+	"golang.org/x/term"
+)
+
+// runMqIntegrationStatusWatch re-renders integration status every
+// --interval, highlighting what changed since the previous refresh.
+// Each tick rebuilds the status from bd and git, so nothing is served stale.
+func runMqIntegrationStatusWatch(epicID string, format output.Format, sinceWindow time.Duration) error {
+	if format != output.FormatText {
+		return fmt.Errorf("--watch cannot be used with --format %s", format)
+	}
+	if mqIntegrationStatusOutputFile != "" {
+		return fmt.Errorf("--watch and --output-file cannot be used together")
+	}
+	if err := validateWatchInterval(time.Duration(mqIntegrationStatusInterval)); err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	ticker := time.NewTicker(time.Duration(mqIntegrationStatusInterval))
+	defer ticker.Stop()
+
+	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+
+	var prev *IntegrationStatusOutput
+	for {
+		if isTTY {
+			fmt.Print("\033[H\033[2J") // ANSI: cursor home + clear screen
+		}
+
+		timestamp := time.Now().Format("15:04:05")
+		header := fmt.Sprintf("[%s] gt mq integration status %s --watch (every %s, Ctrl+C to stop)",
+			timestamp, epicID, mqIntegrationStatusInterval.String())
+		if isTTY {
+			fmt.Printf("%s\n\n", style.Dim.Render(header))
+		} else {
+			fmt.Printf("%s\n\n", header)
+		}
+
+		status, err := buildIntegrationStatus(epicID, sinceWindow)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			for _, change := range integrationStatusTransitions(prev, status) {
+				fmt.Printf("%s %s\n", style.Success.Render("➜"), change)
+			}
+			if err := printIntegrationStatus(status); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+			prev = status
+		}
+
+		select {
+		case <-sigChan:
+			if isTTY {
+				fmt.Println("\nStopped.")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// integrationStatusTransitions describes the notable changes between two
+// refreshes: children closing, pending MRs merging, and the branch becoming
+// ready to land. Returns nil on the first refresh (prev == nil).
+func integrationStatusTransitions(prev, cur *IntegrationStatusOutput) []string {
+	if prev == nil || cur == nil {
+		return nil
+	}
+
+	var changes []string
+	if closed := cur.ChildrenClosed - prev.ChildrenClosed; closed > 0 {
+		changes = append(changes, fmt.Sprintf("%d child issue(s) closed (%d/%d)",
+			closed, cur.ChildrenClosed, cur.ChildrenTotal))
+	}
+
+	merged := make(map[string]bool, len(cur.MergedMRs))
+	for _, mr := range cur.MergedMRs {
+		merged[mr.ID] = true
+	}
+	for _, mr := range prev.PendingMRs {
+		if merged[mr.ID] {
+			changes = append(changes, fmt.Sprintf("MR %s merged: %s", mr.ID, mr.Title))
+		}
+	}
+
+	if cur.ReadyToLand && !prev.ReadyToLand {
+		changes = append(changes, "Integration branch is now ready to land")
+	}
+	return changes
+}
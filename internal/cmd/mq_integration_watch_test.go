@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+func TestRunMqIntegrationStatusWatch_RejectsBadInterval(t *testing.T) {
+	oldInterval := mqIntegrationStatusInterval
+	oldWatch := mqIntegrationStatusWatch
+	defer func() {
+		mqIntegrationStatusInterval = oldInterval
+		mqIntegrationStatusWatch = oldWatch
+	}()
+	t.Setenv(output.FormatEnv, "")
+	mqIntegrationStatusWatch = true
+
+	for _, interval := range []watchInterval{0, watchInterval(-5 * time.Second)} {
+		mqIntegrationStatusInterval = interval
+		err := runMqIntegrationStatus(mqIntegrationStatusCmd, []string{"gt-epic"})
+		if err == nil {
+			t.Fatalf("interval %s: expected error, got nil", interval.String())
+		}
+		if !strings.Contains(err.Error(), "positive") {
+			t.Errorf("interval %s: error %q should mention 'positive'", interval.String(), err.Error())
+		}
+	}
+}
+
+func TestMqIntegrationStatusCmd_IntervalFlag(t *testing.T) {
+	oldInterval := mqIntegrationStatusInterval
+	defer func() { mqIntegrationStatusInterval = oldInterval }()
+
+	flag := mqIntegrationStatusCmd.Flags().Lookup("interval")
+	if flag == nil || flag.Value.Type() != "duration" {
+		t.Fatalf("--interval flag = %+v, want a duration", flag)
+	}
+	for in, want := range map[string]time.Duration{"30": 30 * time.Second, "1m": time.Minute, "500ms": 500 * time.Millisecond} {
+		if err := flag.Value.Set(in); err != nil {
+			t.Errorf("--interval %s: %v", in, err)
+			continue
+		}
+		if got := time.Duration(mqIntegrationStatusInterval); got != want {
+			t.Errorf("--interval %s = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRunMqIntegrationStatusWatch_RejectsJSON(t *testing.T) {
+	oldInterval := mqIntegrationStatusInterval
+	defer func() { mqIntegrationStatusInterval = oldInterval }()
+	mqIntegrationStatusInterval = watchInterval(5 * time.Second)
+
+	err := runMqIntegrationStatusWatch("gt-epic", output.FormatJSON, 0)
+	if err == nil || !strings.Contains(err.Error(), "--watch") {
+		t.Errorf("expected --watch/--format error, got %v", err)
+	}
+}
+
+func TestIntegrationStatusTransitions(t *testing.T) {
+	prev := &IntegrationStatusOutput{
+		ChildrenTotal:  3,
+		ChildrenClosed: 1,
+		PendingMRs: []IntegrationStatusMRSummary{
+			{ID: "mr-1", Title: "auth tokens"},
+			{ID: "mr-2", Title: "login form"},
+		},
+	}
+
+	t.Run("first refresh has no transitions", func(t *testing.T) {
+		if got := integrationStatusTransitions(nil, prev); got != nil {
+			t.Errorf("transitions = %v, want nil", got)
+		}
+	})
+
+	t.Run("no change", func(t *testing.T) {
+		if got := integrationStatusTransitions(prev, prev); len(got) != 0 {
+			t.Errorf("transitions = %v, want none", got)
+		}
+	})
+
+	t.Run("child closed, MR merged, ready to land", func(t *testing.T) {
+		cur := &IntegrationStatusOutput{
+			ChildrenTotal:  3,
+			ChildrenClosed: 3,
+			MergedMRs:      []IntegrationStatusMRSummary{{ID: "mr-1", Title: "auth tokens"}, {ID: "mr-2", Title: "login form"}},
+			ReadyToLand:    true,
+		}
+		want := []string{
+			"2 child issue(s) closed (3/3)",
+			"MR mr-1 merged: auth tokens",
+			"MR mr-2 merged: login form",
+			"Integration branch is now ready to land",
+		}
+		if got := integrationStatusTransitions(prev, cur); !reflect.DeepEqual(got, want) {
+			t.Errorf("transitions = %q, want %q", got, want)
+		}
+	})
+}
@@ -25,10 +25,10 @@ func runMQList(cmd *cobra.Command, args []string) error {
 	// Create beads wrapper for the rig - use BeadsPath() to get the git-synced location
 	b := beads.New(r.BeadsPath())
 
-	// Build list options - query for merge-request type
+	// Build list options - query by the merge-request label
 	// Priority -1 means no priority filter (otherwise 0 would filter to P0 only)
 	opts := beads.ListOptions{
-		Type:     "merge-request",
+		Label:    "gt:merge-request",
 		Priority: -1,
 	}
 
@@ -48,9 +48,9 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("querying ready MRs: %w", err)
 		}
-		// Filter to only merge-request label (issue_type field is deprecated)
+		// Filter to merge requests (label first; issue_type field is deprecated)
 		for _, issue := range allReady {
-			if beads.HasLabel(issue, "gt:merge-request") {
+			if beads.IsMergeRequest(issue) {
 				issues = append(issues, issue)
 			}
 		}
@@ -61,6 +61,11 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Filter by worker
+	if mqListWorker != "" {
+		issues = filterMRsByWorker(issues, mqListWorker)
+	}
+
 	// Apply additional filters and calculate scores
 	now := time.Now()
 	type scoredIssue struct {
@@ -90,17 +95,6 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		// Parse MR fields
 		fields := beads.ParseMRFields(issue)
 
-		// Filter by worker
-		if mqListWorker != "" {
-			worker := ""
-			if fields != nil {
-				worker = fields.Worker
-			}
-			if !strings.EqualFold(worker, mqListWorker) {
-				continue
-			}
-		}
-
 		// Filter by epic (target branch)
 		if mqListEpic != "" {
 			target := ""
@@ -15,7 +15,6 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
-	"github.com/steveyegge/gastown/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -76,17 +75,12 @@ func parseBranchName(branch string) branchInfo {
 }
 
 func runMqSubmit(cmd *cobra.Command, args []string) error {
-	// Find workspace
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
-	}
-
-	// Find current rig
-	rigName, _, err := findCurrentRig(townRoot)
+	// Find the rig (--rig-path, or the one containing cwd)
+	townRoot, r, err := resolveMQRig()
 	if err != nil {
 		return err
 	}
+	rigName := r.Name
 
 	// Initialize git for the current directory
 	cwd, err := os.Getwd()
@@ -106,7 +100,7 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 
 	// Get configured default branch for this rig
 	defaultBranch := "main" // fallback
-	if rigCfg, err := rig.LoadRigConfig(filepath.Join(townRoot, rigName)); err == nil && rigCfg.DefaultBranch != "" {
+	if rigCfg, err := rig.LoadRigConfig(r.Path); err == nil && rigCfg.DefaultBranch != "" {
 		defaultBranch = rigCfg.DefaultBranch
 	}
 
@@ -128,27 +122,29 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot determine source issue from branch '%s'; use --issue to specify", branch)
 	}
 
-	// Initialize beads for looking up source issue
+	// Initialize beads for looking up source issue. Lookups go through a
+	// cache so the source issue read by the parent-chain walk is reused.
 	bd := beads.New(cwd)
+	issues := beads.NewIssueCache(bd)
 
 	// Determine target branch
 	target := defaultBranch
 	if mqSubmitEpic != "" {
 		// Explicit --epic flag: resolve branch name via configured template
-		rigPath := filepath.Join(townRoot, rigName)
+		rigPath := r.Path
 		template := getIntegrationBranchTemplate(rigPath, "")
 		target = buildIntegrationBranchName(template, mqSubmitEpic)
 	} else {
 		// Auto-detect: check if source issue has a parent epic with an integration branch
 		// Only if refinery integration branch auto-targeting is enabled
 		refineryEnabled := true
-		rigPath := filepath.Join(townRoot, rigName)
+		rigPath := r.Path
 		settingsPath := filepath.Join(rigPath, "settings", "config.json")
 		if settings, err := config.LoadRigSettings(settingsPath); err == nil && settings.MergeQueue != nil {
 			refineryEnabled = settings.MergeQueue.IsRefineryIntegrationEnabled()
 		}
 		if refineryEnabled {
-			autoTarget, err := beads.DetectIntegrationBranch(bd, g, issueID)
+			autoTarget, err := beads.DetectIntegrationBranch(issues, g, issueID)
 			if err != nil {
 				// Non-fatal: log and continue with default branch as target
 				fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(note: %v)", err)))
@@ -164,7 +160,7 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		priority = mqSubmitPriority
 	} else {
 		// Try to inherit from source issue
-		sourceIssue, err := bd.Show(issueID)
+		sourceIssue, err := issues.Show(issueID)
 		if err != nil {
 			// Issue not found, use default priority
 			priority = 2
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -424,3 +427,79 @@ func TestMRFilteringByLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestRigFromPath(t *testing.T) {
+	t.Run("bare repo rig", func(t *testing.T) {
+		rigPath := filepath.Join(t.TempDir(), "ci-checkout")
+		if err := os.MkdirAll(filepath.Join(rigPath, ".repo.git"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(`{"type":"rig","name":"gastown","git_url":"https://example.com/gastown.git"}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		townRoot, r, err := rigFromPath(rigPath)
+		if err != nil {
+			t.Fatalf("rigFromPath() error = %v", err)
+		}
+		if r.Path != rigPath || r.Name != "gastown" || r.GitURL != "https://example.com/gastown.git" {
+			t.Errorf("rig = %+v, want gastown at %s", r, rigPath)
+		}
+		if townRoot != filepath.Dir(rigPath) {
+			t.Errorf("townRoot = %q, want the rig's parent %q", townRoot, filepath.Dir(rigPath))
+		}
+	})
+
+	t.Run("mayor clone rig without config", func(t *testing.T) {
+		rigPath := filepath.Join(t.TempDir(), "beads")
+		if err := os.MkdirAll(filepath.Join(rigPath, "mayor", "rig"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		_, r, err := rigFromPath(rigPath)
+		if err != nil {
+			t.Fatalf("rigFromPath() error = %v", err)
+		}
+		if r.Name != "beads" {
+			t.Errorf("rig name = %q, want the directory name", r.Name)
+		}
+	})
+
+	t.Run("not a rig", func(t *testing.T) {
+		_, _, err := rigFromPath(t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "is not a rig") || !strings.Contains(err.Error(), "no repo base found") {
+			t.Errorf("rigFromPath(empty dir) error = %v, want not a rig", err)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "nope")
+		_, _, err := rigFromPath(missing)
+		if err == nil || !strings.Contains(err.Error(), "--rig-path "+missing) {
+			t.Errorf("rigFromPath(missing) error = %v, want it to name the path", err)
+		}
+	})
+}
+
+func TestResolveMQRig_RigPathOverride(t *testing.T) {
+	rigPath := filepath.Join(t.TempDir(), "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, ".repo.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	saved := mqRigPath
+	t.Cleanup(func() { mqRigPath = saved })
+
+	// The working directory is not in a town; the override must be used
+	t.Chdir(t.TempDir())
+	mqRigPath = rigPath
+	_, r, err := resolveMQRig()
+	if err != nil {
+		t.Fatalf("resolveMQRig() error = %v", err)
+	}
+	if r.Path != rigPath {
+		t.Errorf("rig path = %q, want %q", r.Path, rigPath)
+	}
+
+	mqRigPath = ""
+	if _, _, err := resolveMQRig(); err == nil || !strings.Contains(err.Error(), "--rig-path") {
+		t.Errorf("resolveMQRig() outside a town error = %v, want a hint about --rig-path", err)
+	}
+}
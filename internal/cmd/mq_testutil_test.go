@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/steveyegge/gastown/internal/beads"
 )
 
@@ -45,11 +48,39 @@ func (m *mockBeads) List(opts beads.ListOptions) ([]*beads.Issue, error) {
 		if opts.Status != "" && issue.Status != opts.Status {
 			continue
 		}
+		if opts.Priority >= 0 && issue.Priority != opts.Priority {
+			continue
+		}
+		if !matchesLabelFilters(issue, opts) {
+			continue
+		}
 		result = append(result, issue)
 	}
 	return result, nil
 }
 
+// matchesLabelFilters applies the Label, Labels (AND), and AnyLabels (OR)
+// filters the way bd list does.
+func matchesLabelFilters(issue *beads.Issue, opts beads.ListOptions) bool {
+	if opts.Label != "" && !beads.HasLabel(issue, opts.Label) {
+		return false
+	}
+	for _, label := range opts.Labels {
+		if !beads.HasLabel(issue, label) {
+			return false
+		}
+	}
+	if len(opts.AnyLabels) == 0 {
+		return true
+	}
+	for _, label := range opts.AnyLabels {
+		if beads.HasLabel(issue, label) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *mockBeads) Close(id string) error {
 	if m.closeFunc != nil {
 		return m.closeFunc(id)
@@ -94,3 +125,25 @@ func makeTestMR(id, branch, target, worker string, status string) *beads.Issue {
 		UpdatedAt:   "2025-01-01T12:00:00Z",
 	}
 }
+
+// fakeRunner is a test double for util.Runner. It records each command and
+// answers from results, keyed by "name arg1 arg2 ...". Unknown commands fail.
+type fakeRunner struct {
+	results map[string]fakeRunResult
+	calls   []string
+}
+
+type fakeRunResult struct {
+	stdout string
+	err    error
+}
+
+func (f *fakeRunner) Run(dir, name string, args ...string) (string, error) {
+	key := strings.Join(append([]string{name}, args...), " ")
+	f.calls = append(f.calls, dir+": "+key)
+	res, ok := f.results[key]
+	if !ok {
+		return "", fmt.Errorf("fakeRunner: unexpected command %q", key)
+	}
+	return res.stdout, res.err
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
@@ -23,10 +24,11 @@ import (
 
 // Polecat command flags
 var (
-	polecatListJSON  bool
-	polecatListAll   bool
-	polecatForce     bool
-	polecatRemoveAll bool
+	polecatListFormat *output.FormatFlag
+	polecatListAll    bool
+	polecatListRig    string
+	polecatForce      bool
+	polecatRemoveAll  bool
 )
 
 var polecatCmd = &cobra.Command{
@@ -71,10 +73,15 @@ all polecats with their states:
   - done: Completed work, waiting for cleanup
   - stuck: Needs assistance
 
+Structured output (--format json, or GT_OUTPUT_FORMAT=json) includes each
+polecat's rig, name, state, branch, hooked issue, tmux session and creation
+time.
+
 Examples:
   gt polecat list greenplace
+  gt polecat list --rig greenplace
   gt polecat list --all
-  gt polecat list greenplace --json`,
+  gt polecat list greenplace --format json`,
 	RunE: runPolecatList,
 }
 
@@ -286,8 +293,9 @@ Examples:
 
 func init() {
 	// List flags
-	polecatListCmd.Flags().BoolVar(&polecatListJSON, "json", false, "Output as JSON")
+	polecatListFormat = output.NewFormatFlag(polecatListCmd).WithJSONAlias(polecatListCmd)
 	polecatListCmd.Flags().BoolVar(&polecatListAll, "all", false, "List polecats in all rigs")
+	polecatListCmd.Flags().StringVar(&polecatListRig, "rig", "", "Rig to list (alternative to the positional argument)")
 
 	// Remove flags
 	polecatRemoveCmd.Flags().BoolVarP(&polecatForce, "force", "f", false, "Force removal, bypassing checks")
@@ -340,10 +348,30 @@ type PolecatListItem struct {
 	Rig            string        `json:"rig"`
 	Name           string        `json:"name"`
 	State          polecat.State `json:"state"`
-	Issue          string        `json:"issue,omitempty"`
+	Branch         string        `json:"branch,omitempty"`
+	Issue          string        `json:"issue,omitempty"` // Hooked bead
 	SessionRunning bool          `json:"session_running"`
 	Zombie         bool          `json:"zombie,omitempty"`
 	SessionName    string        `json:"session_name,omitempty"`
+	CreatedAt      *time.Time    `json:"created_at,omitempty"` // Unset for zombies
+}
+
+// newPolecatListItem builds the list entry for a polecat with a worktree.
+func newPolecatListItem(rigName string, p *polecat.Polecat, sessionName string, running bool) PolecatListItem {
+	item := PolecatListItem{
+		Rig:            rigName,
+		Name:           p.Name,
+		State:          p.State,
+		Branch:         p.Branch,
+		Issue:          p.Issue,
+		SessionRunning: running,
+		SessionName:    sessionName,
+	}
+	if !p.CreatedAt.IsZero() {
+		created := p.CreatedAt
+		item.CreatedAt = &created
+	}
+	return item
 }
 
 // getPolecatManager creates a polecat manager for the given rig.
@@ -361,6 +389,19 @@ func getPolecatManager(rigName string) (*polecat.Manager, *rig.Rig, error) {
 }
 
 func runPolecatList(cmd *cobra.Command, args []string) error {
+	format, err := polecatListFormat.Resolve()
+	if err != nil {
+		return err
+	}
+
+	rigName := polecatListRig
+	if len(args) > 0 {
+		if rigName != "" && rigName != args[0] {
+			return fmt.Errorf("rig given twice: %q and --rig %q", args[0], rigName)
+		}
+		rigName = args[0]
+	}
+
 	var rigs []*rig.Rig
 
 	if polecatListAll {
@@ -372,10 +413,10 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 		rigs = allRigs
 	} else {
 		// Need a rig name
-		if len(args) < 1 {
+		if rigName == "" {
 			return fmt.Errorf("rig name required (or use --all)")
 		}
-		_, r, err := getPolecatManager(args[0])
+		_, r, err := getPolecatManager(rigName)
 		if err != nil {
 			return err
 		}
@@ -384,7 +425,7 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 
 	// Collect polecats from all rigs
 	t := tmux.NewTmux()
-	var allPolecats []PolecatListItem
+	allPolecats := []PolecatListItem{}
 
 	for _, r := range rigs {
 		polecatGit := git.NewGit(r.Path)
@@ -401,13 +442,7 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 		knownNames := make(map[string]bool)
 		for _, p := range polecats {
 			running, _ := polecatMgr.IsRunning(p.Name)
-			allPolecats = append(allPolecats, PolecatListItem{
-				Rig:            r.Name,
-				Name:           p.Name,
-				State:          p.State,
-				Issue:          p.Issue,
-				SessionRunning: running,
-			})
+			allPolecats = append(allPolecats, newPolecatListItem(r.Name, p, polecatMgr.SessionName(p.Name), running))
 			knownNames[p.Name] = true
 		}
 
@@ -434,10 +469,8 @@ func runPolecatList(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output
-	if polecatListJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(allPolecats)
+	if format != output.FormatText {
+		return output.PrintFormatted(allPolecats, format)
 	}
 
 	if len(allPolecats) == 0 {
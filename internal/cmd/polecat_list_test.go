@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/polecat"
+)
+
+func TestNewPolecatListItem_JSON(t *testing.T) {
+	created := time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+	p := &polecat.Polecat{
+		Name:      "furiosa",
+		Rig:       "gastown",
+		State:     polecat.StateWorking,
+		Branch:    "polecat/furiosa/gt-abc",
+		Issue:     "gt-abc",
+		CreatedAt: created,
+	}
+
+	item := newPolecatListItem("gastown", p, "gt-gastown-furiosa", true)
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"rig":             "gastown",
+		"name":            "furiosa",
+		"state":           "working",
+		"branch":          "polecat/furiosa/gt-abc",
+		"issue":           "gt-abc",
+		"session_running": true,
+		"session_name":    "gt-gastown-furiosa",
+		"created_at":      "2026-01-15T09:30:00Z",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+	if _, ok := got["zombie"]; ok {
+		t.Error("zombie should be omitted for a polecat with a worktree")
+	}
+}
+
+func TestNewPolecatListItem_NoCreatedAt(t *testing.T) {
+	item := newPolecatListItem("gastown", &polecat.Polecat{Name: "nux", State: polecat.StateDone}, "gt-gastown-nux", false)
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["created_at"]; ok {
+		t.Errorf("created_at should be omitted when unknown, got %v", got["created_at"])
+	}
+	if got["session_running"] != false {
+		t.Errorf("session_running = %v, want false", got["session_running"])
+	}
+}
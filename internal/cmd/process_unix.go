@@ -2,7 +2,11 @@
 
 package cmd
 
-import "syscall"
+import (
+	"context"
+	"os/exec"
+	"syscall"
+)
 
 // isProcessRunning checks if a process with the given PID exists.
 func isProcessRunning(pid int) bool {
@@ -18,3 +22,14 @@ func isProcessRunning(pid int) bool {
 	// EPERM means process exists but we don't have permission to signal it.
 	return err == syscall.EPERM
 }
+
+// shellCommand returns a command that runs script through sh in its own
+// process group, so cancelling ctx also kills any children the script spawned.
+func shellCommand(ctx context.Context, script string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "sh", "-c", script) //nolint:gosec // G204: script is from trusted rig config
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	return cmd
+}
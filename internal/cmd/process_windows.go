@@ -3,7 +3,9 @@
 package cmd
 
 import (
+	"context"
 	"math"
+	"os/exec"
 
 	"golang.org/x/sys/windows"
 )
@@ -31,3 +33,8 @@ func isProcessRunning(pid int) bool {
 
 	return exitCode == processStillActive
 }
+
+// shellCommand returns a command that runs script through cmd.exe.
+func shellCommand(ctx context.Context, script string) *exec.Cmd {
+	return exec.CommandContext(ctx, "cmd", "/c", script) //nolint:gosec // G204: script is from trusted rig config
+}
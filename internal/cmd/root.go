@@ -16,6 +16,9 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// noColor is the global --no-color flag.
+var noColor bool
+
 var rootCmd = &cobra.Command{
 	Use:               "gt", // Updated in init() based on GT_COMMAND
 	Short:             "Gas Town - Multi-agent workspace manager",
@@ -89,6 +92,9 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Disable styling for --no-color / NO_COLOR before anything prints
+	style.Configure(noColor)
+
 	// Initialize CLI theme (dark/light mode support)
 	initCLITheme()
 
@@ -279,6 +285,7 @@ func init() {
 
 	// Global flags can be added here
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored and styled output (also honors NO_COLOR)")
 }
 
 // buildCommandPath walks the command hierarchy to build the full command path.
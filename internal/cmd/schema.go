@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+// schemaTypes maps the names accepted by 'gt schema' to the output structs
+// they describe.
+var schemaTypes = map[string]any{
+	"integration-status": IntegrationStatusOutput{}, // gt mq integration status --format json
+	"status":             TownStatus{},              // gt status --json
+}
+
+var schemaCmd = &cobra.Command{
+	Use:    "schema [type]",
+	Short:  "Print the JSON Schema of a command's JSON output",
+	Hidden: true,
+	Long: `Print a JSON Schema describing the JSON output of a gt command.
+
+The schema is generated from the Go output types, so it always matches the
+running binary. Use it to validate output or generate client types.
+
+Run without arguments to list the available types.
+
+Examples:
+  gt schema
+  gt schema integration-status
+  gt schema status > town-status.schema.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, name := range schemaTypeNames() {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	v, ok := schemaTypes[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown schema type %q (available: %v)", args[0], schemaTypeNames())
+	}
+	return output.PrintFormatted(output.Schema(v), output.FormatJSON)
+}
+
+// schemaTypeNames returns the schema type names in sorted order.
+func schemaTypeNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/output"
+)
+
+func TestSchema_IntegrationStatusOutput(t *testing.T) {
+	s := output.Schema(schemaTypes["integration-status"])
+	props, ok := s["properties"].(map[string]any)
+	if !ok {
+		t.Fatalf("schema has no properties: %v", s)
+	}
+
+	want := map[string]any{
+		"epic":          "string",
+		"branch":        "string",
+		"ahead_of_main": "integer",
+		"ready_to_land": "boolean",
+		"merged_mrs":    []string{"array", "null"},
+		"pending_total": "integer",
+		"last_commit":   "object",
+	}
+	for name, typ := range want {
+		p, ok := props[name].(map[string]any)
+		if !ok {
+			t.Errorf("property %q missing", name)
+			continue
+		}
+		if !reflect.DeepEqual(p["type"], typ) {
+			t.Errorf("property %q type = %v, want %v", name, p["type"], typ)
+		}
+	}
+
+	mr := props["pending_mrs"].(map[string]any)["items"].(map[string]any)
+	if _, ok := mr["properties"].(map[string]any)["id"]; !ok {
+		t.Errorf("pending_mrs items should describe MR summaries, got %v", mr)
+	}
+}
+
+func TestSchemaTypes_AllRender(t *testing.T) {
+	for _, name := range schemaTypeNames() {
+		if s := output.Schema(schemaTypes[name]); s["type"] != "object" {
+			t.Errorf("schema %q type = %v, want object", name, s["type"])
+		}
+	}
+}
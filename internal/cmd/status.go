@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -18,6 +21,7 @@ import (
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/output"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -28,8 +32,12 @@ import (
 var statusJSON bool
 var statusFast bool
 var statusWatch bool
-var statusInterval int
+var statusInterval = watchInterval(2 * time.Second)
 var statusVerbose bool
+var statusOutputFile string
+var statusResources bool
+var statusPorcelain bool
+var statusStale time.Duration
 
 var statusCmd = &cobra.Command{
 	Use:     "status",
@@ -41,7 +49,23 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
-Use --watch to continuously refresh status at regular intervals.`,
+Use --watch to continuously refresh status at regular intervals; --interval
+takes a duration (500ms, 2s, 1m) or a bare number of seconds (default 2s).
+Use --output-file to write the JSON status to a file (progress stays on stdout).
+Use --resources to show per-agent CPU and memory (always included in --json on Linux).
+Use --stale <duration> to show only running agents that look idle: nothing
+hooked, or hooked work whose bead hasn't been updated within the window.
+
+Use --porcelain for scripts: one tab-separated line per agent, with no
+styling, in this fixed column order:
+
+  rig  name  role  state  runtime  session
+
+rig is "-" for town-level agents (mayor, deacon). state is one of
+"running", "zombie" (tmux session alive, agent process dead) or "stopped".
+runtime is the agent preset the role resolves to (e.g., claude). Empty
+fields are printed as "-". This format is kept stable across versions;
+new columns, if any, are only ever appended.`,
 	RunE: runStatus,
 }
 
@@ -49,8 +73,12 @@ func init() {
 	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output as JSON")
 	statusCmd.Flags().BoolVar(&statusFast, "fast", false, "Skip mail lookups for faster execution")
 	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
-	statusCmd.Flags().IntVarP(&statusInterval, "interval", "n", 2, "Refresh interval in seconds")
+	statusCmd.Flags().VarP(&statusInterval, "interval", "n", "Refresh interval as a duration (500ms, 2s, 1m) or whole seconds")
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, "Show detailed multi-line output per agent")
+	statusCmd.Flags().BoolVar(&statusResources, "resources", false, "Show per-agent CPU and memory usage (Linux)")
+	statusCmd.Flags().BoolVar(&statusPorcelain, "porcelain", false, "Stable tab-separated output for scripts, one line per agent")
+	statusCmd.Flags().DurationVar(&statusStale, "stale", 0, "Show only running agents with no work, or whose work bead hasn't updated within this window (e.g., 30m)")
+	output.AddFileFlag(statusCmd, &statusOutputFile)
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -62,6 +90,10 @@ type TownStatus struct {
 	Agents   []AgentRuntime `json:"agents"`             // Global agents (Mayor, Deacon)
 	Rigs     []RigStatus    `json:"rigs"`
 	Summary  StatusSum      `json:"summary"`
+
+	// UnregisteredRigs are rig directories on disk with no mayor/rigs.json
+	// entry; they have no agents or status until adopted.
+	UnregisteredRigs []string `json:"unregistered_rigs,omitempty"`
 }
 
 // OverseerInfo represents the human operator's identity and status.
@@ -80,12 +112,19 @@ type AgentRuntime struct {
 	Session      string `json:"session"`                 // tmux session name
 	Role         string `json:"role"`                    // Role type
 	Running      bool   `json:"running"`                 // Is tmux session running?
+	Zombie       bool   `json:"zombie"`                  // tmux session exists but agent process is dead
 	HasWork      bool   `json:"has_work"`                // Has pinned work?
 	WorkTitle    string `json:"work_title,omitempty"`    // Title of pinned work
 	HookBead     string `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
+	WorkUpdated  string `json:"work_updated,omitempty"`  // updated_at of pinned work (RFC3339)
 	State        string `json:"state,omitempty"`         // Agent state from agent bead
 	UnreadMail   int    `json:"unread_mail"`             // Number of unread messages
 	FirstSubject string `json:"first_subject,omitempty"` // Subject of first unread message
+	Stale        bool   `json:"stale,omitempty"`         // Flagged idle by --stale
+
+	// Resource usage of the agent's process tree (Linux only; JSON or --resources)
+	CPUPercent float64 `json:"cpu_percent,omitempty"` // Lifetime average CPU, 100 = one core
+	MemoryMB   float64 `json:"memory_mb,omitempty"`   // Resident memory
 }
 
 // RigStatus represents status of a single rig.
@@ -100,6 +139,9 @@ type RigStatus struct {
 	Hooks        []AgentHookInfo `json:"hooks,omitempty"`
 	Agents       []AgentRuntime  `json:"agents,omitempty"` // Runtime state of all agents in rig
 	MQ           *MQSummary      `json:"mq,omitempty"`     // Merge queue summary
+	Backend      string          `json:"backend"`          // Beads backend (sqlite, dolt, or unknown)
+	Repo         string          `json:"repo,omitempty"`   // owner/repo parsed from the rig's origin remote
+	UnreadMail   int             `json:"unread_mail"`      // Unread messages across the rig's agents
 }
 
 // MQSummary represents the merge queue status for a rig.
@@ -131,6 +173,12 @@ type StatusSum struct {
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+	if statusPorcelain && statusJSON {
+		return fmt.Errorf("--porcelain and --json cannot be used together")
+	}
+	if statusStale < 0 {
+		return fmt.Errorf("--stale must be positive, got %s", statusStale)
+	}
 	if statusWatch {
 		return runStatusWatch(cmd, args)
 	}
@@ -141,34 +189,34 @@ func runStatusWatch(cmd *cobra.Command, args []string) error {
 	if statusJSON {
 		return fmt.Errorf("--json and --watch cannot be used together")
 	}
-	if statusInterval <= 0 {
-		return fmt.Errorf("interval must be positive, got %d", statusInterval)
+	if statusPorcelain {
+		return fmt.Errorf("--porcelain and --watch cannot be used together")
+	}
+	if err := validateWatchInterval(time.Duration(statusInterval)); err != nil {
+		return err
 	}
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	defer signal.Stop(sigChan)
 
-	ticker := time.NewTicker(time.Duration(statusInterval) * time.Second)
+	ticker := time.NewTicker(time.Duration(statusInterval))
 	defer ticker.Stop()
 
 	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	screen := &statusWatchScreen{}
 
 	for {
-		if isTTY {
-			fmt.Print("\033[H\033[2J") // ANSI: cursor home + clear screen
-		}
-
 		timestamp := time.Now().Format("15:04:05")
-		header := fmt.Sprintf("[%s] gt status --watch (every %ds, Ctrl+C to stop)", timestamp, statusInterval)
+		header := fmt.Sprintf("[%s] gt status --watch (every %s, Ctrl+C to stop)", timestamp, statusInterval.String())
+
 		if isTTY {
-			fmt.Printf("%s\n\n", style.Dim.Render(header))
+			screen.render(os.Stdout, style.Dim.Render(header))
 		} else {
 			fmt.Printf("%s\n\n", header)
-		}
-
-		if err := runStatusOnce(cmd, args); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			if err := runStatusOnce(cmd, args); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
 		}
 
 		select {
@@ -182,11 +230,229 @@ func runStatusWatch(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// statusWatchScreen redraws gt status --watch on a terminal. After the first
+// frame it rewrites only the lines that changed, in place, so a large town
+// doesn't flicker every tick. It falls back to a full redraw when the
+// terminal is resized, the agent set changes, or the layout shifts. Frames
+// taller than the terminal are clipped so the screen never scrolls.
+type statusWatchScreen struct {
+	lines  []string       // Last frame drawn, one entry per screen line
+	agents []AgentRuntime // Agents shown in the last frame
+	width  int
+	height int
+}
+
+// render draws one watch frame: the header line, a blank line, then status.
+func (s *statusWatchScreen) render(w io.Writer, header string) {
+	var body bytes.Buffer
+	status, err := collectStatus()
+	if err == nil && statusStale > 0 {
+		status = filterStaleAgents(status, statusStale, time.Now())
+	}
+	if err == nil && statusOutputFile != "" {
+		err = output.WriteFile(statusOutputFile, status, output.FormatJSON)
+	}
+	if err == nil {
+		err = outputStatusText(&body, status)
+	}
+	if err != nil {
+		fmt.Fprintf(&body, "Error: %v\n", err)
+	}
+	lines := append([]string{header, ""}, strings.Split(strings.TrimSuffix(body.String(), "\n"), "\n")...)
+	agents := flattenStatusAgents(status)
+
+	width, height, sizeErr := term.GetSize(int(os.Stdout.Fd()))
+	if sizeErr == nil {
+		lines = clipWatchFrame(lines, height)
+	}
+	_, sameAgents := diffAgents(s.agents, agents)
+	full := s.lines == nil || err != nil || sizeErr != nil ||
+		width != s.width || height != s.height ||
+		!sameAgents || len(lines) != len(s.lines)
+
+	if full {
+		fmt.Fprint(w, "\033[H\033[2J") // ANSI: cursor home + clear screen
+		fmt.Fprintln(w, strings.Join(lines, "\n"))
+	} else {
+		for i, line := range lines {
+			if line != s.lines[i] {
+				// ANSI: move to row i+1, write the line, clear the rest of it
+				fmt.Fprintf(w, "\033[%d;1H%s\033[K", i+1, line)
+			}
+		}
+		fmt.Fprintf(w, "\033[%d;1H", len(lines)+1)
+	}
+
+	s.width, s.height = width, height
+	s.agents = agents
+	s.lines = lines
+	if err != nil {
+		s.lines = nil // Redraw from scratch once status is available again
+	}
+}
+
+// clipWatchFrame keeps a watch frame within height-1 rows, leaving the last
+// row for the cursor so drawing the frame never scrolls the terminal. The
+// last kept row says how many lines were cut.
+func clipWatchFrame(lines []string, height int) []string {
+	if height < 2 || len(lines) <= height-1 {
+		return lines
+	}
+	clipped := append([]string(nil), lines[:height-2]...)
+	more := len(lines) - len(clipped)
+	return append(clipped, style.Dim.Render(fmt.Sprintf("… %d more lines (enlarge the terminal to see them)", more)))
+}
+
+// flattenStatusAgents lists the town-level agents followed by each rig's
+// agents, the order they are displayed in.
+func flattenStatusAgents(status TownStatus) []AgentRuntime {
+	agents := append([]AgentRuntime(nil), status.Agents...)
+	for _, r := range status.Rigs {
+		agents = append(agents, r.Agents...)
+	}
+	return agents
+}
+
+// diffAgents compares two watch ticks. It returns the indices in cur of
+// agents whose displayed state changed (liveness, hooked work, agent state
+// or unread mail), and sameSet=false if the agents themselves differ (added,
+// removed or reordered), in which case changed is nil and the caller must
+// redraw everything.
+func diffAgents(prev, cur []AgentRuntime) (changed []int, sameSet bool) {
+	if len(prev) != len(cur) {
+		return nil, false
+	}
+	for i := range cur {
+		if prev[i].Address != cur[i].Address || prev[i].Name != cur[i].Name {
+			return nil, false
+		}
+	}
+	for i := range cur {
+		p, c := prev[i], cur[i]
+		if p.Running != c.Running || p.Zombie != c.Zombie ||
+			p.HasWork != c.HasWork || p.WorkTitle != c.WorkTitle || p.HookBead != c.HookBead ||
+			p.State != c.State || p.UnreadMail != c.UnreadMail || p.FirstSubject != c.FirstSubject {
+			changed = append(changed, i)
+		}
+	}
+	return changed, true
+}
+
+// filterStaleAgents keeps only the agents isStaleAgent flags, marking them
+// Stale so the renderers can call them out. Rigs stay listed even when none
+// of their agents are stale.
+func filterStaleAgents(status TownStatus, window time.Duration, now time.Time) TownStatus {
+	keep := func(agents []AgentRuntime) []AgentRuntime {
+		stale := []AgentRuntime{}
+		for _, agent := range agents {
+			if isStaleAgent(agent, window, now) {
+				agent.Stale = true
+				stale = append(stale, agent)
+			}
+		}
+		return stale
+	}
+	status.Agents = keep(status.Agents)
+	rigs := make([]RigStatus, len(status.Rigs))
+	for i, r := range status.Rigs {
+		r.Agents = keep(r.Agents)
+		rigs[i] = r
+	}
+	status.Rigs = rigs
+	return status
+}
+
+// isStaleAgent reports whether a running agent looks idle: nothing hooked,
+// or hooked work whose bead hasn't been updated within window. Work with no
+// readable timestamp is not treated as stale.
+func isStaleAgent(agent AgentRuntime, window time.Duration, now time.Time) bool {
+	if !agent.Running {
+		return false
+	}
+	if !agent.HasWork {
+		return true
+	}
+	updated, err := time.Parse(time.RFC3339, agent.WorkUpdated)
+	if err != nil {
+		return false
+	}
+	return now.Sub(updated) > window
+}
+
+// validateWatchInterval rejects zero or negative --interval values for watch modes.
+func validateWatchInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	return nil
+}
+
+// watchInterval is a --interval flag value: a Go duration ("500ms", "2s",
+// "1m") or, as the flag used to take, a bare number of seconds.
+type watchInterval time.Duration
+
+func (w *watchInterval) String() string { return time.Duration(*w).String() }
+
+func (w *watchInterval) Type() string { return "duration" }
+
+func (w *watchInterval) Set(s string) error {
+	d, err := parseWatchInterval(s)
+	if err != nil {
+		return err
+	}
+	*w = watchInterval(d)
+	return nil
+}
+
+// parseWatchInterval parses an --interval value. A bare integer is seconds,
+// for compatibility with scripts written against the old integer flag.
+// Sign is not checked here; validateWatchInterval rejects non-positive values.
+func parseWatchInterval(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %q: use a duration like 500ms, 2s or 1m, or whole seconds", s)
+	}
+	return d, nil
+}
+
 func runStatusOnce(_ *cobra.Command, _ []string) error {
+	status, err := collectStatus()
+	if err != nil {
+		return err
+	}
+	if statusStale > 0 {
+		status = filterStaleAgents(status, statusStale, time.Now())
+	}
+
+	// Output
+	if statusOutputFile != "" {
+		if err := output.WriteFile(statusOutputFile, status, output.FormatJSON); err != nil {
+			return err
+		}
+		if statusJSON {
+			return nil
+		}
+	}
+	if statusJSON {
+		return outputStatusJSON(status)
+	}
+	if statusPorcelain {
+		return outputStatusPorcelain(os.Stdout, status)
+	}
+	return outputStatusText(os.Stdout, status)
+}
+
+// collectStatus gathers the town's status: rigs, agents and their liveness,
+// hooks, mail and merge queue summaries.
+func collectStatus() (TownStatus, error) {
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+		return TownStatus{}, fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
 	// Load town config
@@ -241,7 +507,7 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 	// Discover rigs
 	rigs, err := mgr.DiscoverRigs()
 	if err != nil {
-		return fmt.Errorf("discovering rigs: %w", err)
+		return TownStatus{}, fmt.Errorf("discovering rigs: %w", err)
 	}
 
 	// Pre-fetch agent beads across all rig-specific beads DBs.
@@ -363,6 +629,10 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 		Overseer: overseerInfo,
 		Rigs:     make([]RigStatus, len(rigs)),
 	}
+	// Rig directories the registry doesn't know about (e.g., cloned by hand)
+	if unregistered, err := workspace.UnregisteredRigs(townRoot, rigsConfig); err == nil {
+		status.UnregisteredRigs = unregistered
+	}
 
 	var wg sync.WaitGroup
 
@@ -386,6 +656,8 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 				PolecatCount: len(r.Polecats),
 				HasWitness:   r.HasWitness,
 				HasRefinery:  r.HasRefinery,
+				Backend:      rigBeadsBackend(r.Path),
+				Repo:         rigRepoSlug(r.Path),
 			}
 
 			// Count crew workers
@@ -414,6 +686,7 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 
 			// Discover runtime state for all agents in this rig
 			rs.Agents = discoverRigAgents(allSessions, r, rs.Crews, allAgentBeads, allHookBeads, mailRouter, statusFast)
+			rs.UnreadMail = sumUnreadMail(rs.Agents)
 
 			// Get MQ summary if rig has a refinery
 			// Skip in --fast mode to avoid expensive bd queries
@@ -441,23 +714,117 @@ func runStatusOnce(_ *cobra.Command, _ []string) error {
 	}
 	status.Summary.RigCount = len(rigs)
 
-	// Output
-	if statusJSON {
-		return outputStatusJSON(status)
+	// Resource usage is only worth the /proc reads when it will be shown
+	if statusJSON || statusOutputFile != "" || statusResources {
+		fillAgentResources(&status, t)
+	}
+	return status, nil
+}
+
+// rigBeadsBackend returns the beads backend (e.g., "sqlite" or "dolt") recorded
+// in the rig's .beads/metadata.json, following redirects.
+// Returns "unknown" if the file is missing, unreadable, or has no backend.
+func rigBeadsBackend(rigPath string) string {
+	beadsDir := beads.ResolveBeadsDir(rigPath)
+	data, err := os.ReadFile(filepath.Join(beadsDir, "metadata.json")) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		return "unknown"
+	}
+	var meta struct {
+		Backend string `json:"backend"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil || meta.Backend == "" {
+		return "unknown"
+	}
+	return meta.Backend
+}
+
+// rigRepoSlug returns owner/repo for the rig's origin remote, or "" when
+// the rig has no repo base, no origin, or an origin URL that isn't hosted.
+func rigRepoSlug(rigPath string) string {
+	g, err := getRigGit(rigPath)
+	if err != nil {
+		return ""
 	}
-	return outputStatusText(status)
+	url, err := g.RemoteURL("origin")
+	if err != nil {
+		return ""
+	}
+	slug, _ := git.ParseRepoSlug(url)
+	return slug
 }
 
 func outputStatusJSON(status TownStatus) error {
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(status)
+	return output.PrintFormatted(status, output.FormatJSON)
 }
 
-func outputStatusText(status TownStatus) error {
+// Agent states in --porcelain output. They are part of the stable format.
+const (
+	porcelainRunning = "running"
+	porcelainZombie  = "zombie"
+	porcelainStopped = "stopped"
+)
+
+// outputStatusPorcelain writes one tab-separated line per agent:
+// rig, name, role, state, runtime, session. Town-level agents come first
+// with rig "-". See the status command help for the format contract.
+func outputStatusPorcelain(w io.Writer, status TownStatus) error {
+	for _, agent := range status.Agents {
+		if err := writePorcelainAgent(w, status.Location, "", agent); err != nil {
+			return err
+		}
+	}
+	for _, rs := range status.Rigs {
+		for _, agent := range rs.Agents {
+			if err := writePorcelainAgent(w, status.Location, rs.Name, agent); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writePorcelainAgent(w io.Writer, townRoot, rigName string, agent AgentRuntime) error {
+	fields := []string{rigName, agent.Name, agent.Role, porcelainState(agent), porcelainRuntime(townRoot, rigName, agent), agent.Session}
+	for i, f := range fields {
+		if f == "" {
+			fields[i] = "-"
+		}
+	}
+	_, err := fmt.Fprintln(w, strings.Join(fields, "\t"))
+	return err
+}
+
+// porcelainState maps an agent's liveness to its --porcelain token.
+func porcelainState(agent AgentRuntime) string {
+	switch {
+	case agent.Running:
+		return porcelainRunning
+	case agent.Zombie:
+		return porcelainZombie
+	default:
+		return porcelainStopped
+	}
+}
+
+// porcelainRuntime returns the agent preset the agent's role resolves to.
+// Town-level agents are configured by name (mayor, deacon) rather than by
+// their display role.
+func porcelainRuntime(townRoot, rigName string, agent AgentRuntime) string {
+	role, rigPath := agent.Role, ""
+	if rigName == "" {
+		role = agent.Name
+	} else {
+		rigPath = filepath.Join(townRoot, rigName)
+	}
+	name, _ := config.ResolveRoleAgentName(role, townRoot, rigPath)
+	return name
+}
+
+func outputStatusText(w io.Writer, status TownStatus) error {
 	// Header
-	fmt.Printf("%s %s\n", style.Bold.Render("Town:"), status.Name)
-	fmt.Printf("%s\n\n", style.Dim.Render(status.Location))
+	fmt.Fprintf(w, "%s %s\n", style.Bold.Render("Town:"), status.Name)
+	fmt.Fprintf(w, "%s\n\n", style.Dim.Render(status.Location))
 
 	// Overseer info
 	if status.Overseer != nil {
@@ -467,11 +834,11 @@ func outputStatusText(status TownStatus) error {
 		} else if status.Overseer.Username != "" && status.Overseer.Username != status.Overseer.Name {
 			overseerDisplay = fmt.Sprintf("%s (@%s)", status.Overseer.Name, status.Overseer.Username)
 		}
-		fmt.Printf("👤 %s %s\n", style.Bold.Render("Overseer:"), overseerDisplay)
+		fmt.Fprintf(w, "👤 %s %s\n", style.Bold.Render("Overseer:"), overseerDisplay)
 		if status.Overseer.UnreadMail > 0 {
-			fmt.Printf("   📬 %d unread\n", status.Overseer.UnreadMail)
+			fmt.Fprintf(w, "   📬 %d unread\n", status.Overseer.UnreadMail)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	// Role icons - uses centralized emojis from constants package
@@ -494,27 +861,35 @@ func outputStatusText(status TownStatus) error {
 			icon = roleIcons[agent.Name]
 		}
 		if statusVerbose {
-			fmt.Printf("%s %s\n", icon, style.Bold.Render(capitalizeFirst(agent.Name)))
-			renderAgentDetails(agent, "   ", nil, status.Location)
-			fmt.Println()
+			fmt.Fprintf(w, "%s %s\n", icon, style.Bold.Render(capitalizeFirst(agent.Name)))
+			renderAgentDetails(w, agent, "   ", nil, status.Location)
+			fmt.Fprintln(w)
 		} else {
 			// Compact: icon + name on one line
-			renderAgentCompact(agent, icon+" ", nil, status.Location)
+			renderAgentCompact(w, agent, icon+" ", nil, status.Location)
 		}
 	}
 	if !statusVerbose && len(status.Agents) > 0 {
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	if len(status.Rigs) == 0 {
-		fmt.Printf("%s\n", style.Dim.Render("No rigs registered. Use 'gt rig add' to add one."))
+		fmt.Fprintf(w, "%s\n", style.Dim.Render("No rigs registered. Use 'gt rig add' to add one."))
+		renderUnregisteredRigs(w, status.UnregisteredRigs)
 		return nil
 	}
 
 	// Rigs
 	for _, r := range status.Rigs {
 		// Rig header with separator
-		fmt.Printf("─── %s ───────────────────────────────────────────\n\n", style.Bold.Render(r.Name+"/"))
+		header := style.Bold.Render(r.Name+"/") + " " + style.Dim.Render("["+r.Backend+"]")
+		if r.Repo != "" {
+			header += " " + style.Dim.Render(r.Repo)
+		}
+		if r.UnreadMail > 0 {
+			header += fmt.Sprintf(" 📬 %d unread", r.UnreadMail)
+		}
+		fmt.Fprintf(w, "─── %s ───────────────────────────────────────────\n\n", header)
 
 		// Group agents by role
 		var witnesses, refineries, crews, polecats []AgentRuntime
@@ -534,14 +909,14 @@ func outputStatusText(status TownStatus) error {
 		// Witness
 		if len(witnesses) > 0 {
 			if statusVerbose {
-				fmt.Printf("%s %s\n", roleIcons["witness"], style.Bold.Render("Witness"))
+				fmt.Fprintf(w, "%s %s\n", roleIcons["witness"], style.Bold.Render("Witness"))
 				for _, agent := range witnesses {
-					renderAgentDetails(agent, "   ", r.Hooks, status.Location)
+					renderAgentDetails(w, agent, "   ", r.Hooks, status.Location)
 				}
-				fmt.Println()
+				fmt.Fprintln(w)
 			} else {
 				for _, agent := range witnesses {
-					renderAgentCompact(agent, roleIcons["witness"]+" ", r.Hooks, status.Location)
+					renderAgentCompact(w, agent, roleIcons["witness"]+" ", r.Hooks, status.Location)
 				}
 			}
 		}
@@ -549,18 +924,18 @@ func outputStatusText(status TownStatus) error {
 		// Refinery
 		if len(refineries) > 0 {
 			if statusVerbose {
-				fmt.Printf("%s %s\n", roleIcons["refinery"], style.Bold.Render("Refinery"))
+				fmt.Fprintf(w, "%s %s\n", roleIcons["refinery"], style.Bold.Render("Refinery"))
 				for _, agent := range refineries {
-					renderAgentDetails(agent, "   ", r.Hooks, status.Location)
+					renderAgentDetails(w, agent, "   ", r.Hooks, status.Location)
 				}
 				// MQ summary (shown under refinery)
 				if r.MQ != nil {
 					mqStr := formatMQSummary(r.MQ)
 					if mqStr != "" {
-						fmt.Printf("   MQ: %s\n", mqStr)
+						fmt.Fprintf(w, "   MQ: %s\n", mqStr)
 					}
 				}
-				fmt.Println()
+				fmt.Fprintln(w)
 			} else {
 				for _, agent := range refineries {
 					// Compact: include MQ on same line if present
@@ -571,7 +946,7 @@ func outputStatusText(status TownStatus) error {
 							mqSuffix = "  " + mqStr
 						}
 					}
-					renderAgentCompactWithSuffix(agent, roleIcons["refinery"]+" ", r.Hooks, status.Location, mqSuffix)
+					renderAgentCompactWithSuffix(w, agent, roleIcons["refinery"]+" ", r.Hooks, status.Location, mqSuffix)
 				}
 			}
 		}
@@ -579,15 +954,15 @@ func outputStatusText(status TownStatus) error {
 		// Crew
 		if len(crews) > 0 {
 			if statusVerbose {
-				fmt.Printf("%s %s (%d)\n", roleIcons["crew"], style.Bold.Render("Crew"), len(crews))
+				fmt.Fprintf(w, "%s %s (%d)\n", roleIcons["crew"], style.Bold.Render("Crew"), len(crews))
 				for _, agent := range crews {
-					renderAgentDetails(agent, "   ", r.Hooks, status.Location)
+					renderAgentDetails(w, agent, "   ", r.Hooks, status.Location)
 				}
-				fmt.Println()
+				fmt.Fprintln(w)
 			} else {
-				fmt.Printf("%s %s (%d)\n", roleIcons["crew"], style.Bold.Render("Crew"), len(crews))
+				fmt.Fprintf(w, "%s %s (%d)\n", roleIcons["crew"], style.Bold.Render("Crew"), len(crews))
 				for _, agent := range crews {
-					renderAgentCompact(agent, "   ", r.Hooks, status.Location)
+					renderAgentCompact(w, agent, "   ", r.Hooks, status.Location)
 				}
 			}
 		}
@@ -595,31 +970,45 @@ func outputStatusText(status TownStatus) error {
 		// Polecats
 		if len(polecats) > 0 {
 			if statusVerbose {
-				fmt.Printf("%s %s (%d)\n", roleIcons["polecat"], style.Bold.Render("Polecats"), len(polecats))
+				fmt.Fprintf(w, "%s %s (%d)\n", roleIcons["polecat"], style.Bold.Render("Polecats"), len(polecats))
 				for _, agent := range polecats {
-					renderAgentDetails(agent, "   ", r.Hooks, status.Location)
+					renderAgentDetails(w, agent, "   ", r.Hooks, status.Location)
 				}
-				fmt.Println()
+				fmt.Fprintln(w)
 			} else {
-				fmt.Printf("%s %s (%d)\n", roleIcons["polecat"], style.Bold.Render("Polecats"), len(polecats))
+				fmt.Fprintf(w, "%s %s (%d)\n", roleIcons["polecat"], style.Bold.Render("Polecats"), len(polecats))
 				for _, agent := range polecats {
-					renderAgentCompact(agent, "   ", r.Hooks, status.Location)
+					renderAgentCompact(w, agent, "   ", r.Hooks, status.Location)
 				}
 			}
 		}
 
 		// No agents
 		if len(witnesses) == 0 && len(refineries) == 0 && len(crews) == 0 && len(polecats) == 0 {
-			fmt.Printf("   %s\n", style.Dim.Render("(no agents)"))
+			fmt.Fprintf(w, "   %s\n", style.Dim.Render("(no agents)"))
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
+	renderUnregisteredRigs(w, status.UnregisteredRigs)
 
 	return nil
 }
 
+// renderUnregisteredRigs warns about rig directories missing from the
+// registry, which every other part of status silently skips.
+func renderUnregisteredRigs(w io.Writer, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s %s\n", style.Warning.Render("⚠"), style.Bold.Render("Unregistered rigs (not in mayor/rigs.json):"))
+	for _, name := range names {
+		fmt.Fprintf(w, "   %s/ %s\n", name, style.Dim.Render(fmt.Sprintf("register with: gt rig add %s --adopt", name)))
+	}
+	fmt.Fprintln(w)
+}
+
 // renderAgentDetails renders full agent bead details
-func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo, townRoot string) { //nolint:unparam // indent kept for future customization
+func renderAgentDetails(w io.Writer, agent AgentRuntime, indent string, hooks []AgentHookInfo, townRoot string) { //nolint:unparam // indent kept for future customization
 	// Line 1: Agent bead ID + status
 	// Per gt-zecmc: derive status from tmux (observable reality), not bead state.
 	// "Discover, don't track" - agent liveness is observable from tmux session.
@@ -630,6 +1019,8 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 
 	if sessionExists {
 		statusStr = style.Success.Render("running")
+	} else if agent.Zombie {
+		statusStr = style.Warning.Render("zombie (session alive, agent dead)")
 	} else {
 		statusStr = style.Error.Render("stopped")
 	}
@@ -650,6 +1041,9 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 	// Ignore observable states: "running", "idle", "dead", "done", "stopped", ""
 	// These should be derived from tmux, not bead.
 	}
+	if agent.Stale {
+		stateInfo += style.Warning.Render(" ⚠ [stale]")
+	}
 
 	// Build agent bead ID using canonical naming: prefix-rig-role-name
 	agentBeadID := "gt-" + agent.Name
@@ -676,7 +1070,10 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 		}
 	}
 
-	fmt.Printf("%s%s %s%s\n", indent, style.Dim.Render(agentBeadID), statusStr, stateInfo)
+	if statusResources {
+		stateInfo += formatAgentResources(agent)
+	}
+	fmt.Fprintf(w, "%s%s %s%s\n", indent, style.Dim.Render(agentBeadID), statusStr, stateInfo)
 
 	// Line 2: Hook bead (pinned work)
 	hookStr := style.Dim.Render("(none)")
@@ -705,7 +1102,7 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 		hookStr = truncateWithEllipsis(hookTitle, 50)
 	}
 
-	fmt.Printf("%s  hook: %s\n", indent, hookStr)
+	fmt.Fprintf(w, "%s  hook: %s\n", indent, hookStr)
 
 	// Line 3: Mail (if any unread)
 	if agent.UnreadMail > 0 {
@@ -713,7 +1110,7 @@ func renderAgentDetails(agent AgentRuntime, indent string, hooks []AgentHookInfo
 		if agent.FirstSubject != "" {
 			mailStr = fmt.Sprintf("📬 %d unread → %s", agent.UnreadMail, truncateWithEllipsis(agent.FirstSubject, 35))
 		}
-		fmt.Printf("%s  mail: %s\n", indent, mailStr)
+		fmt.Fprintf(w, "%s  mail: %s\n", indent, mailStr)
 	}
 }
 
@@ -769,7 +1166,7 @@ func formatMQSummaryCompact(mq *MQSummary) string {
 }
 
 // renderAgentCompactWithSuffix renders a single-line agent status with an extra suffix
-func renderAgentCompactWithSuffix(agent AgentRuntime, indent string, hooks []AgentHookInfo, _ string, suffix string) {
+func renderAgentCompactWithSuffix(w io.Writer, agent AgentRuntime, indent string, hooks []AgentHookInfo, _ string, suffix string) {
 	// Build status indicator (gt-zecmc: use tmux state, not bead state)
 	statusIndicator := buildStatusIndicator(agent)
 
@@ -803,13 +1200,16 @@ func renderAgentCompactWithSuffix(agent AgentRuntime, indent string, hooks []Age
 	if agent.UnreadMail > 0 {
 		mailSuffix = fmt.Sprintf(" 📬%d", agent.UnreadMail)
 	}
+	if statusResources {
+		mailSuffix += formatAgentResources(agent)
+	}
 
 	// Print single line: name + status + hook + mail + suffix
-	fmt.Printf("%s%-12s %s%s%s%s\n", indent, agent.Name, statusIndicator, hookSuffix, mailSuffix, suffix)
+	fmt.Fprintf(w, "%s%-12s %s%s%s%s\n", indent, agent.Name, statusIndicator, hookSuffix, mailSuffix, suffix)
 }
 
 // renderAgentCompact renders a single-line agent status
-func renderAgentCompact(agent AgentRuntime, indent string, hooks []AgentHookInfo, _ string) {
+func renderAgentCompact(w io.Writer, agent AgentRuntime, indent string, hooks []AgentHookInfo, _ string) {
 	// Build status indicator (gt-zecmc: use tmux state, not bead state)
 	statusIndicator := buildStatusIndicator(agent)
 
@@ -843,9 +1243,12 @@ func renderAgentCompact(agent AgentRuntime, indent string, hooks []AgentHookInfo
 	if agent.UnreadMail > 0 {
 		mailSuffix = fmt.Sprintf(" 📬%d", agent.UnreadMail)
 	}
+	if statusResources {
+		mailSuffix += formatAgentResources(agent)
+	}
 
 	// Print single line: name + status + hook + mail
-	fmt.Printf("%s%-12s %s%s%s\n", indent, agent.Name, statusIndicator, hookSuffix, mailSuffix)
+	fmt.Fprintf(w, "%s%-12s %s%s%s\n", indent, agent.Name, statusIndicator, hookSuffix, mailSuffix)
 }
 
 // buildStatusIndicator creates the visual status indicator for an agent.
@@ -858,6 +1261,9 @@ func buildStatusIndicator(agent AgentRuntime) string {
 	var indicator string
 	if sessionExists {
 		indicator = style.Success.Render("●")
+	} else if agent.Zombie {
+		// Session alive but agent dead - distinct from no session at all
+		indicator = style.Warning.Render("⚠ zombie")
 	} else {
 		indicator = style.Error.Render("○")
 	}
@@ -873,6 +1279,9 @@ func buildStatusIndicator(agent AgentRuntime) string {
 		indicator += style.Dim.Render(" " + beadState)
 	// Ignore observable states: running, idle, dead, done, stopped, ""
 	}
+	if agent.Stale {
+		indicator += style.Warning.Render(" ⚠ stale")
+	}
 
 	return indicator
 }
@@ -948,7 +1357,7 @@ func discoverRigHooks(r *rig.Rig, crews []string) []AgentHookInfo {
 // allSessions is a preloaded map of tmux sessions for O(1) lookup.
 // allAgentBeads is a preloaded map of agent beads for O(1) lookup.
 // allHookBeads is a preloaded map of hook beads for O(1) lookup.
-func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]*beads.Issue, allHookBeads map[string]*beads.Issue, mailRouter *mail.Router, skipMail bool) []AgentRuntime {
+func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]*beads.Issue, allHookBeads map[string]*beads.Issue, mailRouter mailboxGetter, skipMail bool) []AgentRuntime {
 	// Get session names dynamically
 	mayorSession := getMayorSessionName()
 	deaconSession := getDeaconSessionName()
@@ -988,7 +1397,9 @@ func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]
 			}
 
 			// Check tmux session from preloaded map (O(1))
-			agent.Running = allSessions[d.session]
+			alive, exists := allSessions[d.session]
+			agent.Running = alive
+			agent.Zombie = exists && !alive
 
 			// Look up agent bead from preloaded map (O(1))
 			if issue, ok := allAgentBeads[d.beadID]; ok {
@@ -1001,6 +1412,7 @@ func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]
 					// Get hook title from preloaded map
 					if pinnedIssue, ok := allHookBeads[agent.HookBead]; ok {
 						agent.WorkTitle = pinnedIssue.Title
+						agent.WorkUpdated = pinnedIssue.UpdatedAt
 					}
 				}
 				// Fallback to description for legacy beads without database columns
@@ -1025,8 +1437,13 @@ func discoverGlobalAgents(allSessions map[string]bool, allAgentBeads map[string]
 	return agents
 }
 
+// mailboxGetter resolves an agent's mailbox; *mail.Router implements it.
+type mailboxGetter interface {
+	GetMailbox(address string) (*mail.Mailbox, error)
+}
+
 // populateMailInfo fetches unread mail count and first subject for an agent
-func populateMailInfo(agent *AgentRuntime, router *mail.Router) {
+func populateMailInfo(agent *AgentRuntime, router mailboxGetter) {
 	if router == nil {
 		return
 	}
@@ -1043,6 +1460,15 @@ func populateMailInfo(agent *AgentRuntime, router *mail.Router) {
 	}
 }
 
+// sumUnreadMail totals the unread mail of agents.
+func sumUnreadMail(agents []AgentRuntime) int {
+	total := 0
+	for _, agent := range agents {
+		total += agent.UnreadMail
+	}
+	return total
+}
+
 // agentDef defines an agent to discover
 type agentDef struct {
 	name    string
@@ -1057,7 +1483,7 @@ type agentDef struct {
 // allSessions is a preloaded map of tmux sessions for O(1) lookup.
 // allAgentBeads is a preloaded map of agent beads for O(1) lookup.
 // allHookBeads is a preloaded map of hook beads for O(1) lookup.
-func discoverRigAgents(allSessions map[string]bool, r *rig.Rig, crews []string, allAgentBeads map[string]*beads.Issue, allHookBeads map[string]*beads.Issue, mailRouter *mail.Router, skipMail bool) []AgentRuntime {
+func discoverRigAgents(allSessions map[string]bool, r *rig.Rig, crews []string, allAgentBeads map[string]*beads.Issue, allHookBeads map[string]*beads.Issue, mailRouter mailboxGetter, skipMail bool) []AgentRuntime {
 	// Build list of all agents to discover
 	var defs []agentDef
 	townRoot := filepath.Dir(r.Path)
@@ -1128,7 +1554,9 @@ func discoverRigAgents(allSessions map[string]bool, r *rig.Rig, crews []string,
 			}
 
 			// Check tmux session from preloaded map (O(1))
-			agent.Running = allSessions[d.session]
+			alive, exists := allSessions[d.session]
+			agent.Running = alive
+			agent.Zombie = exists && !alive
 
 			// Look up agent bead from preloaded map (O(1))
 			if issue, ok := allAgentBeads[d.beadID]; ok {
@@ -1141,6 +1569,7 @@ func discoverRigAgents(allSessions map[string]bool, r *rig.Rig, crews []string,
 					// Get hook title from preloaded map
 					if pinnedIssue, ok := allHookBeads[agent.HookBead]; ok {
 						agent.WorkTitle = pinnedIssue.Title
+						agent.WorkUpdated = pinnedIssue.UpdatedAt
 					}
 				}
 				// Fallback to description for legacy beads without database columns
@@ -1175,9 +1604,9 @@ func getMQSummary(r *rig.Rig) *MQSummary {
 	// Create beads instance for the rig
 	b := beads.New(r.BeadsPath())
 
-	// Query for all open merge-request type issues
+	// Query for all open merge requests by label
 	opts := beads.ListOptions{
-		Type:     "merge-request",
+		Label:    "gt:merge-request",
 		Status:   "open",
 		Priority: -1, // No priority filter
 	}
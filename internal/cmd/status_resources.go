@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// procRoot is the procfs mount point; tests point it at synthetic files.
+var procRoot = "/proc"
+
+// clockTicks is USER_HZ, the unit of the time fields in /proc/<pid>/stat.
+// It is 100 on every mainstream Linux platform.
+const clockTicks = 100
+
+// procStats is the resource usage of a process (or process tree).
+type procStats struct {
+	CPUPercent float64 // average CPU over the process lifetime (100 = one core)
+	MemoryMB   float64 // resident set size
+}
+
+// readProcStats reads CPU and memory usage for pid from /proc/<pid>/stat and
+// /proc/<pid>/statm. CPU is averaged over the process lifetime, since a
+// single sample has no previous reading to diff against.
+func readProcStats(pid int) (procStats, error) {
+	var stats procStats
+	dir := filepath.Join(procRoot, strconv.Itoa(pid))
+
+	statData, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return stats, err
+	}
+	// comm (field 2) may contain spaces; fields after it start past the last ')'
+	raw := string(statData)
+	end := strings.LastIndexByte(raw, ')')
+	if end < 0 {
+		return stats, fmt.Errorf("malformed stat for pid %d", pid)
+	}
+	fields := strings.Fields(raw[end+1:])
+	// fields[0] is state (field 3); utime, stime and starttime are fields 14, 15 and 22
+	if len(fields) < 20 {
+		return stats, fmt.Errorf("malformed stat for pid %d: %d fields", pid, len(fields))
+	}
+	utime, err1 := strconv.ParseFloat(fields[11], 64)
+	stime, err2 := strconv.ParseFloat(fields[12], 64)
+	start, err3 := strconv.ParseFloat(fields[19], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return stats, fmt.Errorf("malformed stat times for pid %d", pid)
+	}
+
+	uptimeData, err := os.ReadFile(filepath.Join(procRoot, "uptime"))
+	if err != nil {
+		return stats, err
+	}
+	uptimeFields := strings.Fields(string(uptimeData))
+	if len(uptimeFields) == 0 {
+		return stats, fmt.Errorf("malformed uptime")
+	}
+	uptime, err := strconv.ParseFloat(uptimeFields[0], 64)
+	if err != nil {
+		return stats, fmt.Errorf("malformed uptime: %w", err)
+	}
+	if elapsed := uptime - start/clockTicks; elapsed > 0 {
+		stats.CPUPercent = (utime + stime) / clockTicks / elapsed * 100
+	}
+
+	statmData, err := os.ReadFile(filepath.Join(dir, "statm"))
+	if err != nil {
+		return stats, err
+	}
+	statmFields := strings.Fields(string(statmData))
+	if len(statmFields) < 2 {
+		return stats, fmt.Errorf("malformed statm for pid %d", pid)
+	}
+	residentPages, err := strconv.ParseFloat(statmFields[1], 64)
+	if err != nil {
+		return stats, fmt.Errorf("malformed statm for pid %d: %w", pid, err)
+	}
+	stats.MemoryMB = residentPages * float64(os.Getpagesize()) / (1024 * 1024)
+
+	return stats, nil
+}
+
+// readProcTreeStats sums readProcStats over pid and its descendants, so an
+// agent started through a shell is measured rather than the idle shell.
+func readProcTreeStats(pid int, depth int) procStats {
+	var total procStats
+	if depth > 10 {
+		return total
+	}
+	if s, err := readProcStats(pid); err == nil {
+		total = s
+	}
+	children, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "task", strconv.Itoa(pid), "children"))
+	if err != nil {
+		return total
+	}
+	for _, field := range strings.Fields(string(children)) {
+		child, err := strconv.Atoi(field)
+		if err != nil {
+			continue
+		}
+		s := readProcTreeStats(child, depth+1)
+		total.CPUPercent += s.CPUPercent
+		total.MemoryMB += s.MemoryMB
+	}
+	return total
+}
+
+// fillAgentResources populates CPUPercent and MemoryMB for running agents
+// from the process tree under each agent's tmux pane. Linux only; elsewhere
+// the fields stay zero.
+func fillAgentResources(status *TownStatus, t *tmux.Tmux) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+	fill := func(agents []AgentRuntime) {
+		for i := range agents {
+			if !agents[i].Running || agents[i].Session == "" {
+				continue
+			}
+			pidStr, err := t.GetPanePID(agents[i].Session)
+			if err != nil {
+				continue
+			}
+			pid, err := strconv.Atoi(strings.TrimSpace(pidStr))
+			if err != nil {
+				continue
+			}
+			s := readProcTreeStats(pid, 0)
+			agents[i].CPUPercent = s.CPUPercent
+			agents[i].MemoryMB = s.MemoryMB
+		}
+	}
+	fill(status.Agents)
+	for i := range status.Rigs {
+		fill(status.Rigs[i].Agents)
+	}
+}
+
+// formatAgentResources renders an agent's resource usage for --resources.
+func formatAgentResources(agent AgentRuntime) string {
+	if !agent.Running {
+		return ""
+	}
+	return style.Dim.Render(fmt.Sprintf(" [cpu %.1f%% mem %.0fMB]", agent.CPUPercent, agent.MemoryMB))
+}
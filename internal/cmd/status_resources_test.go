@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// writeFakeProc creates /proc-style stat and statm files for pid under root.
+// utime+stime are in clock ticks; start is the start time in ticks since boot.
+func writeFakeProc(t *testing.T, root string, pid int, comm string, utime, stime, start, rssPages int, children ...int) {
+	t.Helper()
+	dir := filepath.Join(root, strconv.Itoa(pid))
+	taskDir := filepath.Join(dir, "task", strconv.Itoa(pid))
+	if err := os.MkdirAll(taskDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stat := strconv.Itoa(pid) + " (" + comm + ") S 1 1 1 0 -1 4194560 0 0 0 0 " +
+		strconv.Itoa(utime) + " " + strconv.Itoa(stime) + " 0 0 20 0 1 0 " + strconv.Itoa(start) + " 0 0\n"
+	files := map[string]string{
+		filepath.Join(dir, "stat"):  stat,
+		filepath.Join(dir, "statm"): "9000 " + strconv.Itoa(rssPages) + " 100 1 0 500 0\n",
+	}
+	var kids string
+	for _, c := range children {
+		kids += strconv.Itoa(c) + " "
+	}
+	files[filepath.Join(taskDir, "children")] = kids
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func useFakeProc(t *testing.T, uptime string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "uptime"), []byte(uptime+" 12345.00\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := procRoot
+	procRoot = root
+	t.Cleanup(func() { procRoot = old })
+	return root
+}
+
+func pagesMB(pages int) float64 {
+	return float64(pages) * float64(os.Getpagesize()) / (1024 * 1024)
+}
+
+func TestReadProcStats(t *testing.T) {
+	root := useFakeProc(t, "20.00")
+	// 5s of CPU over the 10s since it started at tick 1000 → 50%
+	writeFakeProc(t, root, 4242, "claude code (v2)", 300, 200, 1000, 2560)
+
+	got, err := readProcStats(4242)
+	if err != nil {
+		t.Fatalf("readProcStats() error = %v", err)
+	}
+	if math.Abs(got.CPUPercent-50) > 0.001 {
+		t.Errorf("CPUPercent = %v, want 50", got.CPUPercent)
+	}
+	if want := pagesMB(2560); math.Abs(got.MemoryMB-want) > 0.001 {
+		t.Errorf("MemoryMB = %v, want %v", got.MemoryMB, want)
+	}
+}
+
+func TestReadProcStats_Errors(t *testing.T) {
+	root := useFakeProc(t, "20.00")
+
+	if _, err := readProcStats(1); err == nil {
+		t.Error("expected error for missing pid")
+	}
+
+	dir := filepath.Join(root, "7")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte("7 (short) S 1 2 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readProcStats(7); err == nil {
+		t.Error("expected error for truncated stat")
+	}
+}
+
+func TestReadProcTreeStats(t *testing.T) {
+	root := useFakeProc(t, "20.00")
+	// Idle shell wrapping a busy agent with a helper child
+	writeFakeProc(t, root, 100, "bash", 0, 0, 1000, 256, 101)
+	writeFakeProc(t, root, 101, "node", 400, 100, 1000, 2048, 102)
+	writeFakeProc(t, root, 102, "rg", 100, 0, 1000, 512)
+
+	got := readProcTreeStats(100, 0)
+	if math.Abs(got.CPUPercent-60) > 0.001 {
+		t.Errorf("CPUPercent = %v, want 60", got.CPUPercent)
+	}
+	if want := pagesMB(256 + 2048 + 512); math.Abs(got.MemoryMB-want) > 0.001 {
+		t.Errorf("MemoryMB = %v, want %v", got.MemoryMB, want)
+	}
+}
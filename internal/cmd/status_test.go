@@ -5,10 +5,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -88,7 +91,7 @@ func TestRenderAgentDetails_UsesRigPrefix(t *testing.T) {
 	}
 
 	output := captureStdout(t, func() {
-		renderAgentDetails(agent, "", nil, townRoot)
+		renderAgentDetails(os.Stdout, agent, "", nil, townRoot)
 	})
 
 	if !strings.Contains(output, "bd-beads-witness") {
@@ -122,12 +125,67 @@ func TestDiscoverRigAgents_ZombieSessionNotRunning(t *testing.T) {
 			if a.Running {
 				t.Fatal("zombie witness session (allSessions=false) should show as not running")
 			}
+			if !a.Zombie {
+				t.Fatal("zombie witness session (allSessions=false) should be flagged Zombie")
+			}
 			return
 		}
 	}
 	t.Fatal("witness agent not found in results")
 }
 
+// fixtureMailboxes serves legacy JSONL mailboxes under dir, one per address.
+type fixtureMailboxes struct {
+	dir string
+}
+
+func (f fixtureMailboxes) GetMailbox(address string) (*mail.Mailbox, error) {
+	return mail.NewMailbox(filepath.Join(f.dir, strings.ReplaceAll(address, "/", "_")+".jsonl")), nil
+}
+
+func TestDiscoverRigAgents_UnreadMail(t *testing.T) {
+	townRoot := t.TempDir()
+	writeTestRoutes(t, townRoot, []beads.Route{
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+	})
+	r := &rig.Rig{
+		Name:       "gastown",
+		Path:       filepath.Join(townRoot, "gastown"),
+		HasWitness: true,
+	}
+
+	router := fixtureMailboxes{dir: t.TempDir()}
+	mb, _ := router.GetMailbox("gastown/witness")
+	for _, subject := range []string{"Polecat stuck", "Swarm done"} {
+		if err := mb.Append(mail.NewMessage("mayor/", "gastown/witness", subject, "")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	agents := discoverRigAgents(map[string]bool{}, r, nil, nil, nil, router, false)
+	var witness *AgentRuntime
+	for i := range agents {
+		if agents[i].Role == "witness" {
+			witness = &agents[i]
+		}
+	}
+	if witness == nil {
+		t.Fatal("witness agent not found in results")
+	}
+	if witness.UnreadMail != 2 || witness.FirstSubject != "Swarm done" {
+		t.Errorf("witness mail = %d unread, first %q; want 2, %q", witness.UnreadMail, witness.FirstSubject, "Swarm done")
+	}
+	if got := sumUnreadMail(agents); got != 2 {
+		t.Errorf("sumUnreadMail() = %d, want 2", got)
+	}
+
+	// --fast skips the lookup entirely
+	agents = discoverRigAgents(map[string]bool{}, r, nil, nil, nil, router, true)
+	if got := sumUnreadMail(agents); got != 0 {
+		t.Errorf("sumUnreadMail() with skipMail = %d, want 0", got)
+	}
+}
+
 func TestDiscoverRigAgents_MissingSessionNotRunning(t *testing.T) {
 	// Verify that a session not in allSessions at all results in agent.Running=false.
 	townRoot := t.TempDir()
@@ -150,6 +208,9 @@ func TestDiscoverRigAgents_MissingSessionNotRunning(t *testing.T) {
 			if a.Running {
 				t.Fatal("witness with no tmux session should show as not running")
 			}
+			if a.Zombie {
+				t.Fatal("witness with no tmux session should not be flagged Zombie")
+			}
 			return
 		}
 	}
@@ -165,6 +226,20 @@ func TestBuildStatusIndicator_ZombieShowsStopped(t *testing.T) {
 	}
 }
 
+func TestBuildStatusIndicator_ZombieDistinctFromMissing(t *testing.T) {
+	zombie := buildStatusIndicator(AgentRuntime{Running: false, Zombie: true})
+	missing := buildStatusIndicator(AgentRuntime{Running: false})
+	if zombie == missing {
+		t.Fatalf("zombie and missing sessions should render differently, both got %q", zombie)
+	}
+	if !strings.Contains(zombie, "zombie") {
+		t.Errorf("zombie indicator %q should mention zombie", zombie)
+	}
+	if strings.Contains(missing, "zombie") {
+		t.Errorf("missing-session indicator %q should not mention zombie", missing)
+	}
+}
+
 func TestBuildStatusIndicator_AliveShowsRunning(t *testing.T) {
 	// Verify that an alive agent (Running=true) shows ● (running)
 	agent := AgentRuntime{Running: true}
@@ -202,7 +277,7 @@ func TestRunStatusWatch_RejectsNegativeInterval(t *testing.T) {
 		statusWatch = oldWatch
 	}()
 
-	statusInterval = -5
+	statusInterval = watchInterval(-5 * time.Second)
 	statusWatch = true
 
 	err := runStatusWatch(nil, nil)
@@ -214,6 +289,62 @@ func TestRunStatusWatch_RejectsNegativeInterval(t *testing.T) {
 	}
 }
 
+func TestParseWatchInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "500ms", want: 500 * time.Millisecond},
+		{in: "2s", want: 2 * time.Second},
+		{in: "1m", want: time.Minute},
+		{in: "1m30s", want: 90 * time.Second},
+		// Bare integers keep meaning seconds
+		{in: "5", want: 5 * time.Second},
+		{in: " 3 ", want: 3 * time.Second},
+		{in: "0", want: 0},
+		{in: "-2", want: -2 * time.Second},
+		{in: "fast", wantErr: true},
+		{in: "1.5", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseWatchInterval(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseWatchInterval(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseWatchInterval(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStatusIntervalFlag(t *testing.T) {
+	var interval watchInterval
+	if err := interval.Set("250ms"); err != nil || time.Duration(interval) != 250*time.Millisecond {
+		t.Errorf("Set(250ms) = %s, %v", interval.String(), err)
+	}
+	if err := interval.Set("10"); err != nil || time.Duration(interval) != 10*time.Second {
+		t.Errorf("Set(10) = %s, %v", interval.String(), err)
+	}
+	if err := interval.Set("soon"); err == nil {
+		t.Error("Set(soon) should fail")
+	}
+	if f := statusCmd.Flags().Lookup("interval"); f == nil || f.DefValue != "2s" {
+		t.Errorf("--interval default = %v, want 2s", f)
+	}
+
+	for _, bad := range []time.Duration{0, -time.Second, -time.Millisecond} {
+		if err := validateWatchInterval(bad); err == nil || !strings.Contains(err.Error(), "positive") {
+			t.Errorf("validateWatchInterval(%s) error = %v, want positive error", bad, err)
+		}
+	}
+	if err := validateWatchInterval(100 * time.Millisecond); err != nil {
+		t.Errorf("validateWatchInterval(100ms) error = %v", err)
+	}
+}
+
 func TestRunStatusWatch_RejectsJSONCombo(t *testing.T) {
 	oldJSON := statusJSON
 	oldWatch := statusWatch
@@ -226,7 +357,7 @@ func TestRunStatusWatch_RejectsJSONCombo(t *testing.T) {
 
 	statusJSON = true
 	statusWatch = true
-	statusInterval = 2
+	statusInterval = watchInterval(2 * time.Second)
 
 	err := runStatusWatch(nil, nil)
 	if err == nil {
@@ -236,3 +367,286 @@ func TestRunStatusWatch_RejectsJSONCombo(t *testing.T) {
 		t.Errorf("error %q should mention 'cannot be used together'", err.Error())
 	}
 }
+
+func TestOutputStatusPorcelain(t *testing.T) {
+	status := TownStatus{
+		Location: t.TempDir(),
+		Agents: []AgentRuntime{
+			{Name: "mayor", Session: "hq-mayor", Role: "coordinator", Running: true},
+			{Name: "deacon", Session: "hq-deacon", Role: "health-check"},
+		},
+		Rigs: []RigStatus{{
+			Name: "gastown",
+			Agents: []AgentRuntime{
+				{Name: "witness", Session: "gt-gastown-witness", Role: "witness", Running: true},
+				{Name: "toast", Session: "gt-gastown-toast", Role: "polecat", Zombie: true},
+				{Name: "max", Role: "crew"},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := outputStatusPorcelain(&buf, status); err != nil {
+		t.Fatalf("outputStatusPorcelain() error = %v", err)
+	}
+
+	want := "-\tmayor\tcoordinator\trunning\tclaude\thq-mayor\n" +
+		"-\tdeacon\thealth-check\tstopped\tclaude\thq-deacon\n" +
+		"gastown\twitness\twitness\trunning\tclaude\tgt-gastown-witness\n" +
+		"gastown\ttoast\tpolecat\tzombie\tclaude\tgt-gastown-toast\n" +
+		"gastown\tmax\tcrew\tstopped\tclaude\t-\n"
+	if got := buf.String(); got != want {
+		t.Errorf("porcelain output mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Error("porcelain output must not contain ANSI escapes")
+	}
+}
+
+func TestRunStatus_RejectsPorcelainJSONCombo(t *testing.T) {
+	oldJSON := statusJSON
+	oldPorcelain := statusPorcelain
+	defer func() {
+		statusJSON = oldJSON
+		statusPorcelain = oldPorcelain
+	}()
+
+	statusJSON = true
+	statusPorcelain = true
+
+	err := runStatus(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Errorf("runStatus() error = %v, want --porcelain/--json conflict", err)
+	}
+}
+
+func TestDiffAgents(t *testing.T) {
+	base := []AgentRuntime{
+		{Name: "mayor", Address: "mayor/", Running: true},
+		{Name: "witness", Address: "gastown/witness", Running: true},
+		{Name: "toast", Address: "gastown/toast", HasWork: true, WorkTitle: "Fix login"},
+	}
+	clone := func() []AgentRuntime { return append([]AgentRuntime(nil), base...) }
+
+	tests := []struct {
+		name        string
+		cur         func() []AgentRuntime
+		wantChanged []int
+		wantSameSet bool
+	}{
+		{"unchanged", clone, nil, true},
+		{"stopped", func() []AgentRuntime {
+			cur := clone()
+			cur[1].Running = false
+			return cur
+		}, []int{1}, true},
+		{"work title and liveness", func() []AgentRuntime {
+			cur := clone()
+			cur[0].Running = false
+			cur[2].WorkTitle = "Fix logout"
+			return cur
+		}, []int{0, 2}, true},
+		{"ignores resources", func() []AgentRuntime {
+			cur := clone()
+			cur[0].CPUPercent = 42
+			return cur
+		}, nil, true},
+		{"agent added", func() []AgentRuntime {
+			return append(clone(), AgentRuntime{Name: "nux", Address: "gastown/nux"})
+		}, nil, false},
+		{"agent replaced", func() []AgentRuntime {
+			cur := clone()
+			cur[2] = AgentRuntime{Name: "nux", Address: "gastown/nux"}
+			return cur
+		}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, sameSet := diffAgents(base, tt.cur())
+			if !reflect.DeepEqual(changed, tt.wantChanged) || sameSet != tt.wantSameSet {
+				t.Errorf("diffAgents() = %v, %v; want %v, %v", changed, sameSet, tt.wantChanged, tt.wantSameSet)
+			}
+		})
+	}
+
+	if _, sameSet := diffAgents(nil, base); sameSet {
+		t.Error("diffAgents(nil, cur) should report a different set so the first frame is drawn in full")
+	}
+}
+
+func TestClipWatchFrame(t *testing.T) {
+	lines := []string{"header", "", "a", "b", "c", "d"}
+
+	if got := clipWatchFrame(lines, 7); !reflect.DeepEqual(got, lines) {
+		t.Errorf("frame that fits: got %q, want it unchanged", got)
+	}
+
+	got := clipWatchFrame(lines, 5)
+	if len(got) != 4 {
+		t.Fatalf("clipped frame has %d rows, want height-1 = 4: %q", len(got), got)
+	}
+	if !reflect.DeepEqual(got[:3], lines[:3]) {
+		t.Errorf("clipped frame = %q, want it to start with %q", got, lines[:3])
+	}
+	if !strings.Contains(got[3], "3 more lines") {
+		t.Errorf("last row = %q, want a count of the cut lines", got[3])
+	}
+	if len(lines) != 6 || lines[3] != "b" {
+		t.Errorf("clipWatchFrame modified its input: %q", lines)
+	}
+}
+
+func TestRigBeadsBackend(t *testing.T) {
+	writeMetadata := func(t *testing.T, content string) string {
+		t.Helper()
+		rigPath := t.TempDir()
+		beadsDir := filepath.Join(rigPath, ".beads")
+		if err := os.MkdirAll(beadsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return rigPath
+	}
+
+	tests := []struct {
+		name    string
+		rigPath func(t *testing.T) string
+		want    string
+	}{
+		{
+			name:    "dolt",
+			rigPath: func(t *testing.T) string { return writeMetadata(t, `{"backend":"dolt","database":"dolt"}`) },
+			want:    "dolt",
+		},
+		{
+			name:    "sqlite",
+			rigPath: func(t *testing.T) string { return writeMetadata(t, `{"backend":"sqlite","database":"beads.db"}`) },
+			want:    "sqlite",
+		},
+		{
+			name:    "missing metadata",
+			rigPath: func(t *testing.T) string { return t.TempDir() },
+			want:    "unknown",
+		},
+		{
+			name:    "unreadable metadata",
+			rigPath: func(t *testing.T) string { return writeMetadata(t, `{not json`) },
+			want:    "unknown",
+		},
+		{
+			name:    "no backend field",
+			rigPath: func(t *testing.T) string { return writeMetadata(t, `{"database":"beads.db"}`) },
+			want:    "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rigBeadsBackend(tt.rigPath(t)); got != tt.want {
+				t.Errorf("rigBeadsBackend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRigRepoSlug(t *testing.T) {
+	rigPath := t.TempDir()
+	if got := rigRepoSlug(rigPath); got != "" {
+		t.Errorf("rigRepoSlug() without a repo base = %q, want empty", got)
+	}
+
+	bare := filepath.Join(rigPath, ".repo.git")
+	gitIn(t, rigPath, "init", "--bare", bare)
+	if got := rigRepoSlug(rigPath); got != "" {
+		t.Errorf("rigRepoSlug() without origin = %q, want empty", got)
+	}
+
+	gitIn(t, bare, "remote", "add", "origin", "https://github.com/sfncore/sf-gastown.git")
+	if got := rigRepoSlug(rigPath); got != "sfncore/sf-gastown" {
+		t.Errorf("rigRepoSlug() = %q, want sfncore/sf-gastown", got)
+	}
+}
+
+func TestFilterStaleAgents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	status := TownStatus{
+		Location: t.TempDir(),
+		Agents: []AgentRuntime{
+			{Name: "mayor", Role: "coordinator", Running: true, HasWork: true, HookBead: "hq-1", WorkUpdated: now.Add(-5 * time.Minute).Format(time.RFC3339)},
+		},
+		Rigs: []RigStatus{{
+			Name: "gastown",
+			Agents: []AgentRuntime{
+				{Name: "nux", Role: "polecat", Running: true, HasWork: true, HookBead: "gt-1", WorkTitle: "Busy", WorkUpdated: now.Add(-10 * time.Minute).Format(time.RFC3339)},
+				{Name: "toast", Role: "polecat", Running: true},
+				{Name: "slit", Role: "polecat", Running: true, HasWork: true, HookBead: "gt-2", WorkUpdated: now.Add(-3 * time.Hour).Format(time.RFC3339)},
+				{Name: "max", Role: "crew"},
+			},
+		}},
+	}
+
+	got := filterStaleAgents(status, time.Hour, now)
+
+	if len(got.Agents) != 0 {
+		t.Errorf("global agents = %+v, want none", got.Agents)
+	}
+	var names []string
+	for _, agent := range got.Rigs[0].Agents {
+		if !agent.Stale {
+			t.Errorf("%s should be marked stale", agent.Name)
+		}
+		names = append(names, agent.Name)
+	}
+	if strings.Join(names, ",") != "toast,slit" {
+		t.Errorf("stale agents = %v, want [toast slit]", names)
+	}
+	if len(status.Rigs[0].Agents) != 4 {
+		t.Error("filterStaleAgents() must not modify its input")
+	}
+
+	out := captureStdout(t, func() {
+		if err := outputStatusText(os.Stdout, got); err != nil {
+			t.Errorf("outputStatusText() error = %v", err)
+		}
+	})
+	if !strings.Contains(out, "toast") || !strings.Contains(out, "stale") {
+		t.Errorf("output should flag toast as stale:\n%s", out)
+	}
+	if strings.Contains(out, "nux") || strings.Contains(out, "max") {
+		t.Errorf("output should only list stale agents:\n%s", out)
+	}
+}
+
+func TestIsStaleAgent_UnparseableUpdate(t *testing.T) {
+	agent := AgentRuntime{Name: "nux", Running: true, HasWork: true, WorkUpdated: "yesterday"}
+	if isStaleAgent(agent, time.Minute, time.Now()) {
+		t.Error("work without a readable updated_at should not be flagged stale")
+	}
+}
+
+func TestOutputStatusText_UnregisteredRigs(t *testing.T) {
+	status := TownStatus{
+		Location:         t.TempDir(),
+		Rigs:             []RigStatus{{Name: "gastown"}},
+		UnregisteredRigs: []string{"fresh"},
+	}
+	var buf bytes.Buffer
+	if err := outputStatusText(&buf, status); err != nil {
+		t.Fatalf("outputStatusText() error = %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Unregistered rigs") || !strings.Contains(out, "gt rig add fresh --adopt") {
+		t.Errorf("output should flag the unregistered rig:\n%s", out)
+	}
+
+	buf.Reset()
+	status.UnregisteredRigs = nil
+	if err := outputStatusText(&buf, status); err != nil {
+		t.Fatalf("outputStatusText() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "Unregistered rigs") {
+		t.Errorf("no warning expected when every rig is registered:\n%s", buf.String())
+	}
+}
@@ -240,6 +240,9 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	if c.MaxConcurrent < 0 {
 		return fmt.Errorf("%w: max_concurrent must be non-negative", ErrMissingField)
 	}
+	if c.PushRetries != nil && *c.PushRetries < 0 {
+		return fmt.Errorf("%w: push_retries must be non-negative", ErrMissingField)
+	}
 
 	return nil
 }
@@ -273,7 +276,11 @@ func LoadRigSettings(path string) (*RigSettings, error) {
 		}
 		return nil, fmt.Errorf("reading settings: %w", err)
 	}
+	return ParseRigSettings(data)
+}
 
+// ParseRigSettings parses and validates rig settings JSON.
+func ParseRigSettings(data []byte) (*RigSettings, error) {
 	var settings RigSettings
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return nil, fmt.Errorf("parsing settings: %w", err)
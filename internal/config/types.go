@@ -78,6 +78,37 @@ type TownSettings struct {
 
 	// FeedCurator configures event deduplication and aggregation windows.
 	FeedCurator *FeedCuratorConfig `json:"feed_curator,omitempty"`
+
+	// AllowedTestCommands restricts which executables a rig's
+	// merge_queue.test_command may start with when landing integration
+	// branches. Empty (default) allows any command.
+	// Example: ["go", "make"]
+	AllowedTestCommands []string `json:"allowed_test_commands,omitempty"`
+
+	// CustomChecks are site-specific gt doctor checks, run as shell commands
+	// alongside the built-in checks.
+	CustomChecks []CustomCheckConfig `json:"custom_checks,omitempty"`
+}
+
+// CustomCheckConfig defines a town-specific gt doctor check. Command runs
+// through "sh -c" in the town root; exit status 0 is OK, 1 a warning and 2
+// (or any other failure) an error. The first line of its output becomes the
+// check's message and the remaining lines its details.
+type CustomCheckConfig struct {
+	// Name identifies the check in gt doctor output (e.g., "vpn-up").
+	Name string `json:"name"`
+
+	// Description is shown with --verbose and in listings.
+	Description string `json:"description,omitempty"`
+
+	// Command is the shell command to run.
+	Command string `json:"command"`
+
+	// FixHint is shown when the check doesn't pass.
+	FixHint string `json:"fix_hint,omitempty"`
+
+	// TimeoutSeconds bounds the command. 0 (default) means 30 seconds.
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -867,6 +898,34 @@ type MergeQueueConfig struct {
 	// Nil defaults to false (manual landing required).
 	IntegrationBranchAutoLand *bool `json:"integration_branch_auto_land,omitempty"`
 
+	// LandRequiresAllChildrenClosed makes an integration branch ready to land
+	// only once every child of its epic is closed. Set false to let a child
+	// (e.g., docs) trail the land. Nil defaults to true.
+	LandRequiresAllChildrenClosed *bool `json:"land_requires_all_children_closed,omitempty"`
+
+	// LandRequiresCommitsAhead makes an integration branch ready to land only
+	// when it has commits ahead of its target. Nil defaults to true.
+	LandRequiresCommitsAhead *bool `json:"land_requires_commits_ahead,omitempty"`
+
+	// PartialLand enables 'gt mq integration land --up-to', which lands an
+	// integration branch only up through one merged MR and leaves the branch
+	// and epic open. Nil defaults to false.
+	PartialLand *bool `json:"partial_land,omitempty"`
+
+	// TagOnLand is a tag name template (e.g., "epic/{epic}"). When set,
+	// landing an integration branch creates and pushes an annotated tag at
+	// the merge commit. Supports the same placeholders as
+	// IntegrationBranchTemplate. Empty (default) disables tagging.
+	TagOnLand string `json:"tag_on_land,omitempty"`
+
+	// SignCommits GPG-signs the merge commit (and tag_on_land tag) created
+	// by an integration land, for targets that require signed commits.
+	SignCommits bool `json:"sign_commits,omitempty"`
+
+	// SigningKey is the GPG key ID used when signing. Empty uses git's
+	// user.signingkey, or else the committer identity.
+	SigningKey string `json:"signing_key,omitempty"`
+
 	// OnConflict specifies conflict resolution strategy: "assign_back" or "auto_rebase".
 	OnConflict string `json:"on_conflict"`
 
@@ -874,8 +933,23 @@ type MergeQueueConfig struct {
 	RunTests bool `json:"run_tests"`
 
 	// TestCommand is the command to run for tests.
+	// Runs through the shell, so operators like && and | are supported.
 	TestCommand string `json:"test_command,omitempty"`
 
+	// TestTimeoutSeconds bounds how long TestCommand may run during an
+	// integration land. 0 (default) means no timeout.
+	TestTimeoutSeconds int `json:"test_timeout_seconds,omitempty"`
+
+	// PushRetries is how many times an integration land retries its push
+	// after a non-fast-forward rejection (re-merging and re-testing on the
+	// new target first) or a transient network error. Nil defaults to
+	// DefaultPushRetries; 0 disables retries.
+	PushRetries *int `json:"push_retries,omitempty"`
+
+	// CheckMailBeforeLand makes an integration land refuse (without --force)
+	// while the landing agent has unread mail whose subject mentions the epic.
+	CheckMailBeforeLand bool `json:"check_mail_before_land,omitempty"`
+
 	// LintCommand is the command to run for linting (used by formulas).
 	LintCommand string `json:"lint_command,omitempty"`
 
@@ -901,6 +975,18 @@ const (
 	OnConflictAutoRebase = "auto_rebase"
 )
 
+// DefaultPushRetries is the push retry count when push_retries is unset.
+const DefaultPushRetries = 2
+
+// PushRetryCount returns how many times a land retries its push. Nil-safe,
+// defaults to DefaultPushRetries.
+func (c *MergeQueueConfig) PushRetryCount() int {
+	if c.PushRetries == nil {
+		return DefaultPushRetries
+	}
+	return *c.PushRetries
+}
+
 // IsPolecatIntegrationEnabled returns whether polecat integration branch
 // sourcing is enabled. Nil-safe, defaults to true.
 func (c *MergeQueueConfig) IsPolecatIntegrationEnabled() bool {
@@ -929,6 +1015,34 @@ func (c *MergeQueueConfig) IsIntegrationBranchAutoLandEnabled() bool {
 	return *c.IntegrationBranchAutoLand
 }
 
+// RequiresAllChildrenClosedToLand returns whether every epic child must be
+// closed before an integration branch is ready to land. Nil-safe, defaults
+// to true.
+func (c *MergeQueueConfig) RequiresAllChildrenClosedToLand() bool {
+	if c.LandRequiresAllChildrenClosed == nil {
+		return true
+	}
+	return *c.LandRequiresAllChildrenClosed
+}
+
+// RequiresCommitsAheadToLand returns whether an integration branch must be
+// ahead of its target to be ready to land. Nil-safe, defaults to true.
+func (c *MergeQueueConfig) RequiresCommitsAheadToLand() bool {
+	if c.LandRequiresCommitsAhead == nil {
+		return true
+	}
+	return *c.LandRequiresCommitsAhead
+}
+
+// IsPartialLandEnabled returns whether integration branches may be landed
+// partially with --up-to. Nil-safe, defaults to false.
+func (c *MergeQueueConfig) IsPartialLandEnabled() bool {
+	if c.PartialLand == nil {
+		return false
+	}
+	return *c.PartialLand
+}
+
 // boolPtr returns a pointer to a bool value.
 func boolPtr(b bool) *bool {
 	return &b
@@ -3,6 +3,7 @@ package doctor
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
@@ -38,40 +39,8 @@ func (c *AgentTmuxConfigCheck) Run(ctx *CheckContext) *CheckResult {
 	var issues []tmuxIssue
 	var details []string
 
-	// Load town settings
-	townSettings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(ctx.TownRoot))
-	if err != nil {
-		townSettings = config.NewTownSettings()
-	}
-
-	// Collect all unique roles from town and rig settings
-	rolesToCheck := make(map[string]bool)
-
-	// Add town-level role_agents
-	for role := range townSettings.RoleAgents {
-		rolesToCheck[role] = true
-	}
-
-	// Add rig-level role_agents if checking a specific rig
-	var rigSettings *config.RigSettings
-	if ctx.RigName != "" {
-		rigPath := ctx.RigPath()
-		rigSettings, _ = config.LoadRigSettings(config.RigSettingsPath(rigPath))
-		if rigSettings != nil {
-			for role := range rigSettings.RoleAgents {
-				rolesToCheck[role] = true
-			}
-		}
-	}
-
-	// Also check all known roles even if not in role_agents (uses defaults)
-	knownRoles := []string{"mayor", "deacon", "witness", "refinery", "polecat", "crew", "dog"}
-	for _, role := range knownRoles {
-		rolesToCheck[role] = true
-	}
-
 	// Check each role
-	for role := range rolesToCheck {
+	for _, role := range roleAgentRoles(ctx) {
 		var rigPath string
 		if ctx.RigName != "" {
 			rigPath = ctx.RigPath()
@@ -110,6 +79,44 @@ func (c *AgentTmuxConfigCheck) Run(ctx *CheckContext) *CheckResult {
 	}
 }
 
+// knownAgentRoles are checked even when absent from role_agents (they use defaults).
+var knownAgentRoles = []string{"mayor", "deacon", "witness", "refinery", "polecat", "crew", "dog"}
+
+// roleAgentRoles returns the sorted set of roles to resolve runtimes for:
+// every known role plus any role named in town or (with --rig) rig role_agents.
+func roleAgentRoles(ctx *CheckContext) []string {
+	rolesToCheck := make(map[string]bool)
+
+	// Add town-level role_agents
+	townSettings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(ctx.TownRoot))
+	if err == nil {
+		for role := range townSettings.RoleAgents {
+			rolesToCheck[role] = true
+		}
+	}
+
+	// Add rig-level role_agents if checking a specific rig
+	if ctx.RigName != "" {
+		rigSettings, _ := config.LoadRigSettings(config.RigSettingsPath(ctx.RigPath()))
+		if rigSettings != nil {
+			for role := range rigSettings.RoleAgents {
+				rolesToCheck[role] = true
+			}
+		}
+	}
+
+	for _, role := range knownAgentRoles {
+		rolesToCheck[role] = true
+	}
+
+	roles := make([]string, 0, len(rolesToCheck))
+	for role := range rolesToCheck {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
 // checkTmuxConfig validates the Tmux configuration for a specific role.
 // Returns nil if OK, otherwise returns an issue description.
 func (c *AgentTmuxConfigCheck) checkTmuxConfig(role, agentName string, rc *config.RuntimeConfig) *tmuxIssue {
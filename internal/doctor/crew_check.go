@@ -295,6 +295,15 @@ func (c *CrewWorktreeCheck) Fix(ctx *CheckContext) error {
 	return lastErr
 }
 
+// PlanFix lists the worktrees Fix would remove.
+func (c *CrewWorktreeCheck) PlanFix(ctx *CheckContext) (string, error) {
+	lines := []string{fmt.Sprintf("remove %d cross-rig worktree(s):", len(c.staleWorktrees))}
+	for _, wt := range c.staleWorktrees {
+		lines = append(lines, "  "+wt.path)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
 // findCrewWorktrees finds cross-rig worktrees in crew directories.
 // These are worktrees with hyphenated names (e.g., "beads-dave") that
 // indicate they were created via `gt worktree` for cross-rig work.
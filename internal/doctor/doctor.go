@@ -3,6 +3,7 @@ package doctor
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -49,6 +50,73 @@ func (d *Doctor) Run(ctx *CheckContext) *Report {
 // If w is non-nil, prints each check name as it starts and result when done.
 // If slowThreshold > 0, shows hourglass icon for slow checks.
 func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
+	return d.runStreaming(ctx, w, slowThreshold, false)
+}
+
+// Plan runs all checks and, for each failing fixable check, records what
+// Fix would change in the result's Plan field. Nothing is fixed.
+func (d *Doctor) Plan(ctx *CheckContext) *Report {
+	return d.PlanStreaming(ctx, nil, 0)
+}
+
+// PlanStreaming is Plan with optional real-time output, like RunStreaming.
+func (d *Doctor) PlanStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration) *Report {
+	return d.runStreaming(ctx, w, slowThreshold, true)
+}
+
+// FixPlanEntry is one fix a plan run would apply: the check and what its
+// Fix would change (NoFixPlan when the check can't preview it).
+type FixPlanEntry struct {
+	Check string `json:"check"`
+	Plan  string `json:"plan"`
+}
+
+// FixPlan returns the planned fixes in a report produced by Plan, in check
+// order. Only checks that reported a problem and can fix it have a plan.
+func (r *Report) FixPlan() []FixPlanEntry {
+	entries := []FixPlanEntry{}
+	for _, result := range r.Checks {
+		if result.Plan == "" {
+			continue
+		}
+		entries = append(entries, FixPlanEntry{Check: result.Name, Plan: result.Plan})
+	}
+	return entries
+}
+
+// ResultEntry is one check's outcome in a structured doctor report. Status
+// uses the same tokens as --fail-on: ok, warning, or error.
+type ResultEntry struct {
+	Name      string   `json:"name"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message"`
+	Category  string   `json:"category,omitempty"`
+	Details   []string `json:"details,omitempty"`
+	FixHint   string   `json:"fix_hint,omitempty"`
+	Fixed     bool     `json:"fixed,omitempty"`
+	ElapsedMs int64    `json:"elapsed_ms"`
+}
+
+// Results returns every check result in the report, in check order.
+func (r *Report) Results() []ResultEntry {
+	entries := make([]ResultEntry, 0, len(r.Checks))
+	for _, result := range r.Checks {
+		entries = append(entries, ResultEntry{
+			Name:      result.Name,
+			Status:    strings.ToLower(result.Status.String()),
+			Message:   result.Message,
+			Category:  result.Category,
+			Details:   result.Details,
+			FixHint:   result.FixHint,
+			Fixed:     result.Fixed,
+			ElapsedMs: result.Elapsed.Milliseconds(),
+		})
+	}
+	return entries
+}
+
+// runStreaming executes all checks, optionally planning fixes for failures.
+func (d *Doctor) runStreaming(ctx *CheckContext, w io.Writer, slowThreshold time.Duration, plan bool) *Report {
 	report := NewReport()
 
 	for _, check := range d.checks {
@@ -70,6 +138,15 @@ func (d *Doctor) RunStreaming(ctx *CheckContext, w io.Writer, slowThreshold time
 			result.Category = cg.Category()
 		}
 
+		// Describe the fix without applying it
+		if plan && result.Status != StatusOK && check.CanFix() {
+			desc, err := PlanFix(check, ctx)
+			if err != nil {
+				desc = "plan failed: " + err.Error()
+			}
+			result.Plan = desc
+		}
+
 		// Stream: overwrite line with result
 		if w != nil {
 			var statusIcon string
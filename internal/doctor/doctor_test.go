@@ -2,7 +2,11 @@ package doctor
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/output"
 )
 
 // mockCheck is a test check that can be configured to return any status.
@@ -194,6 +198,97 @@ func TestReport_IsHealthy(t *testing.T) {
 	}
 }
 
+func TestReport_WorstStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []CheckStatus
+		want    CheckStatus
+	}{
+		{"empty", nil, StatusOK},
+		{"all OK", []CheckStatus{StatusOK, StatusOK}, StatusOK},
+		{"has warning", []CheckStatus{StatusOK, StatusWarning}, StatusWarning},
+		{"mixed", []CheckStatus{StatusError, StatusWarning, StatusOK}, StatusError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReport()
+			for _, status := range tt.results {
+				r.Add(&CheckResult{Status: status})
+			}
+			if got := r.WorstStatus(); got != tt.want {
+				t.Errorf("WorstStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFailOn(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    FailOn
+		wantErr bool
+	}{
+		{"ok", FailOnOK, false},
+		{"warning", FailOnWarning, false},
+		{"error", FailOnError, false},
+		{"never", FailOnNever, false},
+		{"ERROR", FailOnError, false},
+		{"warn", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFailOn(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFailOn(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseFailOn(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_ShouldFail(t *testing.T) {
+	okOnly := []CheckStatus{StatusOK, StatusOK}
+	withWarning := []CheckStatus{StatusOK, StatusWarning, StatusOK}
+	withError := []CheckStatus{StatusOK, StatusWarning, StatusError}
+
+	tests := []struct {
+		name      string
+		results   []CheckStatus
+		threshold FailOn
+		want      bool
+	}{
+		{"ok threshold, ok only", okOnly, FailOnOK, true},
+		{"ok threshold, warnings", withWarning, FailOnOK, true},
+		{"ok threshold, errors", withError, FailOnOK, true},
+		{"warning threshold, ok only", okOnly, FailOnWarning, false},
+		{"warning threshold, warnings", withWarning, FailOnWarning, true},
+		{"warning threshold, errors", withError, FailOnWarning, true},
+		{"error threshold, ok only", okOnly, FailOnError, false},
+		{"error threshold, warnings", withWarning, FailOnError, false},
+		{"error threshold, errors", withError, FailOnError, true},
+		{"never threshold, ok only", okOnly, FailOnNever, false},
+		{"never threshold, warnings", withWarning, FailOnNever, false},
+		{"never threshold, errors", withError, FailOnNever, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReport()
+			for _, status := range tt.results {
+				r.Add(&CheckResult{Status: status})
+			}
+			if got := r.ShouldFail(tt.threshold); got != tt.want {
+				t.Errorf("ShouldFail(%v) = %v, want %v", tt.threshold, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestReport_Print(t *testing.T) {
 	r := NewReport()
 	r.Add(&CheckResult{
@@ -330,6 +425,165 @@ func TestDoctor_Fix(t *testing.T) {
 	}
 }
 
+// plannedMockCheck is a mockCheck that also implements FixPlanner.
+type plannedMockCheck struct {
+	*mockCheck
+	plan string
+}
+
+func (p *plannedMockCheck) PlanFix(ctx *CheckContext) (string, error) {
+	return p.plan, nil
+}
+
+func TestDoctor_Plan(t *testing.T) {
+	d := NewDoctor()
+
+	okCheck := &plannedMockCheck{mockCheck: newMockCheck("ok", StatusOK), plan: "unused"}
+	okCheck.fixable = true
+	d.Register(okCheck)
+
+	planned := &plannedMockCheck{mockCheck: newMockCheck("planned", StatusError), plan: "remove /tmp/stale"}
+	planned.fixable = true
+	d.Register(planned)
+
+	unplanned := newMockCheck("unplanned", StatusWarning)
+	unplanned.fixable = true
+	d.Register(unplanned)
+
+	unfixable := newMockCheck("unfixable", StatusError)
+	d.Register(unfixable)
+
+	report := d.Plan(&CheckContext{TownRoot: "/test"})
+
+	wantPlans := []string{"", "remove /tmp/stale", NoFixPlan, ""}
+	for i, want := range wantPlans {
+		if got := report.Checks[i].Plan; got != want {
+			t.Errorf("check %q Plan = %q, want %q", report.Checks[i].Name, got, want)
+		}
+	}
+
+	// Planning must not apply any fixes
+	if planned.fixCount != 0 || unplanned.fixCount != 0 {
+		t.Error("Plan() should not call Fix()")
+	}
+	if report.Checks[1].Status != StatusError {
+		t.Error("planned check should remain Error")
+	}
+}
+
+func TestReport_FixPlanJSON(t *testing.T) {
+	d := NewDoctor()
+
+	okCheck := &plannedMockCheck{mockCheck: newMockCheck("ok", StatusOK), plan: "unused"}
+	okCheck.fixable = true
+	d.Register(okCheck)
+
+	planned := &plannedMockCheck{mockCheck: newMockCheck("planned", StatusError), plan: "remove /tmp/stale"}
+	planned.fixable = true
+	d.Register(planned)
+
+	unplanned := newMockCheck("unplanned", StatusWarning)
+	unplanned.fixable = true
+	d.Register(unplanned)
+
+	d.Register(newMockCheck("unfixable", StatusError))
+
+	data, err := json.Marshal(d.Plan(&CheckContext{TownRoot: "/test"}).FixPlan())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"check":"planned","plan":"remove /tmp/stale"},{"check":"unplanned","plan":"` + NoFixPlan + `"}]`
+	if string(data) != want {
+		t.Errorf("FixPlan JSON = %s\nwant %s", data, want)
+	}
+
+	// A healthy town plans nothing, and encodes as an empty array
+	healthy := NewDoctor()
+	healthy.Register(okCheck)
+	data, _ = json.Marshal(healthy.Plan(&CheckContext{TownRoot: "/test"}).FixPlan())
+	if string(data) != "[]" {
+		t.Errorf("healthy FixPlan JSON = %s, want []", data)
+	}
+}
+
+func TestReport_ResultsJSON(t *testing.T) {
+	report := NewReport()
+	report.Add(&CheckResult{Name: "town-config-exists", Status: StatusOK, Message: "mayor/town.json exists", Category: CategoryCore})
+	report.Add(&CheckResult{Name: "orphan-sessions", Status: StatusWarning, Message: "2 orphaned sessions", Details: []string{"gt-a", "gt-b"}, FixHint: "Run gt doctor --fix"})
+	report.Add(&CheckResult{Name: "rigs-registry-valid", Status: StatusError, Message: "invalid JSON"})
+
+	data, err := json.Marshal(report.Results())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Results JSON does not round-trip: %v\n%s", err, data)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 3: %s", len(got), data)
+	}
+	for i, want := range []struct{ name, status string }{
+		{"town-config-exists", "ok"},
+		{"orphan-sessions", "warning"},
+		{"rigs-registry-valid", "error"},
+	} {
+		if got[i]["name"] != want.name || got[i]["status"] != want.status {
+			t.Errorf("result %d = %v, want name %q status %q", i, got[i], want.name, want.status)
+		}
+	}
+	if details, _ := got[1]["details"].([]any); len(details) != 2 || got[1]["fix_hint"] != "Run gt doctor --fix" {
+		t.Errorf("details and fix hint should be carried through: %v", got[1])
+	}
+}
+
+func TestReport_ResultsTOON(t *testing.T) {
+	report := NewReport()
+	report.Add(&CheckResult{Name: "town-config-exists", Status: StatusOK, Message: "mayor/town.json exists", Category: CategoryCore})
+	report.Add(&CheckResult{Name: "orphan-sessions", Status: StatusWarning, Message: "2 orphaned sessions", Details: []string{"gt-a", "gt-b"}, FixHint: "Run gt doctor --fix"})
+	report.Add(&CheckResult{Name: "rigs-registry-valid", Status: StatusError, Message: "invalid JSON"})
+
+	data, err := output.Marshal(report.Results(), output.FormatTOON)
+	if err != nil {
+		t.Fatalf("Marshal(toon) error = %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"name: town-config-exists", "status: ok",
+		"name: orphan-sessions", "status: warning",
+		"name: rigs-registry-valid", "status: error",
+		"details[2]: gt-a,gt-b", "fix_hint: Run gt doctor --fix",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("TOON output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestReport_PrintPlan(t *testing.T) {
+	report := NewReport()
+	report.Add(&CheckResult{
+		Name:    "stale",
+		Status:  StatusWarning,
+		Message: "2 stale worktree(s)",
+		FixHint: "Run 'gt doctor --fix'",
+		Plan:    "remove 2 worktree(s):\n  /a\n  /b",
+	})
+
+	var buf bytes.Buffer
+	report.Print(&buf, false, 0)
+	out := buf.String()
+
+	for _, want := range []string{"Would fix: remove 2 worktree(s):", "  /a", "  /b"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Run 'gt doctor --fix'") {
+		t.Errorf("plan should replace the fix hint:\n%s", out)
+	}
+}
+
 func TestBaseCheck(t *testing.T) {
 	b := &BaseCheck{
 		CheckName:        "test",
@@ -0,0 +1,121 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// mrType is the issue type merge request beads should carry.
+const mrType = "merge-request"
+
+// MRTypeNormalizationCheck finds merge requests (beads labelled
+// gt:merge-request) whose issue type isn't merge-request. gt done used to
+// create them as tasks (#816); the label is authoritative, so the fix
+// retypes them to match.
+type MRTypeNormalizationCheck struct {
+	FixableCheck
+	mistyped map[string][]string // rig name -> mistyped MR IDs, cached for Fix
+}
+
+// NewMRTypeNormalizationCheck creates a new merge request type check.
+func NewMRTypeNormalizationCheck() *MRTypeNormalizationCheck {
+	return &MRTypeNormalizationCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "mr-type-normalization",
+				CheckDescription: "Detect merge requests whose type isn't merge-request",
+				CheckCategory:    CategoryCleanup,
+			},
+		},
+	}
+}
+
+// Run lists the merge requests of every rig and collects the mistyped ones.
+func (c *MRTypeNormalizationCheck) Run(ctx *CheckContext) *CheckResult {
+	c.mistyped = make(map[string][]string)
+
+	rigs, err := discoverRigs(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusError,
+			Message:  "Failed to discover rigs",
+			Details:  []string{err.Error()},
+			Category: c.Category(),
+		}
+	}
+
+	var details []string
+	total := 0
+	for _, rigName := range rigs {
+		mrs, err := beads.New(filepath.Join(ctx.TownRoot, rigName)).List(beads.ListOptions{
+			Label:    "gt:merge-request",
+			Status:   "all",
+			Priority: -1,
+		})
+		if err != nil {
+			continue // No beads database in this rig
+		}
+		ids := findMistypedMRs(mrs)
+		if len(ids) == 0 {
+			continue
+		}
+		c.mistyped[rigName] = ids
+		total += len(ids)
+		details = append(details, fmt.Sprintf("%s: %d merge request(s) with the wrong type", rigName, len(ids)))
+	}
+
+	if total == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  "All merge requests have type merge-request",
+			Category: c.Category(),
+		}
+	}
+	return &CheckResult{
+		Name:     c.Name(),
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d merge request(s) not typed merge-request", total),
+		Details:  details,
+		FixHint:  "Run 'gt doctor --fix' to set their type to merge-request",
+		Category: c.Category(),
+	}
+}
+
+// Fix sets the type of each mistyped merge request found by Run.
+func (c *MRTypeNormalizationCheck) Fix(ctx *CheckContext) error {
+	var lastErr error
+	for rigName, ids := range c.mistyped {
+		bd := beads.New(filepath.Join(ctx.TownRoot, rigName))
+		for _, id := range ids {
+			if err := bd.SetType(id, mrType); err != nil {
+				lastErr = fmt.Errorf("%s/%s: %w", rigName, id, err)
+			}
+		}
+	}
+	return lastErr
+}
+
+// PlanFix lists the merge requests Fix would retype.
+func (c *MRTypeNormalizationCheck) PlanFix(ctx *CheckContext) (string, error) {
+	total := 0
+	for _, ids := range c.mistyped {
+		total += len(ids)
+	}
+	return fmt.Sprintf("set type merge-request on %d bead(s)", total), nil
+}
+
+// findMistypedMRs returns the IDs of the gt:merge-request beads among
+// issues whose type isn't merge-request.
+func findMistypedMRs(issues []*beads.Issue) []string {
+	var ids []string
+	for _, issue := range issues {
+		if beads.HasLabel(issue, "gt:merge-request") && issue.Type != mrType {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
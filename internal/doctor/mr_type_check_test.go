@@ -0,0 +1,26 @@
+package doctor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestFindMistypedMRs(t *testing.T) {
+	issues := []*beads.Issue{
+		{ID: "gt-mr1", Type: "task", Labels: []string{"gt:merge-request"}},          // #816 drift
+		{ID: "gt-mr2", Type: "merge-request", Labels: []string{"gt:merge-request"}}, // already correct
+		{ID: "gt-mr3", Type: "", Labels: []string{"reviewed", "gt:merge-request"}},  // untyped
+		{ID: "gt-task", Type: "task", Labels: []string{"other"}},                    // not an MR
+		{ID: "gt-legacy", Type: "merge-request"},                                    // typed but unlabelled
+	}
+
+	got := findMistypedMRs(issues)
+	if want := []string{"gt-mr1", "gt-mr3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findMistypedMRs() = %v, want %v", got, want)
+	}
+	if got := findMistypedMRs(nil); got != nil {
+		t.Errorf("findMistypedMRs(nil) = %v, want nil", got)
+	}
+}
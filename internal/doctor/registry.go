@@ -0,0 +1,33 @@
+package doctor
+
+import "sync"
+
+var (
+	registryMu sync.Mutex
+	registry   []Check
+)
+
+// RegisterCheck adds a check that every gt doctor run includes after the
+// built-in checks. It lets code outside this package, and the custom_checks
+// in town settings, extend doctor without editing the fixed check list.
+// Registering a second check with the same name replaces the first. Use one
+// of the Category constants so the check is shown in the report.
+func RegisterCheck(check Check) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for i, c := range registry {
+		if c.Name() == check.Name() {
+			registry[i] = check
+			return
+		}
+	}
+	registry = append(registry, check)
+}
+
+// RegisteredChecks returns the checks added with RegisterCheck, in the
+// order they were first registered.
+func RegisteredChecks() []Check {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Check(nil), registry...)
+}
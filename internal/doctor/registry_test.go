@@ -0,0 +1,46 @@
+package doctor
+
+import "testing"
+
+func TestRegisterCheck(t *testing.T) {
+	saved := registry
+	registry = nil
+	t.Cleanup(func() { registry = saved })
+
+	first := NewShellCheck(configCheck("site-vpn", "true"))
+	other := NewShellCheck(configCheck("site-disk", "true"))
+	replacement := NewShellCheck(configCheck("site-vpn", "false"))
+
+	RegisterCheck(first)
+	RegisterCheck(other)
+	RegisterCheck(replacement)
+
+	got := RegisteredChecks()
+	if len(got) != 2 {
+		t.Fatalf("RegisteredChecks() returned %d checks, want 2", len(got))
+	}
+	if got[0] != Check(replacement) || got[1] != Check(other) {
+		t.Errorf("RegisteredChecks() = [%s, %s], want the replacement site-vpn first, then site-disk", got[0].Name(), got[1].Name())
+	}
+
+	// The returned slice is a copy
+	got[0] = nil
+	if RegisteredChecks()[0] == nil {
+		t.Error("modifying the result of RegisteredChecks() changed the registry")
+	}
+}
+
+func TestRegisteredChecks_RunWithDoctor(t *testing.T) {
+	saved := registry
+	registry = nil
+	t.Cleanup(func() { registry = saved })
+
+	RegisterCheck(NewShellCheck(configCheck("site-ok", "echo all good")))
+
+	d := NewDoctor()
+	d.RegisterAll(RegisteredChecks()...)
+	report := d.Run(&CheckContext{TownRoot: t.TempDir()})
+	if len(report.Checks) != 1 || report.Checks[0].Name != "site-ok" || report.Checks[0].Status != StatusOK {
+		t.Errorf("report checks = %+v, want site-ok passing", report.Checks)
+	}
+}
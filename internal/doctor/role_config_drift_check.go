@@ -0,0 +1,189 @@
+package doctor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// RoleConfigDriftCheck compares each role's town-level runtime config with
+// the config the rig resolves, and warns when a rig switches provider but
+// keeps provider-specific fields from the town config (for example a rig that
+// sets provider "opencode" while still carrying claude's tmux ReadyDelayMs).
+type RoleConfigDriftCheck struct {
+	BaseCheck
+}
+
+// NewRoleConfigDriftCheck creates a new town/rig role config drift check.
+func NewRoleConfigDriftCheck() *RoleConfigDriftCheck {
+	return &RoleConfigDriftCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "role-config-drift",
+			CheckDescription: "Detect rig role_agents overrides that leave stale town fields",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// providerSpecificFields are runtime fields whose defaults depend on the
+// provider. Keeping the town value for one of these after a rig changes
+// provider is almost always a leftover from a copied agent definition.
+var providerSpecificFields = map[string]bool{
+	"command":                  true,
+	"prompt_mode":              true,
+	"session.session_id_env":   true,
+	"session.config_dir_env":   true,
+	"hooks.provider":           true,
+	"hooks.dir":                true,
+	"hooks.settings_file":      true,
+	"tmux.process_names":       true,
+	"tmux.ready_prompt_prefix": true,
+	"tmux.ready_delay_ms":      true,
+	"instructions.file":        true,
+}
+
+// Run diffs the town and rig runtime config for every role.
+func (c *RoleConfigDriftCheck) Run(ctx *CheckContext) *CheckResult {
+	if ctx.RigName == "" {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rig specified (skipped)",
+		}
+	}
+
+	var drifted []string
+	var details []string
+	for _, role := range roleAgentRoles(ctx) {
+		town := config.ResolveRoleAgentConfig(role, ctx.TownRoot, "")
+		rig := config.ResolveRoleAgentConfig(role, ctx.TownRoot, ctx.RigPath())
+		if town == nil || rig == nil {
+			continue
+		}
+
+		diffs, stale := diffRoleConfig(town, rig)
+		if len(stale) == 0 {
+			continue
+		}
+		drifted = append(drifted, role)
+		details = append(details, fmt.Sprintf("role_agents[%s]: provider changed but %s kept from town config",
+			role, strings.Join(stale, ", ")))
+		for _, d := range diffs {
+			details = append(details, "  "+d)
+		}
+	}
+
+	if len(drifted) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "Rig role overrides are consistent with town config",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("Found %d role(s) with partial overrides in rig %s", len(drifted), ctx.RigName),
+		Details: details,
+		FixHint: "Set the listed fields in the rig's agent definition, or remove them so provider defaults apply",
+	}
+}
+
+// diffRoleConfig returns the field-level differences between the town and rig
+// config, and, when the provider differs, the provider-specific fields the
+// rig left at the town value.
+func diffRoleConfig(town, rig *config.RuntimeConfig) (diffs, stale []string) {
+	townFields := runtimeConfigFields(town)
+	rigFields := runtimeConfigFields(rig)
+
+	keys := make(map[string]bool)
+	for k := range townFields {
+		keys[k] = true
+	}
+	for k := range rigFields {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	providerChanged := townFields["provider"] != rigFields["provider"]
+	for _, k := range sorted {
+		tv, rv := townFields[k], rigFields[k]
+		if tv != rv {
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", k, quoteField(tv), quoteField(rv)))
+			continue
+		}
+		if providerChanged && providerSpecificFields[k] && tv != "" {
+			stale = append(stale, k)
+		}
+	}
+	return diffs, stale
+}
+
+// runtimeConfigFields flattens rc into json-style field paths. Empty values
+// are omitted. The --settings flag is dropped from args since it is derived
+// from the rig path rather than configured.
+func runtimeConfigFields(rc *config.RuntimeConfig) map[string]string {
+	fields := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+
+	set("provider", rc.Provider)
+	set("command", rc.Command)
+	set("args", strings.Join(withoutSettingsFlag(rc.Args), " "))
+	for k, v := range rc.Env {
+		set("env."+k, v)
+	}
+	set("initial_prompt", rc.InitialPrompt)
+	set("prompt_mode", rc.PromptMode)
+	if rc.Session != nil {
+		set("session.session_id_env", rc.Session.SessionIDEnv)
+		set("session.config_dir_env", rc.Session.ConfigDirEnv)
+	}
+	if rc.Hooks != nil {
+		set("hooks.provider", rc.Hooks.Provider)
+		set("hooks.dir", rc.Hooks.Dir)
+		set("hooks.settings_file", rc.Hooks.SettingsFile)
+	}
+	if rc.Tmux != nil {
+		set("tmux.process_names", strings.Join(rc.Tmux.ProcessNames, ","))
+		set("tmux.ready_prompt_prefix", rc.Tmux.ReadyPromptPrefix)
+		if rc.Tmux.ReadyDelayMs != 0 {
+			set("tmux.ready_delay_ms", strconv.Itoa(rc.Tmux.ReadyDelayMs))
+		}
+	}
+	if rc.Instructions != nil {
+		set("instructions.file", rc.Instructions.File)
+	}
+	return fields
+}
+
+// withoutSettingsFlag returns args with any "--settings <path>" pair removed.
+func withoutSettingsFlag(args []string) []string {
+	var out []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--settings" {
+			i++
+			continue
+		}
+		out = append(out, args[i])
+	}
+	return out
+}
+
+func quoteField(v string) string {
+	if v == "" {
+		return "(unset)"
+	}
+	return strconv.Quote(v)
+}
@@ -0,0 +1,119 @@
+package doctor
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// setupRoleDriftTown writes a town that assigns the "fast" agent to polecats
+// and a rig "myrig" whose own "fast" definition is given by rigAgent.
+func setupRoleDriftTown(t *testing.T, rigAgent *config.RuntimeConfig) string {
+	t.Helper()
+	townRoot := t.TempDir()
+
+	town := config.NewTownSettings()
+	town.Agents["fast"] = &config.RuntimeConfig{
+		Provider: "claude",
+		Command:  "claude",
+		Tmux: &config.RuntimeTmuxConfig{
+			ProcessNames: []string{"node", "claude"},
+			ReadyDelayMs: 8000,
+		},
+	}
+	town.RoleAgents["polecat"] = "fast"
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	rig := config.NewRigSettings()
+	rig.Agents = map[string]*config.RuntimeConfig{"fast": rigAgent}
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "myrig")), rig); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestNewRoleConfigDriftCheck(t *testing.T) {
+	c := NewRoleConfigDriftCheck()
+	if c.Name() != "role-config-drift" {
+		t.Errorf("Name() = %q, want %q", c.Name(), "role-config-drift")
+	}
+	if c.Category() != CategoryConfig {
+		t.Errorf("Category() = %q, want %q", c.Category(), CategoryConfig)
+	}
+	if c.CanFix() {
+		t.Error("CanFix() = true, want false")
+	}
+}
+
+func TestRoleConfigDriftCheck_NoRig(t *testing.T) {
+	result := NewRoleConfigDriftCheck().Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK without a rig, got %v", result.Status)
+	}
+}
+
+func TestRoleConfigDriftCheck_PartialOverride(t *testing.T) {
+	// The rig switches the agent to opencode but copies claude's tmux block.
+	townRoot := setupRoleDriftTown(t, &config.RuntimeConfig{
+		Provider: "opencode",
+		Command:  "opencode",
+		Tmux: &config.RuntimeTmuxConfig{
+			ProcessNames: []string{"node", "claude"},
+			ReadyDelayMs: 8000,
+		},
+	})
+
+	result := NewRoleConfigDriftCheck().Run(&CheckContext{TownRoot: townRoot, RigName: "myrig"})
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v: %s", result.Status, result.Message)
+	}
+
+	details := strings.Join(result.Details, "\n")
+	for _, want := range []string{
+		"role_agents[polecat]",
+		"tmux.process_names",
+		"tmux.ready_delay_ms",
+		`provider: "claude" -> "opencode"`,
+		`command: "claude" -> "opencode"`,
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("details missing %q:\n%s", want, details)
+		}
+	}
+	if strings.Contains(result.Details[0], "command") {
+		t.Errorf("command was overridden and should not be reported as stale: %q", result.Details[0])
+	}
+}
+
+func TestRoleConfigDriftCheck_FullOverride(t *testing.T) {
+	townRoot := setupRoleDriftTown(t, &config.RuntimeConfig{
+		Provider: "opencode",
+		Command:  "opencode",
+		Tmux: &config.RuntimeTmuxConfig{
+			ProcessNames: []string{"opencode"},
+			ReadyDelayMs: 5000,
+		},
+	})
+
+	result := NewRoleConfigDriftCheck().Run(&CheckContext{TownRoot: townRoot, RigName: "myrig"})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK for a complete override, got %v: %v", result.Status, result.Details)
+	}
+}
+
+func TestDiffRoleConfig_SameProvider(t *testing.T) {
+	town := &config.RuntimeConfig{Provider: "claude", Command: "claude", Tmux: &config.RuntimeTmuxConfig{ReadyDelayMs: 8000}}
+	rig := &config.RuntimeConfig{Provider: "claude", Command: "claude", Tmux: &config.RuntimeTmuxConfig{ReadyDelayMs: 3000}}
+
+	diffs, stale := diffRoleConfig(town, rig)
+	if len(stale) != 0 {
+		t.Errorf("stale = %v, want none when provider is unchanged", stale)
+	}
+	if len(diffs) != 1 || diffs[0] != `tmux.ready_delay_ms: "8000" -> "3000"` {
+		t.Errorf("diffs = %v", diffs)
+	}
+}
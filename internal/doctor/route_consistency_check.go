@@ -0,0 +1,176 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// RouteConsistencyCheck verifies that each routes.jsonl prefix matches the
+// prefix of the issue IDs actually stored in the routed beads database.
+//
+// Unlike DatabasePrefixCheck (which compares the configured issue_prefix),
+// this check samples real issue IDs. A mismatch means agent discovery will
+// build wrong bead IDs (e.g., "bd-beads-witness") for the rig.
+type RouteConsistencyCheck struct {
+	FixableCheck
+	mismatches []routeConsistencyMismatch
+
+	// sampleIssueID returns one issue ID from the beads database at rigPath,
+	// or "" if the database has no issues. Injected for testing.
+	sampleIssueID func(rigPath string) (string, error)
+}
+
+type routeConsistencyMismatch struct {
+	routePath      string // Route path from routes.jsonl
+	expectedPrefix string // Prefix from routes.jsonl (with trailing hyphen)
+	actualPrefix   string // Prefix observed in the database (with trailing hyphen)
+}
+
+// NewRouteConsistencyCheck creates a new beads route consistency check.
+func NewRouteConsistencyCheck() *RouteConsistencyCheck {
+	return &RouteConsistencyCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "beads-route-consistency",
+				CheckDescription: "Check routes.jsonl prefixes match actual issue IDs in each beads database",
+				CheckCategory:    CategoryConfig,
+			},
+		},
+		sampleIssueID: bdSampleIssueID,
+	}
+}
+
+// bdSampleIssueID lists a single issue from the rig's beads database via bd.
+func bdSampleIssueID(rigPath string) (string, error) {
+	if _, err := exec.LookPath("bd"); err != nil {
+		return "", err
+	}
+	issues, err := beads.New(rigPath).List(beads.ListOptions{
+		Status:   "all",
+		Priority: -1,
+		Limit:    1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(issues) == 0 {
+		return "", nil
+	}
+	return issues[0].ID, nil
+}
+
+// Run compares each route's prefix against a sampled issue ID from its database.
+func (c *RouteConsistencyCheck) Run(ctx *CheckContext) *CheckResult {
+	c.mismatches = nil
+
+	routes, err := beads.LoadRoutes(filepath.Join(ctx.TownRoot, ".beads"))
+	if err != nil || len(routes) == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  "No routes configured (nothing to check)",
+			Category: c.Category(),
+		}
+	}
+
+	var details []string
+	checked := 0
+	for _, route := range routes {
+		// Town root routes (hq-, hq-cv-) share one database; skip them
+		if route.Path == "." || route.Path == "" {
+			continue
+		}
+
+		rigPath := filepath.Join(ctx.TownRoot, route.Path)
+		if _, err := os.Stat(beads.ResolveBeadsDir(rigPath)); os.IsNotExist(err) {
+			continue
+		}
+
+		id, err := c.sampleIssueID(rigPath)
+		if err != nil || id == "" {
+			// Unreadable or empty database - nothing to compare against
+			continue
+		}
+		checked++
+
+		// Match on the full route prefix so multi-hyphen prefixes (e.g., "hq-cv-") work
+		if strings.HasPrefix(id, route.Prefix) {
+			continue
+		}
+
+		actual := beads.ExtractPrefix(id)
+		c.mismatches = append(c.mismatches, routeConsistencyMismatch{
+			routePath:      route.Path,
+			expectedPrefix: route.Prefix,
+			actualPrefix:   actual,
+		})
+		details = append(details, fmt.Sprintf("Route '%s': expected prefix '%s', database has '%s' (e.g., %s)",
+			route.Path, route.Prefix, actual, id))
+	}
+
+	if len(c.mismatches) == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  fmt.Sprintf("%d route(s) match their database issue prefixes", checked),
+			Category: c.Category(),
+		}
+	}
+
+	return &CheckResult{
+		Name:     c.Name(),
+		Status:   StatusError,
+		Message:  fmt.Sprintf("%d route prefix(es) don't match database issue IDs", len(c.mismatches)),
+		Details:  details,
+		FixHint:  "Run 'gt doctor --fix' to rewrite routes.jsonl prefixes to match the databases",
+		Category: c.Category(),
+	}
+}
+
+// PlanFix describes the routes.jsonl prefix rewrites Fix would make.
+func (c *RouteConsistencyCheck) PlanFix(ctx *CheckContext) (string, error) {
+	var lines []string
+	for _, m := range c.mismatches {
+		if m.actualPrefix == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("route '%s': rewrite prefix '%s' -> '%s'",
+			m.routePath, m.expectedPrefix, m.actualPrefix))
+	}
+	if len(lines) == 0 {
+		return "no routes.jsonl changes", nil
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// Fix rewrites mismatched route prefixes in routes.jsonl to the prefix
+// observed in each database. Running with --fix is the confirmation step.
+func (c *RouteConsistencyCheck) Fix(ctx *CheckContext) error {
+	if len(c.mismatches) == 0 {
+		return nil
+	}
+
+	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
+	routes, err := beads.LoadRoutes(beadsDir)
+	if err != nil {
+		return fmt.Errorf("loading routes: %w", err)
+	}
+
+	for _, m := range c.mismatches {
+		if m.actualPrefix == "" {
+			continue
+		}
+		for i := range routes {
+			if routes[i].Path == m.routePath && routes[i].Prefix == m.expectedPrefix {
+				routes[i].Prefix = m.actualPrefix
+			}
+		}
+	}
+
+	return beads.WriteRoutes(beadsDir, routes)
+}
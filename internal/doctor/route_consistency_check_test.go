@@ -0,0 +1,199 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// setupRouteConsistencyTown creates a town with routes.jsonl and a .beads
+// directory for each routed rig path.
+func setupRouteConsistencyTown(t *testing.T, routes []beads.Route) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := beads.WriteRoutes(filepath.Join(townRoot, ".beads"), routes); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range routes {
+		if r.Path == "." {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Join(townRoot, r.Path, ".beads"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return townRoot
+}
+
+func TestNewRouteConsistencyCheck(t *testing.T) {
+	check := NewRouteConsistencyCheck()
+
+	if check.Name() != "beads-route-consistency" {
+		t.Errorf("expected name 'beads-route-consistency', got %q", check.Name())
+	}
+	if !check.CanFix() {
+		t.Error("expected CanFix to return true")
+	}
+}
+
+func TestRouteConsistencyCheck_NoRoutes(t *testing.T) {
+	check := NewRouteConsistencyCheck()
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK for no routes, got %v", result.Status)
+	}
+}
+
+func TestRouteConsistencyCheck_AllMatch(t *testing.T) {
+	townRoot := setupRouteConsistencyTown(t, []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+	})
+
+	check := NewRouteConsistencyCheck()
+	check.sampleIssueID = func(rigPath string) (string, error) {
+		return "gt-abc123", nil
+	}
+
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK, got %v: %s", result.Status, result.Message)
+	}
+}
+
+func TestRouteConsistencyCheck_Mismatch(t *testing.T) {
+	townRoot := setupRouteConsistencyTown(t, []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "beads-", Path: "beads/mayor/rig"},
+	})
+
+	check := NewRouteConsistencyCheck()
+	check.sampleIssueID = func(rigPath string) (string, error) {
+		if strings.Contains(rigPath, "beads") {
+			return "bd-xyz", nil
+		}
+		return "gt-abc", nil
+	}
+
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Fatalf("expected StatusError, got %v", result.Status)
+	}
+	if len(result.Details) != 1 {
+		t.Fatalf("expected 1 detail, got %d: %v", len(result.Details), result.Details)
+	}
+	detail := result.Details[0]
+	if !strings.Contains(detail, "'beads-'") || !strings.Contains(detail, "'bd-'") {
+		t.Errorf("detail should include expected and actual prefixes, got %q", detail)
+	}
+}
+
+func TestRouteConsistencyCheck_SkipsEmptyAndUnreadable(t *testing.T) {
+	townRoot := setupRouteConsistencyTown(t, []beads.Route{
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "bd-", Path: "beads/mayor/rig"},
+	})
+
+	check := NewRouteConsistencyCheck()
+	check.sampleIssueID = func(rigPath string) (string, error) {
+		if strings.Contains(rigPath, "beads") {
+			return "", os.ErrNotExist
+		}
+		return "", nil
+	}
+
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK when databases are empty or unreadable, got %v", result.Status)
+	}
+}
+
+func TestRouteConsistencyCheck_Fix(t *testing.T) {
+	townRoot := setupRouteConsistencyTown(t, []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "beads-", Path: "beads/mayor/rig"},
+	})
+
+	check := NewRouteConsistencyCheck()
+	check.sampleIssueID = func(rigPath string) (string, error) {
+		return "bd-xyz", nil
+	}
+
+	ctx := &CheckContext{TownRoot: townRoot}
+	if result := check.Run(ctx); result.Status != StatusError {
+		t.Fatalf("expected StatusError before fix, got %v", result.Status)
+	}
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+
+	routes, err := beads.LoadRoutes(filepath.Join(townRoot, ".beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string)
+	for _, r := range routes {
+		got[r.Path] = r.Prefix
+	}
+	if got["beads/mayor/rig"] != "bd-" {
+		t.Errorf("route prefix after fix = %q, want %q", got["beads/mayor/rig"], "bd-")
+	}
+	if got["."] != "hq-" {
+		t.Errorf("town route should be untouched, got %q", got["."])
+	}
+
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("expected StatusOK after fix, got %v: %v", result.Status, result.Details)
+	}
+}
+
+func TestRouteConsistencyCheck_PlanFix(t *testing.T) {
+	townRoot := setupRouteConsistencyTown(t, []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "beads-", Path: "beads/mayor/rig"},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "wy-", Path: "wyvern/mayor/rig"},
+	})
+
+	check := NewRouteConsistencyCheck()
+	check.sampleIssueID = func(rigPath string) (string, error) {
+		switch {
+		case strings.Contains(rigPath, "beads"):
+			return "bd-xyz", nil
+		case strings.Contains(rigPath, "wyvern"):
+			return "wyv-1", nil
+		}
+		return "gt-abc", nil
+	}
+
+	ctx := &CheckContext{TownRoot: townRoot}
+	if result := check.Run(ctx); result.Status != StatusError {
+		t.Fatalf("expected StatusError, got %v", result.Status)
+	}
+
+	plan, err := check.PlanFix(ctx)
+	if err != nil {
+		t.Fatalf("PlanFix() error = %v", err)
+	}
+	want := "route 'beads/mayor/rig': rewrite prefix 'beads-' -> 'bd-'\n" +
+		"route 'wyvern/mayor/rig': rewrite prefix 'wy-' -> 'wyv-'"
+	if plan != want {
+		t.Errorf("PlanFix() = %q, want %q", plan, want)
+	}
+
+	// Planning must not touch routes.jsonl
+	routes, err := beads.LoadRoutes(filepath.Join(townRoot, ".beads"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range routes {
+		if r.Path == "beads/mayor/rig" && r.Prefix != "beads-" {
+			t.Errorf("PlanFix modified routes.jsonl: beads route prefix = %q", r.Prefix)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package doctor
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// RuntimeBinaryCheck verifies that the runtime command resolved for each
+// role (claude, opencode, codex, ...) is installed. A missing binary means
+// every agent of those roles fails at start, which is otherwise only
+// discovered when a session is launched.
+type RuntimeBinaryCheck struct {
+	BaseCheck
+
+	// lookPath and resolveRuntime are injected for testing.
+	lookPath       func(file string) (string, error)
+	resolveRuntime func(role, townRoot, rigPath string) *config.RuntimeConfig
+}
+
+// NewRuntimeBinaryCheck creates a new runtime binary check.
+func NewRuntimeBinaryCheck() *RuntimeBinaryCheck {
+	return &RuntimeBinaryCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "runtime-binary-check",
+			CheckDescription: "Verify each role's agent runtime command is installed",
+			CheckCategory:    CategoryConfig,
+		},
+		lookPath:       exec.LookPath,
+		resolveRuntime: config.ResolveRoleAgentConfig,
+	}
+}
+
+// Run resolves the runtime for every role and looks up its binary.
+func (c *RuntimeBinaryCheck) Run(ctx *CheckContext) *CheckResult {
+	var rigPath string
+	if ctx.RigName != "" {
+		rigPath = ctx.RigPath()
+	}
+
+	// Group roles by binary so each binary is looked up once
+	rolesByBinary := make(map[string][]string)
+	for _, role := range roleAgentRoles(ctx) {
+		rc := c.resolveRuntime(role, ctx.TownRoot, rigPath)
+		if rc == nil {
+			continue
+		}
+		binary := runtimeBinary(rc)
+		rolesByBinary[binary] = append(rolesByBinary[binary], role)
+	}
+
+	binaries := make([]string, 0, len(rolesByBinary))
+	for binary := range rolesByBinary {
+		binaries = append(binaries, binary)
+	}
+	sort.Strings(binaries)
+
+	var details []string
+	for _, binary := range binaries {
+		if _, err := c.lookPath(binary); err != nil {
+			details = append(details, fmt.Sprintf("%s: not found on PATH (roles that would fail to start: %s)",
+				binary, strings.Join(rolesByBinary[binary], ", ")))
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d runtime command(s) found", len(binaries)),
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d runtime command(s) not installed", len(details)),
+		Details: details,
+		FixHint: "Install the missing runtime, or point role_agents at an installed agent",
+	}
+}
+
+// runtimeBinary returns the executable a runtime config launches.
+// An empty command means the default (claude).
+func runtimeBinary(rc *config.RuntimeConfig) string {
+	fields := strings.Fields(rc.Command)
+	if len(fields) == 0 {
+		return "claude"
+	}
+	return fields[0]
+}
@@ -0,0 +1,87 @@
+package doctor
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// newTestRuntimeBinaryCheck returns a check whose roles resolve to the given
+// commands (unlisted roles get the default) and whose PATH holds installed.
+func newTestRuntimeBinaryCheck(commands map[string]string, installed ...string) *RuntimeBinaryCheck {
+	check := NewRuntimeBinaryCheck()
+	check.resolveRuntime = func(role, townRoot, rigPath string) *config.RuntimeConfig {
+		return &config.RuntimeConfig{Command: commands[role]}
+	}
+	check.lookPath = func(file string) (string, error) {
+		for _, name := range installed {
+			if name == file {
+				return "/usr/bin/" + file, nil
+			}
+		}
+		return "", exec.ErrNotFound
+	}
+	return check
+}
+
+func TestRuntimeBinaryCheck_AllPresent(t *testing.T) {
+	check := newTestRuntimeBinaryCheck(map[string]string{"crew": "opencode"}, "claude", "opencode")
+
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK, got %v: %v", result.Status, result.Details)
+	}
+}
+
+func TestRuntimeBinaryCheck_MissingBinaryListsRoles(t *testing.T) {
+	check := newTestRuntimeBinaryCheck(map[string]string{
+		"crew":    "opencode",
+		"polecat": "opencode",
+	}, "claude")
+
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v", result.Status)
+	}
+	if len(result.Details) != 1 {
+		t.Fatalf("expected 1 detail, got %d: %v", len(result.Details), result.Details)
+	}
+	detail := result.Details[0]
+	if !strings.HasPrefix(detail, "opencode:") || !strings.Contains(detail, "crew, polecat") {
+		t.Errorf("detail should name the binary and affected roles, got %q", detail)
+	}
+	if strings.Contains(detail, "mayor") {
+		t.Errorf("roles using an installed runtime should not be listed, got %q", detail)
+	}
+}
+
+func TestRuntimeBinaryCheck_DefaultRuntimeMissing(t *testing.T) {
+	check := newTestRuntimeBinaryCheck(nil)
+
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v", result.Status)
+	}
+	if !strings.HasPrefix(result.Details[0], "claude:") {
+		t.Errorf("empty command should be checked as claude, got %q", result.Details[0])
+	}
+}
+
+func TestRuntimeBinary(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"", "claude"},
+		{"opencode", "opencode"},
+		{"/opt/bin/codex", "/opt/bin/codex"},
+		{"pi --fast", "pi"},
+	}
+	for _, tt := range tests {
+		if got := runtimeBinary(&config.RuntimeConfig{Command: tt.command}); got != tt.want {
+			t.Errorf("runtimeBinary(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// defaultShellCheckTimeout bounds a custom check that sets no timeout.
+const defaultShellCheckTimeout = 30 * time.Second
+
+// ShellCheck runs a town-defined shell command as a doctor check (see
+// config.CustomCheckConfig). The exit status selects the result status and
+// the command's output becomes the message.
+type ShellCheck struct {
+	BaseCheck
+	command string
+	fixHint string
+	timeout time.Duration
+}
+
+// NewShellCheck creates a check from a custom_checks entry in town settings.
+func NewShellCheck(cfg config.CustomCheckConfig) *ShellCheck {
+	timeout := defaultShellCheckTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	description := cfg.Description
+	if description == "" {
+		description = cfg.Command
+	}
+	return &ShellCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        cfg.Name,
+			CheckDescription: description,
+			CheckCategory:    CategoryCustom,
+		},
+		command: cfg.Command,
+		fixHint: cfg.FixHint,
+		timeout: timeout,
+	}
+}
+
+// Run executes the command in the town root.
+func (c *ShellCheck) Run(ctx *CheckContext) *CheckResult {
+	runCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, "sh", "-c", c.command)
+	cmd.Dir = ctx.TownRoot
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	exitCode := 0
+	var exitErr *exec.ExitError
+	switch {
+	case runCtx.Err() == context.DeadlineExceeded:
+		return c.result(StatusError, fmt.Sprintf("timed out after %s", c.timeout), nil)
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+	case err != nil:
+		return c.result(StatusError, fmt.Sprintf("could not run: %v", err), nil)
+	}
+
+	out := strings.TrimSpace(stdout.String())
+	if out == "" {
+		out = strings.TrimSpace(stderr.String())
+	}
+	message, details := splitCheckOutput(out)
+	status := statusForExitCode(exitCode)
+	if message == "" {
+		message = fmt.Sprintf("exit status %d", exitCode)
+	}
+	return c.result(status, message, details)
+}
+
+func (c *ShellCheck) result(status CheckStatus, message string, details []string) *CheckResult {
+	r := &CheckResult{
+		Name:     c.Name(),
+		Status:   status,
+		Message:  message,
+		Details:  details,
+		Category: c.Category(),
+	}
+	if status != StatusOK {
+		r.FixHint = c.fixHint
+	}
+	return r
+}
+
+// statusForExitCode maps a custom check's exit status to a result status:
+// 0 is OK, 1 a warning, anything else an error.
+func statusForExitCode(code int) CheckStatus {
+	switch code {
+	case 0:
+		return StatusOK
+	case 1:
+		return StatusWarning
+	default:
+		return StatusError
+	}
+}
+
+// splitCheckOutput splits command output into a one-line message and the
+// remaining non-empty lines as details.
+func splitCheckOutput(out string) (string, []string) {
+	if out == "" {
+		return "", nil
+	}
+	lines := strings.Split(out, "\n")
+	var details []string
+	for _, line := range lines[1:] {
+		if line = strings.TrimRight(line, " \t\r"); line != "" {
+			details = append(details, line)
+		}
+	}
+	return strings.TrimSpace(lines[0]), details
+}
@@ -0,0 +1,85 @@
+package doctor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func configCheck(name, command string) config.CustomCheckConfig {
+	return config.CustomCheckConfig{Name: name, Command: command, FixHint: "see the runbook"}
+}
+
+func TestStatusForExitCode(t *testing.T) {
+	tests := []struct {
+		code int
+		want CheckStatus
+	}{
+		{0, StatusOK},
+		{1, StatusWarning},
+		{2, StatusError},
+		{127, StatusError},
+	}
+	for _, tt := range tests {
+		if got := statusForExitCode(tt.code); got != tt.want {
+			t.Errorf("statusForExitCode(%d) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestShellCheck_Run(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		wantStatus  CheckStatus
+		wantMessage string
+		wantDetails []string
+	}{
+		{name: "ok", command: "echo VPN is up", wantStatus: StatusOK, wantMessage: "VPN is up"},
+		{name: "warning", command: "printf 'disk 91%% full\\n/var\\n\\n/home\\n'; exit 1", wantStatus: StatusWarning, wantMessage: "disk 91% full", wantDetails: []string{"/var", "/home"}},
+		{name: "error", command: "echo license expired; exit 2", wantStatus: StatusError, wantMessage: "license expired"},
+		{name: "stderr when stdout is empty", command: "echo broken >&2; exit 2", wantStatus: StatusError, wantMessage: "broken"},
+		{name: "no output", command: "exit 1", wantStatus: StatusWarning, wantMessage: "exit status 1"},
+		{name: "other exit code", command: "exit 3", wantStatus: StatusError, wantMessage: "exit status 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := NewShellCheck(configCheck("site", tt.command))
+			result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", result.Status, tt.wantStatus)
+			}
+			if result.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", result.Message, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(result.Details, tt.wantDetails) {
+				t.Errorf("Details = %q, want %q", result.Details, tt.wantDetails)
+			}
+			if (result.FixHint != "") != (tt.wantStatus != StatusOK) {
+				t.Errorf("FixHint = %q for status %v", result.FixHint, result.Status)
+			}
+			if result.Category != CategoryCustom {
+				t.Errorf("Category = %q, want %q", result.Category, CategoryCustom)
+			}
+		})
+	}
+}
+
+func TestShellCheck_RunsInTownRoot(t *testing.T) {
+	town := t.TempDir()
+	result := NewShellCheck(configCheck("site", "pwd")).Run(&CheckContext{TownRoot: town})
+	if !strings.HasSuffix(result.Message, town) {
+		t.Errorf("Message = %q, want the town root %q", result.Message, town)
+	}
+}
+
+func TestShellCheck_Timeout(t *testing.T) {
+	cfg := configCheck("slow", "sleep 5")
+	cfg.TimeoutSeconds = 1
+	result := NewShellCheck(cfg).Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusError || !strings.Contains(result.Message, "timed out") {
+		t.Errorf("result = %+v, want a timed-out error", result)
+	}
+}
@@ -4,6 +4,7 @@ package doctor
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/ui"
@@ -18,6 +19,7 @@ const (
 	CategoryConfig        = "Configuration"
 	CategoryCleanup       = "Cleanup"
 	CategoryHooks         = "Hooks"
+	CategoryCustom        = "Custom"
 )
 
 // CategoryOrder defines the display order for categories
@@ -29,6 +31,7 @@ var CategoryOrder = []string{
 	CategoryConfig,
 	CategoryCleanup,
 	CategoryHooks,
+	CategoryCustom,
 }
 
 // CheckStatus represents the result status of a health check.
@@ -87,6 +90,7 @@ type CheckResult struct {
 	Category string        // Category for grouping (e.g., CategoryCore)
 	Elapsed  time.Duration // How long the check took to run
 	Fixed    bool          // True if this check was auto-fixed
+	Plan     string        // What Fix would change (plan mode only)
 }
 
 // Check defines the interface for a health check.
@@ -108,6 +112,26 @@ type Check interface {
 	CanFix() bool
 }
 
+// NoFixPlan is the plan reported for fixable checks that don't implement FixPlanner.
+const NoFixPlan = "no preview available"
+
+// FixPlanner is implemented by fixable checks that can describe what Fix
+// would change without changing anything. PlanFix is called after Run,
+// so it may use state cached during Run.
+type FixPlanner interface {
+	PlanFix(ctx *CheckContext) (string, error)
+}
+
+// PlanFix returns a description of what check.Fix would change.
+// Checks that don't implement FixPlanner get NoFixPlan.
+func PlanFix(check Check, ctx *CheckContext) (string, error) {
+	planner, ok := check.(FixPlanner)
+	if !ok {
+		return NoFixPlan, nil
+	}
+	return planner.PlanFix(ctx)
+}
+
 // ReportSummary summarizes the results of all checks.
 type ReportSummary struct {
 	Total       int
@@ -176,6 +200,57 @@ func (r *Report) IsHealthy() bool {
 	return r.Summary.Errors == 0 && r.Summary.Warnings == 0
 }
 
+// WorstStatus returns the most severe status across all checks.
+// An empty report is StatusOK.
+func (r *Report) WorstStatus() CheckStatus {
+	switch {
+	case r.Summary.Errors > 0:
+		return StatusError
+	case r.Summary.Warnings > 0:
+		return StatusWarning
+	default:
+		return StatusOK
+	}
+}
+
+// FailOn is the minimum aggregate status that makes a doctor run fail.
+type FailOn int
+
+const (
+	// FailOnOK fails whenever any check ran (useful for testing pipelines).
+	FailOnOK FailOn = iota
+	// FailOnWarning fails on warnings or errors.
+	FailOnWarning
+	// FailOnError fails only on errors (default).
+	FailOnError
+	// FailOnNever always exits successfully.
+	FailOnNever
+)
+
+// ParseFailOn parses a --fail-on level: ok, warning, error, or never.
+func ParseFailOn(s string) (FailOn, error) {
+	switch strings.ToLower(s) {
+	case "ok":
+		return FailOnOK, nil
+	case "warning":
+		return FailOnWarning, nil
+	case "error":
+		return FailOnError, nil
+	case "never":
+		return FailOnNever, nil
+	default:
+		return 0, fmt.Errorf("invalid fail-on level %q (want ok, warning, error, or never)", s)
+	}
+}
+
+// ShouldFail reports whether the report's worst status meets the threshold.
+func (r *Report) ShouldFail(threshold FailOn) bool {
+	if threshold == FailOnNever {
+		return false
+	}
+	return int(r.WorstStatus()) >= int(threshold)
+}
+
 // PrintSummaryOnly outputs just the summary and warnings section.
 // Used after streaming output where checks were already printed as they ran.
 // Slow checks are already counted during streaming, so slowThreshold is only
@@ -386,9 +461,7 @@ func (r *Report) printWarningsSection(w io.Writer, issues []*CheckResult) {
 		for i, check := range failures {
 			line := fmt.Sprintf("%s: %s", check.Name, check.Message)
 			_, _ = fmt.Fprintf(w, "  %s  %s %s\n", ui.RenderFailIcon(), ui.RenderFail(fmt.Sprintf("%d.", i+1)), ui.RenderFail(line))
-			if check.FixHint != "" {
-				_, _ = fmt.Fprintf(w, "        %s%s\n", ui.MutedStyle.Render(ui.TreeLast), check.FixHint)
-			}
+			printFixGuidance(w, check)
 		}
 	}
 
@@ -399,9 +472,7 @@ func (r *Report) printWarningsSection(w io.Writer, issues []*CheckResult) {
 		for i, check := range warnings {
 			line := fmt.Sprintf("%s: %s", check.Name, check.Message)
 			_, _ = fmt.Fprintf(w, "  %s  %s %s\n", ui.RenderWarnIcon(), ui.RenderWarn(fmt.Sprintf("%d.", i+1)), line)
-			if check.FixHint != "" {
-				_, _ = fmt.Fprintf(w, "        %s%s\n", ui.MutedStyle.Render(ui.TreeLast), check.FixHint)
-			}
+			printFixGuidance(w, check)
 		}
 	}
 
@@ -421,3 +492,19 @@ func (r *Report) printWarningsSection(w io.Writer, issues []*CheckResult) {
 		_, _ = fmt.Fprintln(w, ui.RenderPass(ui.IconPass+" All remaining checks passed"))
 	}
 }
+
+// printFixGuidance prints a check's fix plan (plan mode) or its fix hint
+// beneath the check's line in the warnings section.
+func printFixGuidance(w io.Writer, check *CheckResult) {
+	if check.Plan != "" {
+		lines := strings.Split(check.Plan, "\n")
+		_, _ = fmt.Fprintf(w, "        %sWould fix: %s\n", ui.MutedStyle.Render(ui.TreeLast), lines[0])
+		for _, line := range lines[1:] {
+			_, _ = fmt.Fprintf(w, "          %s\n", line)
+		}
+		return
+	}
+	if check.FixHint != "" {
+		_, _ = fmt.Fprintf(w, "        %s%s\n", ui.MutedStyle.Render(ui.TreeLast), check.FixHint)
+	}
+}
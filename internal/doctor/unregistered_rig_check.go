@@ -0,0 +1,66 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// UnregisteredRigCheck finds rig directories in the town (a .repo.git or
+// mayor/rig inside) that have no entry in mayor/rigs.json. Commands that read
+// the registry, including gt status, don't see them.
+type UnregisteredRigCheck struct {
+	BaseCheck
+}
+
+// NewUnregisteredRigCheck creates a new unregistered rig check.
+func NewUnregisteredRigCheck() *UnregisteredRigCheck {
+	return &UnregisteredRigCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "unregistered-rigs",
+			CheckDescription: "Check for rig directories missing from rigs.json",
+			CheckCategory:    CategoryRig,
+		},
+	}
+}
+
+// Run compares rig directories on disk with the rigs.json registry.
+func (c *UnregisteredRigCheck) Run(ctx *CheckContext) *CheckResult {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	if err != nil {
+		rigsConfig = nil // Unreadable registry: every rig on disk is unregistered
+	}
+
+	unregistered, err := workspace.UnregisteredRigs(ctx.TownRoot, rigsConfig)
+	if err != nil {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusWarning,
+			Message:  fmt.Sprintf("Could not scan town for rigs: %v", err),
+			Category: c.Category(),
+		}
+	}
+	if len(unregistered) == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  "All rig directories are registered",
+			Category: c.Category(),
+		}
+	}
+
+	details := make([]string, 0, len(unregistered))
+	for _, name := range unregistered {
+		details = append(details, fmt.Sprintf("%s: on disk but not in mayor/rigs.json", name))
+	}
+	return &CheckResult{
+		Name:     c.Name(),
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d rig director(ies) not registered", len(unregistered)),
+		Details:  details,
+		FixHint:  "Register each with 'gt rig add <name> --adopt', or remove the directory",
+		Category: c.Category(),
+	}
+}
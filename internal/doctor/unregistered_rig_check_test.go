@@ -0,0 +1,40 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnregisteredRigCheck_Run(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"mayor", "gastown/.repo.git", "fresh/mayor/rig"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rigsJSON := `{"version":1,"rigs":{"gastown":{"git_url":"https://example.com/gastown.git"}}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewUnregisteredRigCheck()
+	result := check.Run(&CheckContext{TownRoot: tmpDir})
+
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.HasPrefix(result.Details[0], "fresh:") {
+		t.Errorf("Details = %v, want only the unregistered rig 'fresh'", result.Details)
+	}
+
+	// Registering it clears the warning
+	rigsJSON = `{"version":1,"rigs":{"gastown":{"git_url":"x"},"fresh":{"git_url":"y"}}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Run(&CheckContext{TownRoot: tmpDir}); result.Status != StatusOK {
+		t.Errorf("expected StatusOK once registered, got %v: %s", result.Status, result.Details)
+	}
+}
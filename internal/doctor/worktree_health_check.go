@@ -0,0 +1,176 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// WorktreeHealthCheck lists the worktrees of each rig's repository and flags
+// ones git operations will trip over: worktrees whose directory is gone,
+// worktrees on a branch that no longer exists, and long-lived clones (mayor,
+// refinery, crew) left on a detached HEAD.
+type WorktreeHealthCheck struct {
+	FixableCheck
+	prunableRigs []string // Rig clone paths with prunable worktrees, cached for Fix
+}
+
+// NewWorktreeHealthCheck creates a new worktree health check.
+func NewWorktreeHealthCheck() *WorktreeHealthCheck {
+	return &WorktreeHealthCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "worktree-health",
+				CheckDescription: "Detect missing, branchless, or detached rig worktrees",
+				CheckCategory:    CategoryRig,
+			},
+		},
+	}
+}
+
+// worktreeProblem is one unhealthy worktree of a rig.
+type worktreeProblem struct {
+	Path     string
+	Reason   string
+	Prunable bool // git worktree prune removes it
+}
+
+// Run inspects the worktrees of every registered rig's mayor clone.
+func (c *WorktreeHealthCheck) Run(ctx *CheckContext) *CheckResult {
+	c.prunableRigs = nil
+
+	rigs := loadRigNames(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	names := make([]string, 0, len(rigs))
+	for name := range rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var details []string
+	for _, name := range names {
+		rigPath := filepath.Join(ctx.TownRoot, name)
+		clone := filepath.Join(rigPath, "mayor", "rig")
+		if _, err := os.Stat(clone); err != nil {
+			continue // mayor-clone-exists reports this
+		}
+		g := git.NewGit(clone)
+		worktrees, err := g.WorktreeList()
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: listing worktrees: %v", name, err))
+			continue
+		}
+		problems := findWorktreeProblems(rigPath, worktrees, branchExistsFunc(g), pathExists)
+		prunable := false
+		for _, p := range problems {
+			details = append(details, fmt.Sprintf("%s: %s", relOrAbs(ctx.TownRoot, p.Path), p.Reason))
+			prunable = prunable || p.Prunable
+		}
+		if prunable {
+			c.prunableRigs = append(c.prunableRigs, clone)
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:     c.Name(),
+			Status:   StatusOK,
+			Message:  "All rig worktrees are healthy",
+			Category: c.Category(),
+		}
+	}
+	return &CheckResult{
+		Name:     c.Name(),
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d unhealthy worktree(s)", len(details)),
+		Details:  details,
+		FixHint:  "Run 'gt doctor --fix' (git worktree prune) to drop missing worktrees; check out a branch in the others",
+		Category: c.Category(),
+	}
+}
+
+// Fix prunes stale worktree entries in each rig that had missing worktrees.
+// Detached and branchless worktrees need a person to pick a branch.
+func (c *WorktreeHealthCheck) Fix(ctx *CheckContext) error {
+	for _, clone := range c.prunableRigs {
+		if err := git.NewGit(clone).WorktreePrune(); err != nil {
+			return fmt.Errorf("pruning worktrees in %s: %w", clone, err)
+		}
+	}
+	return nil
+}
+
+// PlanFix describes which rigs Fix would prune.
+func (c *WorktreeHealthCheck) PlanFix(ctx *CheckContext) (string, error) {
+	if len(c.prunableRigs) == 0 {
+		return "nothing to prune; detached and branchless worktrees need a manual checkout", nil
+	}
+	rels := make([]string, 0, len(c.prunableRigs))
+	for _, clone := range c.prunableRigs {
+		rels = append(rels, relOrAbs(ctx.TownRoot, clone))
+	}
+	return "run 'git worktree prune' in " + strings.Join(rels, ", "), nil
+}
+
+// findWorktreeProblems returns the unhealthy worktrees among worktrees of
+// the rig at rigPath.
+func findWorktreeProblems(rigPath string, worktrees []git.Worktree, branchExists, pathExists func(string) bool) []worktreeProblem {
+	var problems []worktreeProblem
+	for _, wt := range worktrees {
+		switch {
+		case wt.Prunable || !pathExists(wt.Path):
+			problems = append(problems, worktreeProblem{Path: wt.Path, Reason: "directory no longer exists (prunable)", Prunable: true})
+		case wt.Branch != "" && !branchExists(wt.Branch):
+			problems = append(problems, worktreeProblem{Path: wt.Path, Reason: fmt.Sprintf("branch '%s' no longer exists", wt.Branch)})
+		case wt.Detached && expectsBranch(rigPath, wt.Path):
+			problems = append(problems, worktreeProblem{Path: wt.Path, Reason: "detached HEAD (expected a branch)"})
+		}
+	}
+	return problems
+}
+
+// expectsBranch reports whether the worktree at path is a long-lived clone
+// that should always be on a branch: mayor/rig, refinery/rig, or a crew
+// workspace. Polecat and temporary worktrees may be detached.
+func expectsBranch(rigPath, path string) bool {
+	rel, err := filepath.Rel(rigPath, path)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	switch {
+	case len(parts) == 2 && parts[1] == "rig":
+		return parts[0] == "mayor" || parts[0] == "refinery"
+	case len(parts) == 2 && parts[0] == "crew":
+		return true
+	}
+	return false
+}
+
+// branchExistsFunc adapts g.BranchExists for findWorktreeProblems. A branch
+// that can't be checked is assumed to exist.
+func branchExistsFunc(g *git.Git) func(string) bool {
+	return func(branch string) bool {
+		exists, err := g.BranchExists(branch)
+		return err != nil || exists
+	}
+}
+
+// pathExists reports whether path exists on disk.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// relOrAbs returns path relative to townRoot, or path itself if it is
+// outside the town.
+func relOrAbs(townRoot, path string) string {
+	rel, err := filepath.Rel(townRoot, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return rel
+}
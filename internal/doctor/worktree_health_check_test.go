@@ -0,0 +1,55 @@
+package doctor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestFindWorktreeProblems(t *testing.T) {
+	rig := "/town/gastown"
+	worktrees := []git.Worktree{
+		{Path: "/town/gastown/.repo.git"},
+		{Path: "/town/gastown/mayor/rig", Branch: "main"},
+		{Path: "/town/gastown/refinery/rig", Detached: true},
+		{Path: "/town/gastown/crew/max", Branch: "feature/deleted"},
+		{Path: "/town/gastown/polecats/nux", Detached: true},
+		{Path: "/town/gastown/polecats/toast", Branch: "polecat/toast", Prunable: true},
+		{Path: "/tmp/gt-land-123", Branch: "main"},
+	}
+	branches := map[string]bool{"main": true, "polecat/toast": true}
+	onDisk := map[string]bool{
+		"/town/gastown/.repo.git":    true,
+		"/town/gastown/mayor/rig":    true,
+		"/town/gastown/refinery/rig": true,
+		"/town/gastown/crew/max":     true,
+		"/town/gastown/polecats/nux": true,
+		// polecats/toast was deleted; so was the land worktree
+	}
+
+	got := findWorktreeProblems(rig, worktrees,
+		func(b string) bool { return branches[b] },
+		func(p string) bool { return onDisk[p] })
+
+	want := []worktreeProblem{
+		{Path: "/town/gastown/refinery/rig", Reason: "detached HEAD (expected a branch)"},
+		{Path: "/town/gastown/crew/max", Reason: "branch 'feature/deleted' no longer exists"},
+		{Path: "/town/gastown/polecats/toast", Reason: "directory no longer exists (prunable)", Prunable: true},
+		{Path: "/tmp/gt-land-123", Reason: "directory no longer exists (prunable)", Prunable: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findWorktreeProblems() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestWorktreeHealthCheck_NoRigs(t *testing.T) {
+	check := NewWorktreeHealthCheck()
+	result := check.Run(&CheckContext{TownRoot: t.TempDir()})
+	if result.Status != StatusOK {
+		t.Errorf("expected StatusOK with no rigs, got %v: %s", result.Status, result.Message)
+	}
+	if !check.CanFix() {
+		t.Error("worktree-health should be fixable")
+	}
+}
@@ -68,16 +68,43 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// Integration branch land events (emitted to the GT_EVENTS_FILE sink)
+	TypeLandStarted     = "land_started"
+	TypeLandMerged      = "land_merged"
+	TypeLandTestsPassed = "land_tests_passed"
+	TypeLandPushed      = "land_pushed"
+	TypeLandCompleted   = "land_completed"
+	TypeLandFailed      = "land_failed"
 )
 
 // EventsFile is the name of the raw events log.
 const EventsFile = ".events.jsonl"
 
+// SinkEnv names the environment variable pointing at an extra NDJSON file
+// that Emit appends to, for shipping events to a log aggregator.
+const SinkEnv = "GT_EVENTS_FILE"
+
 // Log writes an event to the events log.
 // The event is appended to ~/gt/.events.jsonl.
 // Returns nil if logging fails (events are best-effort).
 func Log(eventType, actor string, payload map[string]interface{}, visibility string) error {
-	event := Event{
+	return write(newEvent(eventType, actor, payload, visibility))
+}
+
+// Emit writes an event to the GT_EVENTS_FILE sink. It is a no-op when the
+// variable is unset, so callers can emit unconditionally. Unlike Log, the
+// sink does not depend on being inside a workspace.
+func Emit(eventType, actor string, payload map[string]interface{}) error {
+	path := os.Getenv(SinkEnv)
+	if path == "" {
+		return nil
+	}
+	return appendEvent(path, newEvent(eventType, actor, payload, VisibilityAudit))
+}
+
+func newEvent(eventType, actor string, payload map[string]interface{}, visibility string) Event {
+	return Event{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		Source:     "gt",
 		Type:       eventType,
@@ -85,7 +112,6 @@ func Log(eventType, actor string, payload map[string]interface{}, visibility str
 		Payload:    payload,
 		Visibility: visibility,
 	}
-	return write(event)
 }
 
 // LogFeed is a convenience wrapper for feed-visible events.
@@ -109,8 +135,11 @@ func write(event Event) error {
 		return nil
 	}
 
-	eventsPath := filepath.Join(townRoot, EventsFile)
+	return appendEvent(filepath.Join(townRoot, EventsFile), event)
+}
 
+// appendEvent appends event as one JSON line to the file at eventsPath.
+func appendEvent(eventsPath string, event Event) error {
 	// Marshal event to JSON
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -215,6 +244,20 @@ func MergePayload(mrID, worker, branch, reason string) map[string]interface{} {
 	return p
 }
 
+// LandPayload creates a payload for integration branch land events.
+// reason: failure reason (for land_failed events)
+func LandPayload(epic, branch, target, reason string) map[string]interface{} {
+	p := map[string]interface{}{
+		"epic":   epic,
+		"branch": branch,
+		"target": target,
+	}
+	if reason != "" {
+		p["reason"] = reason
+	}
+	return p
+}
+
 // PatrolPayload creates a payload for patrol start/complete events.
 func PatrolPayload(rig string, polecatCount int, message string) map[string]interface{} {
 	p := map[string]interface{}{
@@ -3,12 +3,16 @@ package git
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // GitError contains raw output from a git command for agent observation.
@@ -84,21 +88,34 @@ func (g *Git) IsRepo() bool {
 
 // run executes a git command and returns stdout.
 func (g *Git) run(args ...string) (string, error) {
+	return g.runContext(context.Background(), args...)
+}
+
+// runContext is run with a context. If ctx ends before git exits, git is
+// killed; a deadline is reported as ErrNetworkTimeout.
+func (g *Git) runContext(ctx context.Context, args ...string) (string, error) {
 	// If gitDir is set (bare repo), prepend --git-dir flag
 	if g.gitDir != "" {
 		args = append([]string{"--git-dir=" + g.gitDir}, args...)
 	}
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.CommandContext(ctx, "git", args...)
 	if g.workDir != "" {
 		cmd.Dir = g.workDir
 	}
+	if ctx.Done() != nil {
+		// Don't wait on pipes held open by ssh or credential helpers
+		cmd.WaitDelay = time.Second
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
 	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("%w: git %s", ErrNetworkTimeout, strings.Join(args, " "))
+	}
 	if err != nil {
 		return "", g.wrapError(err, stdout.String(), stderr.String(), args)
 	}
@@ -106,6 +123,52 @@ func (g *Git) run(args ...string) (string, error) {
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// runNetwork runs a git command that talks to a remote, bounded by
+// NetworkTimeout.
+func (g *Git) runNetwork(args ...string) (string, error) {
+	ctx, cancel := networkContext()
+	defer cancel()
+	return g.runContext(ctx, args...)
+}
+
+// NetworkTimeoutEnv names the environment variable holding the default
+// timeout for git network operations, as a Go duration (e.g., "2m").
+const NetworkTimeoutEnv = "GT_GIT_TIMEOUT"
+
+// ErrNetworkTimeout is returned when a fetch, pull, push or ls-remote
+// exceeds its timeout.
+var ErrNetworkTimeout = errors.New("git network operation timed out")
+
+// networkTimeoutOverride is set by SetNetworkTimeout (e.g., from --git-timeout).
+var networkTimeoutOverride time.Duration
+
+// SetNetworkTimeout sets the timeout for git network operations in this
+// process, taking precedence over GT_GIT_TIMEOUT. 0 restores the default.
+func SetNetworkTimeout(d time.Duration) {
+	networkTimeoutOverride = d
+}
+
+// NetworkTimeout returns the timeout applied to git network operations:
+// the SetNetworkTimeout value, else GT_GIT_TIMEOUT, else 0 (no timeout).
+// An unparseable GT_GIT_TIMEOUT is ignored.
+func NetworkTimeout() time.Duration {
+	if networkTimeoutOverride > 0 {
+		return networkTimeoutOverride
+	}
+	if d, err := time.ParseDuration(os.Getenv(NetworkTimeoutEnv)); err == nil && d > 0 {
+		return d
+	}
+	return 0
+}
+
+// networkContext returns a context bounded by NetworkTimeout.
+func networkContext() (context.Context, context.CancelFunc) {
+	if d := NetworkTimeout(); d > 0 {
+		return context.WithTimeout(context.Background(), d)
+	}
+	return context.Background(), func() {}
+}
+
 // wrapError wraps git errors with context.
 // ZFC: Returns GitError with raw output for agent observation.
 // Does not detect or interpret error types - agents should observe and decide.
@@ -333,38 +396,82 @@ func (g *Git) Checkout(ref string) error {
 	return err
 }
 
-// Fetch fetches from the remote.
+// Fetch fetches from the remote, bounded by NetworkTimeout.
 func (g *Git) Fetch(remote string) error {
-	_, err := g.run("fetch", remote)
+	ctx, cancel := networkContext()
+	defer cancel()
+	return g.FetchWithContext(ctx, remote)
+}
+
+// FetchWithContext fetches from the remote, giving up when ctx ends.
+func (g *Git) FetchWithContext(ctx context.Context, remote string) error {
+	_, err := g.runContext(ctx, "fetch", remote)
 	return err
 }
 
 // FetchPrune fetches from the remote and prunes stale remote-tracking refs.
 // This removes remote-tracking branches for branches that no longer exist on the remote.
 func (g *Git) FetchPrune(remote string) error {
-	_, err := g.run("fetch", "--prune", remote)
+	_, err := g.runNetwork("fetch", "--prune", remote)
 	return err
 }
 
 // FetchBranch fetches a specific branch from the remote.
 func (g *Git) FetchBranch(remote, branch string) error {
-	_, err := g.run("fetch", remote, branch)
+	_, err := g.runNetwork("fetch", remote, branch)
 	return err
 }
 
-// Pull pulls from the remote branch.
+// Pull pulls from the remote branch, bounded by NetworkTimeout.
 func (g *Git) Pull(remote, branch string) error {
-	_, err := g.run("pull", remote, branch)
+	ctx, cancel := networkContext()
+	defer cancel()
+	return g.PullWithContext(ctx, remote, branch)
+}
+
+// PullWithContext pulls from the remote branch, giving up when ctx ends.
+func (g *Git) PullWithContext(ctx context.Context, remote, branch string) error {
+	_, err := g.runContext(ctx, "pull", remote, branch)
 	return err
 }
 
-// Push pushes to the remote branch.
+// Push pushes to the remote branch, bounded by NetworkTimeout.
 func (g *Git) Push(remote, branch string, force bool) error {
+	ctx, cancel := networkContext()
+	defer cancel()
+	return g.PushWithContext(ctx, remote, branch, force)
+}
+
+// PushWithContext pushes to the remote branch, giving up when ctx ends.
+func (g *Git) PushWithContext(ctx context.Context, remote, branch string, force bool) error {
 	args := []string{"push", remote, branch}
 	if force {
 		args = append(args, "--force")
 	}
-	_, err := g.run(args...)
+	_, err := g.runContext(ctx, args...)
+	return err
+}
+
+// CreateTag creates an annotated tag pointing at ref.
+func (g *Git) CreateTag(name, ref, message string) error {
+	_, err := g.run("tag", "-a", name, "-m", message, ref)
+	return err
+}
+
+// CreateSignedTag creates a GPG-signed annotated tag pointing at ref. key
+// names the signing key; "" lets git pick it as for MergeNoFFSigned.
+func (g *Git) CreateSignedTag(name, ref, message, key string) error {
+	args := []string{"tag", "-s"}
+	if key != "" {
+		args = []string{"tag", "-u", key}
+	}
+	_, err := g.run(append(args, name, "-m", message, ref)...)
+	return err
+}
+
+// PushTag pushes a single tag to the remote.
+func (g *Git) PushTag(remote, name string) error {
+	_, err := g.runNetwork("push", remote, "refs/tags/"+name)
 	return err
 }
 
@@ -432,6 +539,7 @@ func (g *Git) Status() (*GitStatus, error) {
 }
 
 // CurrentBranch returns the current branch name.
+// Returns "HEAD" when the working tree is in detached-HEAD state.
 func (g *Git) CurrentBranch() (string, error) {
 	return g.run("rev-parse", "--abbrev-ref", "HEAD")
 }
@@ -493,6 +601,45 @@ func (g *Git) RemoteURL(remote string) (string, error) {
 	return g.run("remote", "get-url", remote)
 }
 
+// ParseRepoSlug extracts the repository path, e.g. "owner/repo", from a
+// remote URL. It understands scp-style SSH (git@host:owner/repo.git) and
+// ssh://, https:// and http:// URLs, with or without a .git suffix. GitLab
+// subgroups are kept ("group/sub/repo"). Returns false for local paths and
+// URLs without at least an owner and a repo.
+func ParseRepoSlug(url string) (string, bool) {
+	url = strings.TrimSpace(url)
+	var path string
+	if i := strings.Index(url, "://"); i >= 0 {
+		rest := url[i+3:]
+		slash := strings.Index(rest, "/")
+		if slash < 0 {
+			return "", false
+		}
+		if url[:i] == "file" {
+			return "", false
+		}
+		path = rest[slash+1:]
+	} else if colon := strings.Index(url, ":"); colon > 1 && !strings.ContainsAny(url[:colon], "/\\") {
+		// scp-style host:path; a slash before the colon (or a drive letter)
+		// means a local path
+		path = url[colon+1:]
+	} else {
+		return "", false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return "", false
+	}
+	for _, part := range parts {
+		if part == "" {
+			return "", false
+		}
+	}
+	return path, true
+}
+
 // AddRemote adds a new remote with the given name and URL.
 func (g *Git) AddRemote(name, url string) (string, error) {
 	return g.run("remote", "add", name, url)
@@ -538,6 +685,21 @@ func (g *Git) MergeNoFF(branch, message string) error {
 	return err
 }
 
+// MergeNoFFSigned is MergeNoFF with a GPG-signed merge commit. key names
+// the signing key; "" lets git use user.signingkey or the committer identity.
+func (g *Git) MergeNoFFSigned(branch, message, key string) error {
+	_, err := g.run("merge", "--no-ff", gpgSignArg(key), "-m", message, branch)
+	return err
+}
+
+// gpgSignArg returns the --gpg-sign flag for key ("" for the default key).
+func gpgSignArg(key string) string {
+	if key == "" {
+		return "--gpg-sign"
+	}
+	return "--gpg-sign=" + key
+}
+
 // MergeSquash performs a squash merge of the given branch and commits with the provided message.
 // This stages all changes from the branch without creating a merge commit, then commits them
 // as a single commit with the given message. This eliminates redundant merge commits while
@@ -561,7 +723,7 @@ func (g *Git) GetBranchCommitMessage(branch string) (string, error) {
 
 // DeleteRemoteBranch deletes a branch on the remote.
 func (g *Git) DeleteRemoteBranch(remote, branch string) error {
-	_, err := g.run("push", remote, "--delete", branch)
+	_, err := g.runNetwork("push", remote, "--delete", branch)
 	return err
 }
 
@@ -691,12 +853,12 @@ func (g *Git) BranchExists(name string) (bool, error) {
 
 // RemoteBranchExists checks if a branch exists on the remote.
 func (g *Git) RemoteBranchExists(remote, branch string) (bool, error) {
-	_, err := g.run("ls-remote", "--heads", remote, branch)
+	_, err := g.runNetwork("ls-remote", "--heads", remote, branch)
 	if err != nil {
 		return false, err
 	}
 	// ls-remote returns empty if branch doesn't exist, need to check output
-	out, err := g.run("ls-remote", "--heads", remote, branch)
+	out, err := g.runNetwork("ls-remote", "--heads", remote, branch)
 	if err != nil {
 		return false, err
 	}
@@ -745,6 +907,31 @@ func (g *Git) ListBranches(pattern string) ([]string, error) {
 	return strings.Split(out, "\n"), nil
 }
 
+// ListRemoteBranches returns remote-tracking branches for remote matching a pattern.
+// Pattern is relative to the remote (e.g., "integration/*" matches origin/integration/*).
+// Returns branch names without the remote prefix. Does not hit the network.
+func (g *Git) ListRemoteBranches(remote, pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	out, err := g.run("branch", "-r", "--list", "--format=%(refname:short)", remote+"/"+pattern)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	var branches []string
+	for _, line := range strings.Split(out, "\n") {
+		name := strings.TrimPrefix(line, remote+"/")
+		if name == "" || name == "HEAD" || name == line {
+			continue
+		}
+		branches = append(branches, name)
+	}
+	return branches, nil
+}
+
 // ResetBranch force-updates a branch to point to a ref.
 // This is useful for resetting stale polecat branches to main.
 func (g *Git) ResetBranch(name, ref string) error {
@@ -770,6 +957,22 @@ func (g *Git) IsAncestor(ancestor, descendant string) (bool, error) {
 	return true, nil
 }
 
+// BranchContains reports whether commit is reachable from branch, which may
+// be a local branch or a remote-tracking branch such as origin/main. An
+// unknown commit is an error.
+func (g *Git) BranchContains(branch, commit string) (bool, error) {
+	out, err := g.run("branch", "--all", "--contains", commit, "--format=%(refname:short)")
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == branch {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // WorktreeAdd creates a new worktree at the given path with a new branch.
 // The new branch is created from the current HEAD.
 func (g *Git) WorktreeAdd(path, branch string) error {
@@ -842,9 +1045,11 @@ func (g *Git) WorktreePrune() error {
 
 // Worktree represents a git worktree.
 type Worktree struct {
-	Path   string
-	Branch string
-	Commit string
+	Path     string
+	Branch   string
+	Commit   string
+	Detached bool // HEAD is not on a branch
+	Prunable bool // git reports the worktree's directory as gone
 }
 
 // WorktreeList returns all worktrees for this repository.
@@ -873,6 +1078,10 @@ func (g *Git) WorktreeList() ([]Worktree, error) {
 			current.Commit = strings.TrimPrefix(line, "HEAD ")
 		case strings.HasPrefix(line, "branch "):
 			current.Branch = strings.TrimPrefix(line, "branch refs/heads/")
+		case line == "detached":
+			current.Detached = true
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
 		}
 	}
 
@@ -939,6 +1148,132 @@ func (g *Git) CommitsAhead(base, branch string) (int, error) {
 	return count, nil
 }
 
+// FirstParentCommits returns the SHAs on branch's first-parent history that
+// are not reachable from base, oldest first. For a branch that MRs are
+// merged into, these are the merge (or squash) commits in landing order.
+func (g *Git) FirstParentCommits(base, branch string) ([]string, error) {
+	out, err := g.run("rev-list", "--first-parent", "--reverse", base+".."+branch)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// FileChange is one file's line counts from git diff --numstat.
+type FileChange struct {
+	Path    string `json:"path"`
+	OldPath string `json:"old_path,omitempty"` // Set for renames
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+	Binary  bool   `json:"binary,omitempty"` // Line counts are 0 for binary files
+}
+
+// DiffStat returns the files changed between fromRef and toRef, with
+// renames detected.
+func (g *Git) DiffStat(fromRef, toRef string) ([]FileChange, error) {
+	out, err := g.run("diff", "--numstat", "-z", "-M", fromRef, toRef)
+	if err != nil {
+		return nil, err
+	}
+	return parseNumstat(out)
+}
+
+// parseNumstat parses git diff --numstat -z output. Each record is
+// "added\tdeleted\tpath\0", or "added\tdeleted\t\0old\0new\0" for a
+// rename. Binary files report "-" for both counts.
+func parseNumstat(out string) ([]FileChange, error) {
+	changes := []FileChange{}
+	fields := strings.Split(out, "\x00")
+	for i := 0; i < len(fields); i++ {
+		record := fields[i]
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, "\t", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("parsing numstat record %q", record)
+		}
+		var c FileChange
+		if parts[0] == "-" && parts[1] == "-" {
+			c.Binary = true
+		} else {
+			added, err := strconv.Atoi(parts[0])
+			if err != nil {
+				return nil, fmt.Errorf("parsing numstat added count %q: %w", parts[0], err)
+			}
+			deleted, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return nil, fmt.Errorf("parsing numstat deleted count %q: %w", parts[1], err)
+			}
+			c.Added, c.Deleted = added, deleted
+		}
+		if parts[2] != "" {
+			c.Path = parts[2]
+		} else {
+			// Rename: old and new paths follow as separate fields
+			if i+2 >= len(fields) {
+				return nil, fmt.Errorf("parsing numstat rename record %q: missing paths", record)
+			}
+			c.OldPath, c.Path = fields[i+1], fields[i+2]
+			i += 2
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// Commit is one entry of git log.
+type Commit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"` // Author date
+	Subject string    `json:"subject"`
+}
+
+// logFormat separates fields with US (0x1f) and records with RS (0x1e),
+// neither of which appears in names or subjects.
+const logFormat = "--format=%H%x1f%an%x1f%aI%x1f%s%x1e"
+
+// Log returns up to n commits reachable from ref, newest first.
+// n <= 0 returns the full history.
+func (g *Git) Log(ref string, n int) ([]Commit, error) {
+	args := []string{"log", logFormat}
+	if n > 0 {
+		args = append(args, fmt.Sprintf("-n%d", n))
+	}
+	out, err := g.run(append(args, ref, "--")...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out)
+}
+
+// parseLog parses git log output produced with logFormat.
+func parseLog(out string) ([]Commit, error) {
+	var commits []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, "\x1f")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("parsing git log record %q: expected 4 fields, got %d", record, len(fields))
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("parsing commit date %q: %w", fields[2], err)
+		}
+		commits = append(commits, Commit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+	return commits, nil
+}
+
 // CountCommitsBehind returns the number of commits that HEAD is behind the given ref.
 // For example, CountCommitsBehind("origin/main") returns how many commits
 // are on origin/main that are not on the current HEAD.
@@ -979,6 +1314,31 @@ func (g *Git) StashCount() (int, error) {
 	return count, nil
 }
 
+// StashPush stashes uncommitted changes, including untracked files, under
+// message. Returns false if the working tree was clean and nothing was
+// stashed, so callers know whether a matching StashPop is needed.
+func (g *Git) StashPush(message string) (bool, error) {
+	before, err := g.StashCount()
+	if err != nil {
+		return false, err
+	}
+	if _, err := g.run("stash", "push", "--include-untracked", "-m", message); err != nil {
+		return false, err
+	}
+	after, err := g.StashCount()
+	if err != nil {
+		return false, err
+	}
+	return after > before, nil
+}
+
+// StashPop restores the most recent stash and drops it. On conflict the
+// stash is kept and an error is returned.
+func (g *Git) StashPop() error {
+	_, err := g.run("stash", "pop")
+	return err
+}
+
 // UnpushedCommits returns the number of commits that are not pushed to the remote.
 // It checks if the current branch has an upstream and counts commits ahead.
 // Returns 0 if there is no upstream configured.
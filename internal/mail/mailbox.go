@@ -522,6 +522,81 @@ func (m *Mailbox) deleteLegacy(id string) error {
 	return m.rewriteLegacy(filtered)
 }
 
+// PruneCandidates returns the messages Prune would remove: those sent
+// before the cutoff, and if readOnly is set, only those already read.
+func (m *Mailbox) PruneCandidates(before time.Time, readOnly bool) ([]*Message, error) {
+	messages, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	return selectPrunable(messages, before, readOnly), nil
+}
+
+// selectPrunable filters messages to those sent before the cutoff, and
+// with readOnly, only the read ones.
+func selectPrunable(messages []*Message, before time.Time, readOnly bool) []*Message {
+	var prunable []*Message
+	for _, msg := range messages {
+		if msg.Timestamp.Before(before) && (msg.Read || !readOnly) {
+			prunable = append(prunable, msg)
+		}
+	}
+	return prunable
+}
+
+// Prune removes messages sent before the cutoff, or with readOnly only
+// the read ones among them, and returns how many it removed. Legacy
+// mailboxes are rewritten without them; in beads mode they are closed,
+// as Delete does.
+func (m *Mailbox) Prune(before time.Time, readOnly bool) (int, error) {
+	if m.legacy {
+		return m.pruneLegacy(before, readOnly)
+	}
+	candidates, err := m.PruneCandidates(before, readOnly)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, msg := range candidates {
+		if err := m.Delete(msg.ID); err != nil {
+			return removed, fmt.Errorf("pruning %s: %w", msg.ID, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (m *Mailbox) pruneLegacy(before time.Time, readOnly bool) (int, error) {
+	fl, err := m.lockLegacy()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	messages, err := m.List()
+	if err != nil {
+		return 0, err
+	}
+	prune := make(map[string]bool)
+	for _, msg := range selectPrunable(messages, before, readOnly) {
+		prune[msg.ID] = true
+	}
+	if len(prune) == 0 {
+		return 0, nil
+	}
+
+	var keep []*Message
+	for _, msg := range messages {
+		if !prune[msg.ID] {
+			keep = append(keep, msg)
+		}
+	}
+	if err := m.rewriteLegacy(keep); err != nil {
+		return 0, err
+	}
+	return len(prune), nil
+}
+
 // Archive moves a message to the archive file and removes it from inbox.
 func (m *Mailbox) Archive(id string) error {
 	if m.legacy {
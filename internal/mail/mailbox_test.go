@@ -704,3 +704,73 @@ func TestMailboxLegacyAtomicArchive(t *testing.T) {
 	}
 }
 
+
+func TestMailboxLegacyPrune(t *testing.T) {
+	now := time.Now()
+	fixture := []*Message{
+		{ID: "old-read", Subject: "old, read", Timestamp: now.Add(-10 * 24 * time.Hour), Read: true},
+		{ID: "old-unread", Subject: "old, unread", Timestamp: now.Add(-9 * 24 * time.Hour)},
+		{ID: "recent-read", Subject: "recent, read", Timestamp: now.Add(-2 * time.Hour), Read: true},
+		{ID: "recent-unread", Subject: "recent, unread", Timestamp: now.Add(-time.Hour)},
+	}
+	cutoff := now.Add(-7 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		readOnly bool
+		want     []string // IDs left in the mailbox, newest first
+	}{
+		{"all old messages", false, []string{"recent-unread", "recent-read"}},
+		{"only read old messages", true, []string{"recent-unread", "recent-read", "old-unread"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMailbox(t.TempDir())
+			for _, msg := range fixture {
+				copied := *msg
+				if err := m.Append(&copied); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			candidates, err := m.PruneCandidates(cutoff, tt.readOnly)
+			if err != nil {
+				t.Fatalf("PruneCandidates error: %v", err)
+			}
+			removed, err := m.Prune(cutoff, tt.readOnly)
+			if err != nil {
+				t.Fatalf("Prune error: %v", err)
+			}
+			wantRemoved := len(fixture) - len(tt.want)
+			if removed != wantRemoved || len(candidates) != wantRemoved {
+				t.Errorf("removed %d (candidates %d), want %d", removed, len(candidates), wantRemoved)
+			}
+
+			remaining, err := m.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, msg := range remaining {
+				ids = append(ids, msg.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("remaining = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestMailboxLegacyPruneNothingOld(t *testing.T) {
+	m := NewMailbox(t.TempDir())
+	if err := m.Append(&Message{ID: "new", Timestamp: time.Now(), Read: true}); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := m.Prune(time.Now().Add(-time.Hour), false)
+	if err != nil || removed != 0 {
+		t.Fatalf("Prune = %d, %v; want 0, nil", removed, err)
+	}
+	if total, _, _ := m.Count(); total != 1 {
+		t.Errorf("Count = %d after pruning nothing, want 1", total)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -710,6 +711,81 @@ func (r *Router) sendToGroup(msg *Message) error {
 	return nil
 }
 
+// Broadcast sends a copy of a message from `from` to every known agent
+// (town-level and all rigs), excluding the sender.
+// Delivery continues past individual failures (e.g., a missing mailbox):
+// delivered counts the copies that were sent, and err lists any failures.
+func (r *Router) Broadcast(from, subject, body string) (delivered int, err error) {
+	return r.BroadcastToRole(from, subject, body, "")
+}
+
+// BroadcastToRole is like Broadcast but only targets agents whose role_type
+// matches role (e.g., "witness", "polecat"). An empty role targets all agents.
+func (r *Router) BroadcastToRole(from, subject, body, role string) (delivered int, err error) {
+	recipients := broadcastRecipients(r.queryAgents(""), role, from)
+	if len(recipients) == 0 {
+		if role != "" {
+			return 0, fmt.Errorf("no %s agents found for broadcast", role)
+		}
+		return 0, errors.New("no agents found for broadcast")
+	}
+
+	return deliverBroadcast(recipients, func(to string) error {
+		return r.sendToSingle(NewMessage(from, to, subject, body))
+	})
+}
+
+// broadcastRecipients returns the sorted, de-duplicated addresses of agents
+// with the given role_type (all agents if role is empty), excluding the sender.
+func broadcastRecipients(agents []*agentBead, role, from string) []string {
+	sender := AddressToIdentity(from)
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, agent := range agents {
+		if role != "" && agentRoleType(agent) != role {
+			continue
+		}
+		addr := agentBeadToAddress(agent)
+		if addr == "" || seen[addr] || AddressToIdentity(addr) == sender {
+			continue
+		}
+		seen[addr] = true
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// agentRoleType returns the role_type recorded in an agent bead's description.
+func agentRoleType(bead *agentBead) string {
+	for _, line := range strings.Split(bead.Description, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "role_type:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "role_type:"))
+		}
+	}
+	return ""
+}
+
+// deliverBroadcast calls send for each recipient, collecting failures
+// instead of stopping at the first one.
+func deliverBroadcast(recipients []string, send func(to string) error) (int, error) {
+	delivered := 0
+	var errs []string
+	for _, to := range recipients {
+		if err := send(to); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", to, err))
+			continue
+		}
+		delivered++
+	}
+	if len(errs) > 0 {
+		return delivered, fmt.Errorf("broadcast failed for %d of %d recipient(s): %s",
+			len(errs), len(recipients), strings.Join(errs, "; "))
+	}
+	return delivered, nil
+}
+
 // validateRecipient checks that the recipient identity corresponds to an existing agent.
 // Returns an error if the recipient is invalid or doesn't exist.
 // Queries agents from town-level beads AND all rig-level beads via routes.jsonl.
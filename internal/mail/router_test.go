@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -1048,3 +1049,89 @@ func TestAddressToAgentBeadID(t *testing.T) {
 		})
 	}
 }
+
+func TestBroadcastRecipients(t *testing.T) {
+	agents := []*agentBead{
+		{ID: "hq-mayor", Description: "role_type: mayor\nrig: null"},
+		{ID: "hq-deacon", Description: "role_type: deacon\nrig: null"},
+		{ID: "gt-gastown-witness", Description: "role_type: witness\nrig: gastown"},
+		{ID: "gt-beads-witness", Description: "role_type: witness\nrig: beads"},
+		{ID: "gt-gastown-polecat-Toast", Description: "role_type: polecat\nrig: gastown"},
+		{ID: "gt-gastown-crew-max", Description: "role_type: crew\nrig: gastown"},
+		{ID: "gt-gastown-crew-max", Description: "role_type: crew\nrig: gastown"}, // duplicate
+		{ID: "bd-unparseable", Description: ""},
+	}
+
+	tests := []struct {
+		name string
+		role string
+		from string
+		want []string
+	}{
+		{
+			name: "all agents except sender",
+			role: "",
+			from: "mayor/",
+			want: []string{"beads/witness", "deacon/", "gastown/Toast", "gastown/max", "gastown/witness"},
+		},
+		{
+			name: "role scoped",
+			role: "witness",
+			from: "mayor/",
+			want: []string{"beads/witness", "gastown/witness"},
+		},
+		{
+			name: "role with no agents",
+			role: "dog",
+			from: "mayor/",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := broadcastRecipients(agents, tt.role, tt.from)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("broadcastRecipients(%q) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliverBroadcast(t *testing.T) {
+	recipients := []string{"gastown/witness", "gastown/Toast", "beads/witness"}
+
+	t.Run("full delivery", func(t *testing.T) {
+		var sent []string
+		delivered, err := deliverBroadcast(recipients, func(to string) error {
+			sent = append(sent, to)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("deliverBroadcast() error = %v", err)
+		}
+		if delivered != 3 || len(sent) != 3 {
+			t.Errorf("delivered = %d (sent %v), want 3", delivered, sent)
+		}
+	})
+
+	t.Run("partial failure continues", func(t *testing.T) {
+		var sent []string
+		delivered, err := deliverBroadcast(recipients, func(to string) error {
+			if to == "gastown/Toast" {
+				return errors.New("mailbox not found")
+			}
+			sent = append(sent, to)
+			return nil
+		})
+		if err == nil {
+			t.Fatal("expected error reporting the failed recipient")
+		}
+		if !strings.Contains(err.Error(), "gastown/Toast") {
+			t.Errorf("error should name the failed recipient, got %v", err)
+		}
+		if delivered != 2 || strings.Join(sent, ",") != "gastown/witness,beads/witness" {
+			t.Errorf("delivered = %d (sent %v), want 2 deliveries after the failure", delivered, sent)
+		}
+	})
+}
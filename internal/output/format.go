@@ -0,0 +1,127 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// FormatText selects a command's human-readable output. It is not a
+// structured encoding, so FprintFormatted rejects it; callers branch on it.
+const FormatText Format = "text"
+
+// FormatEnv names the environment variable consulted when no --format is given.
+const FormatEnv = "GT_OUTPUT_FORMAT"
+
+// formatNames maps the names accepted by --format and GT_OUTPUT_FORMAT to
+// formats, in the order they are listed in error messages.
+var formatNames = []struct {
+	name   string
+	format Format
+}{
+	{"text", FormatText},
+	{"json", FormatJSON},
+	{"toon", FormatTOON},
+}
+
+// SupportedFormats returns the accepted format names.
+func SupportedFormats() []string {
+	names := make([]string, len(formatNames))
+	for i, f := range formatNames {
+		names[i] = f.name
+	}
+	return names
+}
+
+// ResolveFormatStrict returns the format called name. Names are
+// case-insensitive. Unknown and empty names are errors that list the
+// supported formats, so a typo on the command line is never ignored.
+func ResolveFormatStrict(name string) (Format, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, f := range formatNames {
+		if f.name == key {
+			return f.format, nil
+		}
+	}
+	supported := strings.Join(SupportedFormats(), ", ")
+	if key == "" {
+		return "", fmt.Errorf("no output format given (supported: %s)", supported)
+	}
+	return "", fmt.Errorf("unsupported output format %q (supported: %s)", name, supported)
+}
+
+// ResolveFormat returns the format for a --format flag value. An explicit
+// flag is resolved strictly. Without one, GT_OUTPUT_FORMAT is consulted
+// permissively: an unknown value there falls back to FormatText rather
+// than failing every command run in that environment.
+func ResolveFormat(flag string) (Format, error) {
+	if flag != "" {
+		format, err := ResolveFormatStrict(flag)
+		if err != nil {
+			return "", fmt.Errorf("--format: %w", err)
+		}
+		return format, nil
+	}
+	if format, err := ResolveFormatStrict(os.Getenv(FormatEnv)); err == nil {
+		return format, nil
+	}
+	return FormatText, nil
+}
+
+// FormatFlag is a --format flag registered on a command. Use it instead of
+// per-command --json booleans so every command resolves formats the same way.
+type FormatFlag struct {
+	value     string
+	jsonAlias bool
+	toonAlias bool
+}
+
+// NewFormatFlag registers --format on cmd.
+func NewFormatFlag(cmd *cobra.Command) *FormatFlag {
+	f := &FormatFlag{}
+	cmd.Flags().StringVar(&f.value, "format", "", "Output format: "+strings.Join(SupportedFormats(), ", ")+" (default from "+FormatEnv+", else text)")
+	return f
+}
+
+// WithJSONAlias registers --json as a deprecated alias for --format json,
+// for commands that had a --json flag before --format.
+func (f *FormatFlag) WithJSONAlias(cmd *cobra.Command) *FormatFlag {
+	cmd.Flags().BoolVar(&f.jsonAlias, "json", false, "Output as JSON (deprecated: use --format json)")
+	_ = cmd.Flags().MarkDeprecated("json", "use --format json")
+	return f
+}
+
+// WithTOONAlias registers --toon as shorthand for --format toon.
+func (f *FormatFlag) WithTOONAlias(cmd *cobra.Command) *FormatFlag {
+	cmd.Flags().BoolVar(&f.toonAlias, "toon", false, "Output as TOON (same as --format toon)")
+	return f
+}
+
+// Resolve returns the selected format. --json maps to json and --toon to
+// toon; giving one together with a different --format, or both, is an error.
+func (f *FormatFlag) Resolve() (Format, error) {
+	var alias string
+	var aliasFormat Format
+	switch {
+	case f.jsonAlias && f.toonAlias:
+		return "", fmt.Errorf("--json and --toon can't be used together")
+	case f.jsonAlias:
+		alias, aliasFormat = "--json", FormatJSON
+	case f.toonAlias:
+		alias, aliasFormat = "--toon", FormatTOON
+	default:
+		return ResolveFormat(f.value)
+	}
+	if f.value != "" {
+		format, err := ResolveFormat(f.value)
+		if err != nil {
+			return "", err
+		}
+		if format != aliasFormat {
+			return "", fmt.Errorf("%s conflicts with --format %s", alias, f.value)
+		}
+	}
+	return aliasFormat, nil
+}
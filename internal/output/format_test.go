@@ -0,0 +1,156 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolveFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		want    Format
+		wantErr bool
+	}{
+		{name: "default is text", want: FormatText},
+		{name: "flag", flag: "json", want: FormatJSON},
+		{name: "flag is case-insensitive", flag: "JSON", want: FormatJSON},
+		{name: "env fallback", env: "json", want: FormatJSON},
+		{name: "flag overrides env", flag: "text", env: "json", want: FormatText},
+		{name: "unsupported flag", flag: "xml", wantErr: true},
+		{name: "unsupported env falls back to text", env: "yaml", want: FormatText},
+		{name: "flag still strict with bad env", flag: "tooon", env: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(FormatEnv, tt.env)
+			got, err := ResolveFormat(tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveFormat(%q) error = %v, wantErr %v", tt.flag, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFormat(%q) = %q, want %q", tt.flag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFormatStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Format
+		wantErr string
+	}{
+		{name: "text", input: "text", want: FormatText},
+		{name: "json", input: "json", want: FormatJSON},
+		{name: "toon", input: "toon", want: FormatTOON},
+		{name: "case and space", input: " Json ", want: FormatJSON},
+		{name: "typo", input: "tooon", wantErr: `unsupported output format "tooon" (supported: text, json, toon)`},
+		{name: "empty", input: "", wantErr: "no output format given (supported: text, json, toon)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The env never affects strict resolution
+			t.Setenv(FormatEnv, "json")
+			got, err := ResolveFormatStrict(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ResolveFormatStrict(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveFormatStrict(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFormat_UnknownFlagListsFormats(t *testing.T) {
+	t.Setenv(FormatEnv, "")
+	_, err := ResolveFormat("tooon")
+	if err == nil || !strings.Contains(err.Error(), "--format") || !strings.Contains(err.Error(), "supported: text, json") {
+		t.Errorf("ResolveFormat(tooon) error = %v", err)
+	}
+}
+
+func TestFormatFlag_JSONAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Format
+		wantErr bool
+	}{
+		{name: "no flags", args: nil, want: FormatText},
+		{name: "json alias maps to format json", args: []string{"--json"}, want: FormatJSON},
+		{name: "format json", args: []string{"--format", "json"}, want: FormatJSON},
+		{name: "alias agrees with format", args: []string{"--json", "--format=json"}, want: FormatJSON},
+		{name: "alias conflicts with format", args: []string{"--json", "--format=text"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(FormatEnv, "")
+			cmd := &cobra.Command{Use: "test"}
+			f := NewFormatFlag(cmd).WithJSONAlias(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+			}
+
+			got, err := f.Resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFlag_TOONAlias(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    Format
+		wantErr bool
+	}{
+		{name: "toon alias maps to format toon", args: []string{"--toon"}, want: FormatTOON},
+		{name: "format toon", args: []string{"--format", "toon"}, want: FormatTOON},
+		{name: "alias agrees with format", args: []string{"--toon", "--format=toon"}, want: FormatTOON},
+		{name: "alias conflicts with format", args: []string{"--toon", "--format=json"}, wantErr: true},
+		{name: "both aliases", args: []string{"--toon", "--json"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(FormatEnv, "")
+			cmd := &cobra.Command{Use: "test"}
+			f := NewFormatFlag(cmd).WithJSONAlias(cmd).WithTOONAlias(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+			}
+
+			got, err := f.Resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFlag_JSONAliasDeprecated(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	NewFormatFlag(cmd).WithJSONAlias(cmd)
+	if flag := cmd.Flags().Lookup("json"); flag == nil || flag.Deprecated == "" {
+		t.Error("--json should be registered as a deprecated alias")
+	}
+}
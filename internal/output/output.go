@@ -0,0 +1,113 @@
+// Package output renders structured command results for machine consumption.
+//
+// Commands build a result value (e.g., TownStatus) and hand it to
+// PrintFormatted instead of encoding it themselves, so every command gets
+// the same encoding and the same redirection options.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Format identifies a structured output encoding.
+type Format string
+
+const (
+	// FormatJSON renders indented JSON.
+	FormatJSON Format = "json"
+
+	// FormatTOON renders TOON, a compact tabular encoding of the same
+	// data (see toon.go).
+	FormatTOON Format = "toon"
+)
+
+// PrintFormatted writes v to stdout in the given format.
+func PrintFormatted(v any, format Format) error {
+	return FprintFormatted(os.Stdout, v, format)
+}
+
+// FprintFormatted writes v to w in the given format.
+func FprintFormatted(w io.Writer, v any, format Format) error {
+	data, err := Marshal(v, format)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Marshal returns v encoded in the given format, exactly as FprintFormatted
+// would write it, for embedding in a larger document such as a mail body.
+func Marshal(v any, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatTOON:
+		return marshalTOON(v)
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+// ErrorPayload is the shape of an error reported in a structured format.
+type ErrorPayload struct {
+	Error string `json:"error"`
+}
+
+// PrintError reports err on stderr in the given format. See FprintError.
+func PrintError(err error, format Format) {
+	FprintError(os.Stderr, err, format)
+}
+
+// FprintError writes err to w: as a single-line {"error": "..."} object for
+// structured formats (error: "..." in TOON), so consumers of --format json
+// or toon can parse failures as well as results, or as a styled "Error:"
+// line for text.
+func FprintError(w io.Writer, err error, format Format) {
+	switch format {
+	case FormatJSON:
+		_ = json.NewEncoder(w).Encode(ErrorPayload{Error: err.Error()})
+		return
+	case FormatTOON:
+		if data, mErr := marshalTOON(ErrorPayload{Error: err.Error()}); mErr == nil {
+			_, _ = w.Write(data)
+			return
+		}
+	}
+	fmt.Fprintf(w, "%s %s\n", style.Error.Render("Error:"), err)
+}
+
+// AddFileFlag registers the shared --output-file flag on cmd.
+func AddFileFlag(cmd *cobra.Command, path *string) {
+	cmd.Flags().StringVar(path, "output-file", "", "Write the formatted payload to this file instead of stdout")
+}
+
+// WriteFile writes v to path in the given format. An existing file is
+// truncated. Only the payload is written; progress output is unaffected.
+func WriteFile(path string, v any, format Format) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	if err := FprintFormatted(f, v, format); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing output file %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing output file %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+type testPayload struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestFprintFormatted_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FprintFormatted(&buf, testPayload{Name: "gt", Count: 2}, FormatJSON); err != nil {
+		t.Fatalf("FprintFormatted() error = %v", err)
+	}
+	want := "{\n  \"name\": \"gt\",\n  \"count\": 2\n}\n"
+	if buf.String() != want {
+		t.Errorf("FprintFormatted() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFprintFormatted_UnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := FprintFormatted(&buf, testPayload{}, Format("xml")); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestMarshal_MatchesFprint(t *testing.T) {
+	v := []testPayload{{Name: "<gt & co>", Count: 2}, {Name: "x, y", Count: 3}}
+	for _, format := range []Format{FormatJSON, FormatTOON} {
+		data, err := Marshal(v, format)
+		if err != nil {
+			t.Fatalf("Marshal(%s) error = %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := FprintFormatted(&buf, v, format); err != nil {
+			t.Fatalf("FprintFormatted(%s) error = %v", format, err)
+		}
+		if string(data) != buf.String() {
+			t.Errorf("Marshal(%s) = %q, FprintFormatted wrote %q", format, data, buf.String())
+		}
+	}
+
+	if _, err := Marshal(v, Format("xml")); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status.json")
+
+	// Pre-populate with longer content to verify truncation
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", 512)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(path, testPayload{Name: "gastown", Count: 3}, FormatJSON); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got testPayload
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("output file is not valid JSON: %v\n%s", err, data)
+	}
+	if got.Name != "gastown" || got.Count != 3 {
+		t.Errorf("WriteFile() wrote %+v", got)
+	}
+}
+
+func TestWriteFile_CreateError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "status.json")
+
+	err := WriteFile(path, testPayload{}, FormatJSON)
+	if err == nil {
+		t.Fatal("expected error when parent directory does not exist")
+	}
+	if !strings.Contains(err.Error(), "creating output file") {
+		t.Errorf("error should explain the file could not be created, got %v", err)
+	}
+}
+
+func TestFprintError_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	FprintError(&buf, errors.New(`epic "gt-1" not found`), FormatJSON)
+
+	if !strings.HasSuffix(buf.String(), "\n") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("JSON error should be one line, got %q", buf.String())
+	}
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("error output is not JSON: %v (%q)", err, buf.String())
+	}
+	if len(got) != 1 || got["error"] != `epic "gt-1" not found` {
+		t.Errorf("error payload = %v, want only {\"error\": ...}", got)
+	}
+}
+
+func TestFprintError_TOON(t *testing.T) {
+	var buf bytes.Buffer
+	FprintError(&buf, errors.New("epic \"gt-1\" not found\nrun gt mq list"), FormatTOON)
+
+	want := `error: "epic \"gt-1\" not found\nrun gt mq list"` + "\n"
+	if buf.String() != want {
+		t.Errorf("TOON error = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	FprintError(&buf, errors.New("not in a Gas Town workspace"), FormatTOON)
+	if buf.String() != "error: not in a Gas Town workspace\n" {
+		t.Errorf("TOON error = %q", buf.String())
+	}
+}
+
+func TestFprintError_Text(t *testing.T) {
+	var buf bytes.Buffer
+	FprintError(&buf, errors.New("not in a Gas Town workspace"), FormatText)
+
+	out := buf.String()
+	if want := style.Error.Render("Error:") + " not in a Gas Town workspace\n"; out != want {
+		t.Errorf("text error = %q", out)
+	}
+	if strings.HasPrefix(strings.TrimSpace(out), "{") {
+		t.Errorf("text error should not be JSON: %q", out)
+	}
+}
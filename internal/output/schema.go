@@ -0,0 +1,149 @@
+package output
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaDialect is the JSON Schema version Schema emits.
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Schema returns a JSON Schema describing how v encodes with encoding/json,
+// derived by reflection over its fields and json tags. Fields without
+// omitempty are required; slices, maps and pointers that may encode as null
+// allow null. Types with custom JSON encoding (other than time.Time) are
+// described as {} since their shape can't be inferred.
+func Schema(v any) map[string]any {
+	t := reflect.TypeOf(v)
+	s := typeSchema(t, map[reflect.Type]bool{})
+	s["$schema"] = SchemaDialect
+	if t != nil {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Name() != "" {
+			s["title"] = t.Name()
+		}
+	}
+	return s
+}
+
+// typeSchema returns the schema for t. visiting holds the struct types
+// currently being expanded, so recursive types terminate.
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return map[string]any{"type": "integer", "description": "nanoseconds"}
+	case t.Implements(jsonMarshalerType), reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{}
+	case t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), visiting)
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]any{} // Recursive reference
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		props := map[string]any{}
+		var required []string
+		addStructFields(t, visiting, props, &required)
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+	default:
+		// interface{} and anything else: any JSON value
+		return map[string]any{}
+	}
+}
+
+// addStructFields adds t's encoded fields to props, flattening embedded
+// structs the way encoding/json does.
+func addStructFields(t reflect.Type, visiting map[reflect.Type]bool, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructFields(ft, visiting, props, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		omitEmpty := strings.Contains(","+opts+",", ",omitempty,")
+		var fs map[string]any
+		if strings.Contains(","+opts+",", ",string,") {
+			fs = map[string]any{"type": "string"}
+		} else {
+			fs = typeSchema(f.Type, visiting)
+		}
+		if !omitEmpty {
+			switch f.Type.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+				allowNull(fs)
+			}
+			*required = append(*required, name)
+		}
+		props[name] = fs
+	}
+}
+
+// allowNull widens a schema's type to also accept null.
+func allowNull(s map[string]any) {
+	if typ, ok := s["type"].(string); ok {
+		s["type"] = []string{typ, "null"}
+	}
+}
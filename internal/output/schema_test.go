@@ -0,0 +1,102 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaInner struct {
+	ID string `json:"id"`
+}
+
+type schemaEmbedded struct {
+	Source string `json:"source"`
+}
+
+type schemaPayload struct {
+	schemaEmbedded
+	Name     string         `json:"name"`
+	Count    int            `json:"count"`
+	Ratio    float64        `json:"ratio,omitempty"`
+	Ready    bool           `json:"ready"`
+	Tags     []string       `json:"tags"`
+	Inner    *schemaInner   `json:"inner,omitempty"`
+	Items    []schemaInner  `json:"items"`
+	Extra    map[string]int `json:"extra,omitempty"`
+	At       time.Time      `json:"at"`
+	Skipped  string         `json:"-"`
+	Untagged string
+	hidden   string
+	Children []*schemaPayload  `json:"children,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty"`
+	Labels   map[string]string `json:"labels"`
+}
+
+func TestSchema(t *testing.T) {
+	s := Schema(schemaPayload{})
+
+	if s["$schema"] != SchemaDialect || s["title"] != "schemaPayload" || s["type"] != "object" {
+		t.Errorf("header = %v/%v/%v", s["$schema"], s["title"], s["type"])
+	}
+	props := s["properties"].(map[string]any)
+
+	typeOf := func(name string) any {
+		p, ok := props[name].(map[string]any)
+		if !ok {
+			t.Fatalf("property %q missing", name)
+		}
+		return p["type"]
+	}
+	if typeOf("name") != "string" || typeOf("count") != "integer" || typeOf("ratio") != "number" || typeOf("ready") != "boolean" {
+		t.Errorf("scalar types wrong: %v", props)
+	}
+	if got := typeOf("tags"); !reflect.DeepEqual(got, []string{"array", "null"}) {
+		t.Errorf("tags type = %v, want nullable array", got)
+	}
+	if typeOf("inner") != "object" || typeOf("extra") != "object" {
+		t.Errorf("omitempty pointer/map should not be nullable: inner=%v extra=%v", typeOf("inner"), typeOf("extra"))
+	}
+	if at := props["at"].(map[string]any); at["type"] != "string" || at["format"] != "date-time" {
+		t.Errorf("time.Time schema = %v", at)
+	}
+	if typeOf("source") != "string" {
+		t.Error("embedded struct fields should be flattened")
+	}
+	if typeOf("Untagged") != "string" {
+		t.Error("untagged exported field should use the Go name")
+	}
+	for _, name := range []string{"Skipped", "-", "hidden"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("property %q should be omitted", name)
+		}
+	}
+	items := props["items"].(map[string]any)["items"].(map[string]any)
+	if items["properties"].(map[string]any)["id"].(map[string]any)["type"] != "string" {
+		t.Errorf("array items schema = %v", items)
+	}
+	if children := props["children"].(map[string]any)["items"].(map[string]any); len(children) != 0 {
+		t.Errorf("recursive reference should be {}, got %v", children)
+	}
+
+	required := map[string]bool{}
+	for _, name := range s["required"].([]string) {
+		required[name] = true
+	}
+	for _, name := range []string{"name", "count", "ready", "tags", "at", "source"} {
+		if !required[name] {
+			t.Errorf("%q should be required", name)
+		}
+	}
+	for _, name := range []string{"ratio", "inner", "extra", "children"} {
+		if required[name] {
+			t.Errorf("omitempty field %q should not be required", name)
+		}
+	}
+
+	// The schema itself must be valid JSON
+	if _, err := json.Marshal(s); err != nil {
+		t.Errorf("marshal schema: %v", err)
+	}
+}
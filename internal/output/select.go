@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AddSelectFlag registers the shared --select flag on cmd.
+func AddSelectFlag(cmd *cobra.Command, path *string) {
+	cmd.Flags().StringVar(path, "select", "", "Print only the value at this dotted path (e.g., ready_to_land, merged_mrs.0.id)")
+}
+
+// Select returns the value at a dotted path into v's JSON form. Path
+// segments are object keys (as named in the JSON output) or array indexes.
+func Select(v any, path string) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // keep integers exact
+	var cur any
+	if err := dec.Decode(&cur); err != nil {
+		return nil, fmt.Errorf("decoding value: %w", err)
+	}
+
+	if path == "" {
+		return cur, nil
+	}
+	segments := strings.Split(path, ".")
+	for i, seg := range segments {
+		at := strings.Join(segments[:i+1], ".")
+		switch node := cur.(type) {
+		case map[string]any:
+			next, ok := node[seg]
+			if !ok {
+				return nil, fmt.Errorf("unknown path %q: no field %q", at, seg)
+			}
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil {
+				return nil, fmt.Errorf("unknown path %q: %q is not an array index", at, seg)
+			}
+			if idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("unknown path %q: index %d out of range (length %d)", at, idx, len(node))
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("unknown path %q: cannot index into a scalar", at)
+		}
+	}
+	return cur, nil
+}
+
+// PrintSelected writes the value at path in v to stdout in the given format.
+func PrintSelected(v any, path string, format Format) error {
+	return FprintSelected(os.Stdout, v, path, format)
+}
+
+// FprintSelected writes the value at path in v to w. With FormatText,
+// scalars are printed bare (true, 3, main) so they can be used directly in
+// scripts; objects and arrays fall back to JSON.
+func FprintSelected(w io.Writer, v any, path string, format Format) error {
+	selected, err := Select(v, path)
+	if err != nil {
+		return err
+	}
+	if format == FormatText {
+		switch s := selected.(type) {
+		case string, bool, json.Number:
+			_, err := fmt.Fprintln(w, s)
+			return err
+		case nil:
+			_, err := fmt.Fprintln(w, "null")
+			return err
+		}
+		format = FormatJSON
+	}
+	return FprintFormatted(w, selected, format)
+}
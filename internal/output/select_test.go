@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+type selectChild struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type selectPayload struct {
+	Ready  bool          `json:"ready_to_land"`
+	Count  int           `json:"count"`
+	Branch string        `json:"branch"`
+	Merged []selectChild `json:"merged_mrs"`
+	Meta   map[string]any
+}
+
+func testSelectPayload() selectPayload {
+	return selectPayload{
+		Ready:  true,
+		Count:  3,
+		Branch: "integration/gt-epic",
+		Merged: []selectChild{{ID: "mr-1", Title: "first"}, {ID: "mr-2", Title: "second"}},
+		Meta:   map[string]any{"owner": map[string]any{"name": "nux"}},
+	}
+}
+
+func TestFprintSelected(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		format Format
+		want   string
+	}{
+		{name: "scalar bool json", path: "ready_to_land", format: FormatJSON, want: "true\n"},
+		{name: "scalar bool text", path: "ready_to_land", format: FormatText, want: "true\n"},
+		{name: "number text", path: "count", format: FormatText, want: "3\n"},
+		{name: "string json is quoted", path: "branch", format: FormatJSON, want: "\"integration/gt-epic\"\n"},
+		{name: "string text is bare", path: "branch", format: FormatText, want: "integration/gt-epic\n"},
+		{name: "array index", path: "merged_mrs.1.id", format: FormatText, want: "mr-2\n"},
+		{name: "nested map", path: "Meta.owner.name", format: FormatText, want: "nux\n"},
+		{name: "object falls back to json in text", path: "merged_mrs.0", format: FormatText,
+			want: "{\n  \"id\": \"mr-1\",\n  \"title\": \"first\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := FprintSelected(&buf, testSelectPayload(), tt.path, tt.format); err != nil {
+				t.Fatalf("FprintSelected(%q) error = %v", tt.path, err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("FprintSelected(%q) = %q, want %q", tt.path, buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSelect_UnknownPath(t *testing.T) {
+	for _, path := range []string{
+		"no_such_field",
+		"merged_mrs.5.id",
+		"merged_mrs.first",
+		"ready_to_land.value",
+		"merged_mrs.0.missing",
+	} {
+		if _, err := Select(testSelectPayload(), path); err == nil {
+			t.Errorf("Select(%q) should fail", path)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+)
+
+// PrintJSONStream writes the values received from items to stdout as an
+// indented JSON array, one element at a time. See FprintJSONStream.
+func PrintJSONStream(items <-chan any) error {
+	return FprintJSONStream(os.Stdout, items)
+}
+
+// FprintJSONStream writes the values received from items to w as a JSON
+// array until items is closed. Each element is encoded and written as it
+// arrives, so the whole list is never held in memory. The output is
+// byte-for-byte what FprintFormatted produces for the equivalent slice,
+// except that an empty stream is written as [] rather than null.
+//
+// After an encoding or write error the channel is still drained, so a
+// sending goroutine never blocks; the first error is returned.
+//
+// Only JSON streams. Other formats go through FprintFormatted, which
+// buffers the full value.
+func FprintJSONStream(w io.Writer, items <-chan any) error {
+	bw := bufio.NewWriter(w)
+	var err error
+	n := 0
+	for item := range items {
+		if err != nil {
+			continue
+		}
+		var data []byte
+		data, err = json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			continue
+		}
+		sep := ",\n  "
+		if n == 0 {
+			sep = "[\n  "
+		}
+		if _, err = bw.WriteString(sep); err != nil {
+			continue
+		}
+		_, err = bw.Write(data)
+		n++
+	}
+	if err != nil {
+		return err
+	}
+
+	end := "\n]\n"
+	if n == 0 {
+		end = "[]\n"
+	}
+	if _, err := bw.WriteString(end); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// StreamJSON writes items to w as a JSON array without encoding the whole
+// slice at once, for lists large enough that a second full-size buffer
+// matters.
+func StreamJSON[T any](w io.Writer, items []T) error {
+	ch := make(chan any)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			ch <- item
+		}
+	}()
+	return FprintJSONStream(w, ch)
+}
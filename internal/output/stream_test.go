@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestFprintJSONStream_MatchesBatch(t *testing.T) {
+	items := []testPayload{{Name: "gt", Count: 1}, {Name: "bd", Count: 2}, {Name: "<hq>", Count: 3}}
+
+	ch := make(chan any)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			ch <- item
+		}
+	}()
+	var streamed bytes.Buffer
+	if err := FprintJSONStream(&streamed, ch); err != nil {
+		t.Fatalf("FprintJSONStream() error = %v", err)
+	}
+
+	var batch bytes.Buffer
+	if err := FprintFormatted(&batch, items, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if streamed.String() != batch.String() {
+		t.Errorf("streamed output differs from batch:\nstream: %q\nbatch:  %q", streamed.String(), batch.String())
+	}
+
+	var parsed []testPayload
+	if err := json.Unmarshal(streamed.Bytes(), &parsed); err != nil {
+		t.Fatalf("streamed output is not valid JSON: %v", err)
+	}
+	if len(parsed) != len(items) || parsed[2] != items[2] {
+		t.Errorf("parsed = %+v, want %+v", parsed, items)
+	}
+}
+
+func TestStreamJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := StreamJSON(&buf, []testPayload{{Name: "gt", Count: 1}}); err != nil {
+		t.Fatalf("StreamJSON() error = %v", err)
+	}
+	want := "[\n  {\n    \"name\": \"gt\",\n    \"count\": 1\n  }\n]\n"
+	if buf.String() != want {
+		t.Errorf("StreamJSON() = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := StreamJSON[testPayload](&buf, nil); err != nil {
+		t.Fatalf("StreamJSON(nil) error = %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("StreamJSON(nil) = %q, want %q", buf.String(), "[]\n")
+	}
+}
+
+func TestFprintJSONStream_DrainsAfterError(t *testing.T) {
+	ch := make(chan any)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(ch)
+		ch <- testPayload{Name: "ok"}
+		ch <- func() {} // not JSON-encodable
+		ch <- testPayload{Name: "after"}
+	}()
+
+	var buf bytes.Buffer
+	if err := FprintJSONStream(&buf, ch); err == nil {
+		t.Error("expected error for unencodable element")
+	}
+	<-done // sender must not be left blocked
+}
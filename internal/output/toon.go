@@ -0,0 +1,289 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// TOON (Token-Oriented Object Notation) is a compact, indentation-based
+// encoding of the JSON data model. Objects are "key: value" lines, arrays
+// declare their length as key[N], and arrays of flat objects sharing the
+// same keys become a table: a key[N]{field,...}: header followed by one
+// comma-separated row per element.
+//
+// Values are encoded from their JSON form, so json tags, omitempty and
+// MarshalJSON apply exactly as they do for FormatJSON, and struct field
+// order is preserved.
+
+// toonField is one key of an object, in encoding order.
+type toonField struct {
+	key string
+	val any
+}
+
+// toonObject is a JSON object with its key order preserved.
+type toonObject []toonField
+
+// marshalTOON encodes v as TOON, ending with a newline.
+func marshalTOON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	root, err := decodeTOONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &toonEncoder{}
+	switch root := root.(type) {
+	case toonObject:
+		for _, f := range root {
+			e.field(0, "", f.key, f.val, 1)
+		}
+	case []any:
+		e.array(0, "", root, 1)
+	default:
+		e.line(0, toonPrimitive(root))
+	}
+	return e.buf.Bytes(), nil
+}
+
+// decodeTOONValue reads the next JSON value from dec, keeping object key
+// order. Numbers stay json.Number so they render exactly as JSON has them.
+func decodeTOONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := toonObject{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return nil, fmt.Errorf("toon: unexpected object key %v", keyTok)
+			}
+			val, err := decodeTOONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, toonField{key: key, val: val})
+		}
+		if _, err := dec.Token(); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return obj, nil
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			val, err := decodeTOONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		if _, err := dec.Token(); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return arr, nil
+	default:
+		return tok, nil
+	}
+}
+
+type toonEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *toonEncoder) line(depth int, s string) {
+	e.buf.WriteString(strings.Repeat("  ", depth))
+	e.buf.WriteString(s)
+	e.buf.WriteByte('\n')
+}
+
+// field writes key: val at depth, after prefix ("- " for the first field
+// of a list item). Nested content goes at childDepth.
+func (e *toonEncoder) field(depth int, prefix, key string, val any, childDepth int) {
+	head := prefix + toonKey(key)
+	switch val := val.(type) {
+	case toonObject:
+		e.line(depth, head+":")
+		for _, f := range val {
+			e.field(childDepth, "", f.key, f.val, childDepth+1)
+		}
+	case []any:
+		e.array(depth, head, val, childDepth)
+	default:
+		e.line(depth, head+": "+toonPrimitive(val))
+	}
+}
+
+// array writes head[N] and the elements: inline for primitives, as a
+// table for uniform flat objects, else as a "- " list at childDepth.
+func (e *toonEncoder) array(depth int, head string, arr []any, childDepth int) {
+	head = fmt.Sprintf("%s[%d]", head, len(arr))
+	if len(arr) == 0 {
+		e.line(depth, head+":")
+		return
+	}
+	if toonAllPrimitive(arr) {
+		values := make([]string, len(arr))
+		for i, v := range arr {
+			values[i] = toonPrimitive(v)
+		}
+		e.line(depth, head+": "+strings.Join(values, ","))
+		return
+	}
+	if fields := toonTableFields(arr); fields != nil {
+		keys := make([]string, len(fields))
+		for i, k := range fields {
+			keys[i] = toonKey(k)
+		}
+		e.line(depth, head+"{"+strings.Join(keys, ",")+"}:")
+		for _, v := range arr {
+			obj := v.(toonObject)
+			row := make([]string, len(obj))
+			for i, f := range obj {
+				row[i] = toonPrimitive(f.val)
+			}
+			e.line(childDepth, strings.Join(row, ","))
+		}
+		return
+	}
+	e.line(depth, head+":")
+	for _, v := range arr {
+		e.item(childDepth, v)
+	}
+}
+
+// item writes one "- " list element at depth.
+func (e *toonEncoder) item(depth int, v any) {
+	switch v := v.(type) {
+	case toonObject:
+		if len(v) == 0 {
+			e.line(depth, "-")
+			return
+		}
+		e.field(depth, "- ", v[0].key, v[0].val, depth+2)
+		for _, f := range v[1:] {
+			e.field(depth+1, "", f.key, f.val, depth+2)
+		}
+	case []any:
+		e.array(depth, "- ", v, depth+1)
+	default:
+		e.line(depth, "- "+toonPrimitive(v))
+	}
+}
+
+func toonIsPrimitive(v any) bool {
+	switch v.(type) {
+	case toonObject, []any:
+		return false
+	}
+	return true
+}
+
+func toonAllPrimitive(arr []any) bool {
+	for _, v := range arr {
+		if !toonIsPrimitive(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// toonTableFields returns the shared keys when every element of arr is a
+// non-empty object with the same keys in the same order and only primitive
+// values, or nil when arr can't be a table.
+func toonTableFields(arr []any) []string {
+	var fields []string
+	for i, v := range arr {
+		obj, ok := v.(toonObject)
+		if !ok || len(obj) == 0 {
+			return nil
+		}
+		if i == 0 {
+			for _, f := range obj {
+				fields = append(fields, f.key)
+			}
+		} else if len(obj) != len(fields) {
+			return nil
+		}
+		for j, f := range obj {
+			if f.key != fields[j] || !toonIsPrimitive(f.val) {
+				return nil
+			}
+		}
+	}
+	return fields
+}
+
+var (
+	toonBareKey   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	toonNumberish = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?$|^0\d+$`)
+)
+
+func toonKey(key string) string {
+	if toonBareKey.MatchString(key) {
+		return key
+	}
+	return toonQuote(key)
+}
+
+func toonPrimitive(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if v {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return v.String()
+	case string:
+		if toonNeedsQuotes(v) {
+			return toonQuote(v)
+		}
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toonNeedsQuotes reports whether s would be misread unquoted: empty or
+// padded, a literal or number, or containing structural characters.
+func toonNeedsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch s {
+	case "true", "false", "null":
+		return true
+	}
+	if toonNumberish.MatchString(s) || strings.HasPrefix(s, "-") {
+		return true
+	}
+	return strings.ContainsAny(s, ",:\"\\[]{}\n\r\t")
+}
+
+func toonQuote(s string) string {
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(s) + `"`
+}
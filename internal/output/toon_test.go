@@ -0,0 +1,105 @@
+package output
+
+import (
+	"testing"
+)
+
+type toonCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Details []string `json:"details,omitempty"`
+}
+
+type toonReport struct {
+	Town   string      `json:"town"`
+	Ready  bool        `json:"ready"`
+	Count  int         `json:"count"`
+	Tags   []string    `json:"tags"`
+	Owner  *toonCheck  `json:"owner"`
+	Checks []toonCheck `json:"checks"`
+	Empty  []string    `json:"empty"`
+}
+
+func TestMarshalTOON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "object keeps field order",
+			v: toonReport{
+				Town:   "gastown",
+				Ready:  true,
+				Count:  2,
+				Tags:   []string{"a", "b c"},
+				Owner:  &toonCheck{Name: "mayor", Status: "ok"},
+				Checks: []toonCheck{{Name: "git", Status: "ok"}, {Name: "beads", Status: "warning"}},
+				Empty:  []string{},
+			},
+			want: `town: gastown
+ready: true
+count: 2
+tags[2]: a,b c
+owner:
+  name: mayor
+  status: ok
+checks[2]{name,status}:
+  git,ok
+  beads,warning
+empty[0]:
+`,
+		},
+		{
+			name: "nested arrays fall back to a list",
+			v: []toonCheck{
+				{Name: "git", Status: "error", Details: []string{"no remote", "detached"}},
+				{Name: "beads", Status: "ok"},
+			},
+			want: `[2]:
+  - name: git
+    status: error
+    details[2]: no remote,detached
+  - name: beads
+    status: ok
+`,
+		},
+		{
+			name: "ambiguous strings are quoted",
+			v: map[string]any{
+				"a": "",
+				"b": "42",
+				"c": "true",
+				"d": "x, y",
+				"e": "say \"hi\"\nbye",
+				"f": "- item",
+				"g": nil,
+			},
+			want: `a: ""
+b: "42"
+c: "true"
+d: "x, y"
+e: "say \"hi\"\nbye"
+f: "- item"
+g: null
+`,
+		},
+		{
+			name: "keys that need quotes",
+			v:    map[string]int{"my key": 1, "ok_key.x": 2},
+			want: "\"my key\": 1\nok_key.x: 2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.v, FormatTOON)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/steveyegge/gastown/internal/ui"
 )
 
@@ -50,6 +51,26 @@ var (
 	ArrowPrefix = Info.Render("→")
 )
 
+// Configure applies the color policy for this process. Styling is disabled
+// when noColor is set (the global --no-color flag) or when ui.ShouldUseColor
+// says the environment doesn't want it (NO_COLOR, CLICOLOR=0, non-TTY).
+// With styling disabled, every style's Render returns its input unchanged.
+func Configure(noColor bool) {
+	if noColor || !ui.ShouldUseColor() {
+		setColorProfile(termenv.Ascii)
+	}
+}
+
+// setColorProfile switches the lipgloss profile and re-renders the prefixes,
+// which are rendered once at package init under the startup profile.
+func setColorProfile(p termenv.Profile) {
+	lipgloss.SetColorProfile(p)
+	SuccessPrefix = Success.Render(ui.IconPass)
+	WarningPrefix = Warning.Render(ui.IconWarn)
+	ErrorPrefix = Error.Render(ui.IconFail)
+	ArrowPrefix = Info.Render("→")
+}
+
 // PrintWarning prints a warning message with consistent formatting.
 // The format and args work like fmt.Printf.
 func PrintWarning(format string, args ...interface{}) {
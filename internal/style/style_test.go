@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func TestStyleVariables(t *testing.T) {
@@ -167,3 +171,45 @@ func ExamplePrintWarning() {
 	PrintWarning("This is a warning message")
 	PrintWarning("Warning with value: %d", 42)
 }
+
+func TestConfigure_NoColorEnv(t *testing.T) {
+	// Start from a color profile, as on a TTY, and restore it afterwards
+	prev := lipgloss.ColorProfile()
+	setColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { setColorProfile(prev) })
+	if !strings.Contains(Bold.Render("x"), "\x1b[") {
+		t.Fatal("expected escape sequences under TrueColor")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	Configure(false)
+
+	for name, got := range map[string]string{
+		"Bold":          Bold.Render("landed"),
+		"Success":       Success.Render("landed"),
+		"Dim":           Dim.Render("landed"),
+		"SuccessPrefix": SuccessPrefix,
+		"ArrowPrefix":   ArrowPrefix,
+	} {
+		if strings.Contains(got, "\x1b") {
+			t.Errorf("%s rendered with escape sequences under NO_COLOR: %q", name, got)
+		}
+	}
+	if got := Bold.Render("landed"); got != "landed" {
+		t.Errorf("Bold.Render() = %q, want input unchanged", got)
+	}
+}
+
+func TestConfigure_NoColorFlag(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	setColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { setColorProfile(prev) })
+
+	// --no-color wins even when the environment forces color
+	t.Setenv("CLICOLOR_FORCE", "1")
+	Configure(true)
+
+	if got := Error.Render("failed"); got != "failed" {
+		t.Errorf("Error.Render() = %q, want input unchanged", got)
+	}
+}
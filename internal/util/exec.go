@@ -47,3 +47,17 @@ func ExecRun(workDir, cmd string, args ...string) error {
 
 	return nil
 }
+
+// Runner runs an external command in dir and returns its trimmed stdout.
+// Code that shells out takes a Runner so tests can substitute a fake.
+type Runner interface {
+	Run(dir, name string, args ...string) (stdout string, err error)
+}
+
+// ExecRunner is the Runner that executes commands for real via ExecWithOutput.
+type ExecRunner struct{}
+
+// Run implements Runner.
+func (ExecRunner) Run(dir, name string, args ...string) (string, error) {
+	return ExecWithOutput(dir, name, args...)
+}
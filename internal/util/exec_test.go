@@ -95,3 +95,17 @@ func TestExecWithOutput_StderrInError(t *testing.T) {
 		t.Errorf("expected error to contain stderr, got %q", err.Error())
 	}
 }
+
+func TestExecRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX echo")
+	}
+	var r Runner = ExecRunner{}
+	out, err := r.Run(".", "echo", "hello")
+	if err != nil || out != "hello" {
+		t.Errorf("Run() = %q, %v; want \"hello\", nil", out, err)
+	}
+	if _, err := r.Run(".", "false"); err == nil {
+		t.Error("expected error for failing command")
+	}
+}
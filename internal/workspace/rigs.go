@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// rigMarkers are paths whose presence marks a town subdirectory as a rig:
+// the shared bare repo, or the mayor's clone.
+var rigMarkers = []string{".repo.git", filepath.Join("mayor", "rig")}
+
+// DiscoverRigDirs returns the names of the directories directly under
+// townRoot that look like rigs on disk, whether or not they are registered
+// in mayor/rigs.json. Hidden directories are skipped. Names are sorted.
+func DiscoverRigDirs(townRoot string) ([]string, error) {
+	entries, err := os.ReadDir(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading town root: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		for _, marker := range rigMarkers {
+			if _, err := os.Stat(filepath.Join(townRoot, entry.Name(), marker)); err == nil {
+				names = append(names, entry.Name())
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// UnregisteredRigs returns the rig directories found by DiscoverRigDirs that
+// have no entry in rigsConfig. Such rigs are invisible to commands that only
+// read the registry; 'gt rig add <name> --adopt' registers them.
+func UnregisteredRigs(townRoot string, rigsConfig *config.RigsConfig) ([]string, error) {
+	found, err := DiscoverRigDirs(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var unregistered []string
+	for _, name := range found {
+		if rigsConfig != nil {
+			if _, ok := rigsConfig.Rigs[name]; ok {
+				continue
+			}
+		}
+		unregistered = append(unregistered, name)
+	}
+	return unregistered, nil
+}
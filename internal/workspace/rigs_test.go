@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// setupRigTown creates a town with a registered rig (bare repo), an
+// unregistered rig (mayor clone only), and directories that aren't rigs.
+func setupRigTown(t *testing.T) (string, *config.RigsConfig) {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{
+		"mayor",             // town mayor, not a rig
+		"deacon",            // no rig markers
+		"gastown/.repo.git", // registered rig
+		"fresh/mayor/rig",   // cloned by hand, never registered
+		".hidden/.repo.git", // hidden directories are skipped
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.repo.git"), nil, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	rigsConfig := &config.RigsConfig{Rigs: map[string]config.RigEntry{
+		"gastown": {GitURL: "https://example.com/gastown.git"},
+		"gone":    {GitURL: "https://example.com/gone.git"}, // registered, missing on disk
+	}}
+	return root, rigsConfig
+}
+
+func TestDiscoverRigDirs(t *testing.T) {
+	root, _ := setupRigTown(t)
+
+	got, err := DiscoverRigDirs(root)
+	if err != nil {
+		t.Fatalf("DiscoverRigDirs: %v", err)
+	}
+	if want := []string{"fresh", "gastown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DiscoverRigDirs = %v, want %v", got, want)
+	}
+}
+
+func TestUnregisteredRigs(t *testing.T) {
+	root, rigsConfig := setupRigTown(t)
+
+	got, err := UnregisteredRigs(root, rigsConfig)
+	if err != nil {
+		t.Fatalf("UnregisteredRigs: %v", err)
+	}
+	if want := []string{"fresh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnregisteredRigs = %v, want %v", got, want)
+	}
+
+	// With no registry every rig on disk is unregistered
+	got, err = UnregisteredRigs(root, nil)
+	if err != nil {
+		t.Fatalf("UnregisteredRigs: %v", err)
+	}
+	if want := []string{"fresh", "gastown"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UnregisteredRigs(nil) = %v, want %v", got, want)
+	}
+}